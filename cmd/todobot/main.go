@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -11,12 +12,14 @@ import (
 	"github.com/Kerhoff/TodoboT/internal/api"
 	"github.com/Kerhoff/TodoboT/internal/config"
 	"github.com/Kerhoff/TodoboT/internal/handlers"
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/repository/postgres"
 	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/storage"
 	"github.com/Kerhoff/TodoboT/internal/telegram"
+	"github.com/Kerhoff/TodoboT/internal/urlmeta"
 	"github.com/Kerhoff/TodoboT/pkg/logger"
-
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func main() {
@@ -28,6 +31,14 @@ func main() {
 	l := logger.New(cfg.LogLevel)
 	l.Info("Starting TodoboT...")
 
+	// Messages missing from a catalog are shown in the default language. The
+	// i18n tests fail on them; this is a backstop.
+	for _, lang := range i18n.Languages() {
+		if missing := i18n.Missing(lang); len(missing) > 0 {
+			l.Warnf("Language %q lacks %d messages: %v", lang, len(missing), missing)
+		}
+	}
+
 	// Database
 	db, err := config.NewDatabase(cfg.DatabaseURL, l)
 	if err != nil {
@@ -49,12 +60,26 @@ func main() {
 	buyingRepo := postgres.NewBuyingListRepository(db.DB)
 	wishListRepo := postgres.NewWishListRepository(db.DB)
 	reminderRepo := postgres.NewReminderRepository(db.DB)
+	occasionRepo := postgres.NewOccasionRepository(db.DB)
+	attachmentRepo := postgres.NewAttachmentRepository(db.DB)
+	searchRepo := postgres.NewSearchRepository(db.DB)
+	dialogRepo := postgres.NewDialogRepository(db.DB)
+
+	// Blob storage for uploaded files
+	blobs, err := storage.NewLocalStore(cfg.StorageDir)
+	if err != nil {
+		l.Fatalf("Failed to initialize storage: %v", err)
+	}
 
 	// Service layer
 	svc := service.New(db.DB, l,
 		userRepo, todoRepo, commentRepo, familyRepo,
-		calendarRepo, buyingRepo, wishListRepo, reminderRepo,
+		calendarRepo, buyingRepo, wishListRepo, reminderRepo, occasionRepo,
+		attachmentRepo, searchRepo, dialogRepo, blobs,
+		storage.NewTelegramFiles(cfg.TelegramToken),
+		urlmeta.NewHTTPFetcher(urlmeta.Options{}),
 	)
+	svc.DownloadMedia = cfg.DownloadMedia
 
 	// Telegram bot
 	bot, err := telegram.NewBot(cfg.TelegramToken, l)
@@ -62,37 +87,122 @@ func main() {
 		l.Fatalf("Failed to create Telegram bot: %v", err)
 	}
 
-	// Register command handlers
-	bot.RegisterCommand("start", handlers.NewStartHandler(l))
-	bot.RegisterCommand("help", handlers.NewHelpHandler(l))
+	// Register command handlers. Commands are listed in /help and in the
+	// command menus in the order they are registered.
+	bot.RegisterCommand("start", "", telegram.Everywhere, handlers.NewStartHandler(svc, l))
+	bot.RegisterCommand("help", "", telegram.Everywhere, handlers.NewHelpHandler(svc, l, bot))
+
+	// Keep family membership in sync with the group
+	bot.SetMemberHandler(handlers.NewMembershipHandler(svc, l))
 
 	// Todo handlers
-	bot.RegisterCommand("add", handlers.NewAddHandler(svc, l))
-	bot.RegisterCommand("list", handlers.NewListHandler(svc, l))
-	bot.RegisterCommand("done", handlers.NewDoneHandler(svc, l))
-	bot.RegisterCommand("delete", handlers.NewDeleteHandler(svc, l))
-	bot.RegisterCommand("my", handlers.NewMyHandler(svc, l))
+	bot.RegisterCommand("add", "todos", telegram.Everywhere, handlers.NewAddHandler(svc, l))
+	listHandler := handlers.NewListHandler(svc, l)
+	bot.RegisterCommand("list", "todos", telegram.Everywhere, listHandler)
+	bot.RegisterCallback("list", listHandler)
+	bot.RegisterCommand("done", "todos", telegram.Everywhere, handlers.NewDoneHandler(svc, l))
+	bot.RegisterCommand("cancel", "todos", telegram.Everywhere, handlers.NewCancelHandler(svc, l))
+	bot.RegisterCommand("reopen", "todos", telegram.Everywhere, handlers.NewReopenHandler(svc, l))
+	bot.RegisterCommand("show", "todos", telegram.Everywhere, handlers.NewShowHandler(svc, l))
+	bot.RegisterCommand("sub", "todos", telegram.Everywhere, handlers.NewSubHandler(svc, l))
+	bot.RegisterCommand("check", "todos", telegram.Everywhere, handlers.NewCheckHandler(svc, l))
+	bot.RegisterCommand("delete", "todos", telegram.Everywhere, handlers.NewDeleteHandler(svc, l))
+	deadlineHandler := handlers.NewDeadlineHandler(svc, l)
+	bot.RegisterCommand("deadline", "todos", telegram.Everywhere, deadlineHandler)
+	bot.RegisterCallback("deadline", deadlineHandler)
+	bot.RegisterCommand("my", "todos", telegram.Everywhere, handlers.NewMyHandler(svc, l))
+
+	// Chore handlers
+	bot.RegisterCommand("chore", "chores", telegram.Family, handlers.NewChoreAddHandler(svc, l))
+	bot.RegisterCommand("rotation", "chores", telegram.Family, handlers.NewRotationHandler(svc, l))
+	bot.RegisterCommand("chores", "chores", telegram.Family, handlers.NewChoresHandler(svc, l))
 
 	// Calendar handlers
-	bot.RegisterCommand("event", handlers.NewCalendarAddHandler(svc, l))
-	bot.RegisterCommand("events", handlers.NewCalendarListHandler(svc, l))
-	bot.RegisterCommand("delevent", handlers.NewCalendarDeleteHandler(svc, l))
+	bot.RegisterCommand("event", "calendar", telegram.Everywhere, handlers.NewCalendarAddHandler(svc, l))
+	eventsHandler := handlers.NewCalendarListHandler(svc, l)
+	bot.RegisterCommand("events", "calendar", telegram.Everywhere, eventsHandler)
+	bot.RegisterCallback("events", eventsHandler)
+	bot.RegisterCommand("delevent", "calendar", telegram.Everywhere, handlers.NewCalendarDeleteHandler(svc, l))
 
 	// Buying list handlers
-	bot.RegisterCommand("buy", handlers.NewBuyAddHandler(svc, l))
-	bot.RegisterCommand("buylist", handlers.NewBuyListHandler(svc, l))
-	bot.RegisterCommand("bought", handlers.NewBuyDoneHandler(svc, l))
-	bot.RegisterCommand("buyclear", handlers.NewBuyClearHandler(svc, l))
+	bot.RegisterCommand("buy", "shopping", telegram.Everywhere, handlers.NewBuyAddHandler(svc, l))
+	buyListHandler := handlers.NewBuyListHandler(svc, l)
+	bot.RegisterCommand("buylist", "shopping", telegram.Everywhere, buyListHandler)
+	bot.RegisterCallback("buylist", buyListHandler)
+	bot.RegisterCommand("bought", "shopping", telegram.Everywhere, handlers.NewBuyDoneHandler(svc, l))
+	bot.RegisterCommand("buyclear", "shopping", telegram.Everywhere, handlers.NewBuyClearHandler(svc, l))
+	bot.RegisterCommand("spent", "shopping", telegram.Everywhere, handlers.NewSpentHandler(svc, l))
+	bot.RegisterCommand("receipt", "shopping", telegram.Everywhere, handlers.NewReceiptHandler(svc, l))
 
 	// Wish list handlers
-	bot.RegisterCommand("wish", handlers.NewWishAddHandler(svc, l))
-	bot.RegisterCommand("wishlist", handlers.NewWishListHandler(svc, l))
-	bot.RegisterCommand("reserve", handlers.NewWishReserveHandler(svc, l))
+	bot.RegisterCommand("wish", "wishes", telegram.Everywhere, handlers.NewWishAddHandler(svc, l))
+	wishListHandler := handlers.NewWishListHandler(svc, l)
+	bot.RegisterCommand("wishlist", "wishes", telegram.Everywhere, wishListHandler)
+	bot.RegisterCallback("wishlist", wishListHandler)
+	bot.RegisterCommand("reserve", "wishes", telegram.Everywhere, handlers.NewWishReserveHandler(svc, l))
+	bot.RegisterCommand("unreserve", "wishes", telegram.Everywhere, handlers.NewWishUnreserveHandler(svc, l))
+	bot.RegisterCommand("editwish", "wishes", telegram.Everywhere, handlers.NewWishEditHandler(svc, l))
+	bot.RegisterCommand("delwish", "wishes", telegram.Everywhere, handlers.NewWishDeleteHandler(svc, l))
+	bot.RegisterCommand("pledge", "wishes", telegram.Everywhere, handlers.NewPledgeHandler(svc, l))
+	bot.RegisterCommand("unpledge", "wishes", telegram.Everywhere, handlers.NewUnpledgeHandler(svc, l))
+	bot.RegisterCommand("purchased", "wishes", telegram.Everywhere, handlers.NewPurchasedHandler(svc, l))
+	bot.RegisterCommand("wishhint", "wishes", telegram.Personal, handlers.NewWishHintHandler(svc, l))
+	bot.RegisterCommand("wishfor", "wishes", telegram.Everywhere, handlers.NewWishForHandler(svc, l))
+
+	// Occasion handlers
+	bot.RegisterCommand("birthday", "occasions", telegram.Everywhere, handlers.NewBirthdayHandler(svc, l))
+	bot.RegisterCommand("occasion", "occasions", telegram.Everywhere, handlers.NewOccasionAddHandler(svc, l))
+	bot.RegisterCommand("occasions", "occasions", telegram.Everywhere, handlers.NewOccasionListHandler(svc, l))
+	bot.RegisterCommand("deloccasion", "occasions", telegram.Everywhere, handlers.NewOccasionDeleteHandler(svc, l))
 
 	// Reminder handlers
-	bot.RegisterCommand("remind", handlers.NewRemindHandler(svc, l))
-	bot.RegisterCommand("reminders", handlers.NewRemindersListHandler(svc, l))
-	bot.RegisterCommand("delremind", handlers.NewRemindDeleteHandler(svc, l))
+	bot.RegisterCommand("remind", "reminders", telegram.Everywhere, handlers.NewRemindHandler(svc, l))
+	snoozeHandler := handlers.NewSnoozeHandler(svc, l)
+	bot.RegisterCallback("snooze", snoozeHandler)
+	bot.RegisterCommand("reminders", "reminders", telegram.Everywhere, handlers.NewRemindersListHandler(svc, l))
+	bot.RegisterCommand("delremind", "reminders", telegram.Everywhere, handlers.NewRemindDeleteHandler(svc, l))
+
+	// Search handlers
+	searchHandler := handlers.NewSearchHandler(svc, l)
+	bot.RegisterCommand("search", "search", telegram.Everywhere, searchHandler)
+	bot.RegisterCallback("search", searchHandler)
+
+	// Inline mode: "@TodoboT milk" in any chat
+	inlineHandler := handlers.NewInlineHandler(svc, l)
+	bot.SetInlineHandler(inlineHandler)
+	bot.RegisterCallback("inline", inlineHandler)
+
+	// Reply in the language picked with /lang or the user's Telegram app.
+	// Only admins can change it in groups.
+	languageHandler := handlers.NewLanguageHandler(svc, l)
+	bot.RegisterCommand("lang", "language", telegram.Personal|telegram.Admin, languageHandler)
+	bot.RegisterCallback("lang", languageHandler)
+	bot.SetLocalizer(languageHandler)
+
+	// Questions asked step by step, e.g. by /event without arguments
+	dialogHandler := handlers.NewDialogHandler(svc, l)
+	bot.AddReplyHandler(dialogHandler)
+	bot.RegisterCallback("dialog", dialogHandler)
+
+	// Offers to save what plain messages ask for, once turned on with /capture
+	captureHandler := handlers.NewCaptureHandler(svc, l)
+	bot.RegisterCommand("capture", "capture", telegram.Admin, captureHandler)
+	bot.AddReplyHandler(captureHandler)
+	bot.RegisterCallback("capture", captureHandler)
+
+	// Family handlers
+	familyHandler := handlers.NewFamilyHandler(svc, l)
+	bot.RegisterCommand("family", "family", telegram.Personal, familyHandler)
+	bot.RegisterCallback("family", familyHandler)
+	bot.RegisterCommand("promote", "family", telegram.Admin, handlers.NewPromoteHandler(svc, l))
+	bot.RegisterCommand("demote", "family", telegram.Admin, handlers.NewDemoteHandler(svc, l))
+	bot.RegisterCommand("link", "family", telegram.Admin, handlers.NewLinkHandler(svc, l))
+	bot.RegisterCommand("join", "family", telegram.Admin, handlers.NewJoinHandler(svc, l))
+
+	// Command menus shown by Telegram clients
+	if err := bot.PublishCommands(); err != nil {
+		l.Errorf("Failed to publish commands: %v", err)
+	}
 
 	// Context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -107,20 +217,44 @@ func main() {
 		cancel()
 	}()
 
-	// Start reminder scheduler
-	go svc.StartReminderScheduler(ctx, func(chatID int64, text string) {
-		msg := tgbotapi.NewMessage(chatID, text)
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.SendRaw(msg)
+	// Start reminder scheduler (also announces upcoming occasions)
+	go svc.StartReminderScheduler(ctx, func(chatID int64, text string, reminder *models.Reminder) {
+		var err error
+		if reminder != nil {
+			err = bot.SendMessageWithKeyboard(chatID, text, snoozeHandler.Keyboard(reminder))
+		} else {
+			err = bot.SendMessage(chatID, text)
+		}
+		if err != nil {
+			l.WithError(err).WithField("chat_id", chatID).Error("Failed to send reminder")
+		}
 	})
 
 	// Start HTTP server for web UI
-	apiServer := api.NewServer(svc, l)
+	apiServer := api.NewServer(svc, cfg.TelegramToken, l)
 	httpServer := &http.Server{
 		Addr:    ":" + cfg.Port,
 		Handler: apiServer.Handler(),
 	}
 
+	// With WEBHOOK_URL set, Telegram posts updates to its path on the same
+	// server instead of the bot polling for them. Anyone could post there,
+	// so the path has to be one only Telegram is told.
+	if cfg.WebhookURL != "" {
+		webhookURL, err := url.Parse(cfg.WebhookURL)
+		if err != nil || webhookURL.Path == "" || webhookURL.Path == "/" {
+			l.Fatalf("WEBHOOK_URL must be a URL with a path to receive updates on: %q", cfg.WebhookURL)
+		}
+		if err := bot.SetWebhook(cfg.WebhookURL); err != nil {
+			l.Fatalf("Failed to set webhook: %v", err)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(webhookURL.Path, bot.WebhookHandler())
+		mux.Handle("/", apiServer.Handler())
+		httpServer.Handler = mux
+	}
+
 	go func() {
 		l.Infof("HTTP server listening on :%s", cfg.Port)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -129,11 +263,13 @@ func main() {
 	}()
 
 	// Start Telegram bot polling
-	go func() {
-		if err := bot.Start(ctx); err != nil {
-			l.Errorf("Bot error: %v", err)
-		}
-	}()
+	if cfg.WebhookURL == "" {
+		go func() {
+			if err := bot.Start(ctx); err != nil {
+				l.Errorf("Bot error: %v", err)
+			}
+		}()
+	}
 
 	l.Info("TodoboT started successfully")
 
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+)
+
+// initDataHeader carries the Telegram Web App init data of the caller.
+const initDataHeader = "X-Telegram-Init-Data"
+
+// initDataMaxAge bounds how long signed init data is accepted.
+const initDataMaxAge = 24 * time.Hour
+
+// webAppUser is the user object embedded in Telegram Web App init data.
+type webAppUser struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// validateInitData verifies the signature of Telegram Web App init data and
+// returns the user it was issued for. See
+// https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func validateInitData(initData, botToken string, now time.Time) (*webAppUser, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, fmt.Errorf("malformed init data: %w", err)
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, fmt.Errorf("init data is not signed")
+	}
+
+	pairs := make([]string, 0, len(values))
+	for key := range values {
+		if key == "hash" {
+			continue
+		}
+		pairs = append(pairs, key+"="+values.Get(key))
+	}
+	sort.Strings(pairs)
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+	mac := hmac.New(sha256.New, secret.Sum(nil))
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+
+	expected, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(mac.Sum(nil), expected) {
+		return nil, fmt.Errorf("invalid init data signature")
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth_date")
+	}
+	if now.Sub(time.Unix(authDate, 0)) > initDataMaxAge {
+		return nil, fmt.Errorf("init data has expired")
+	}
+
+	var user webAppUser
+	if err := json.Unmarshal([]byte(values.Get("user")), &user); err != nil || user.ID == 0 {
+		return nil, fmt.Errorf("init data has no user")
+	}
+
+	return &user, nil
+}
+
+// requireUser authenticates the request through its Telegram Web App init
+// data. It writes a 401 response and returns false when the caller cannot be
+// identified.
+func (s *Server) requireUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	if r.Header.Get(initDataHeader) == "" {
+		s.respondError(w, http.StatusUnauthorized, "authentication required")
+		return nil, false
+	}
+	return s.optionalUser(w, r)
+}
+
+// authorize checks through the service permission rules that the user may
+// change something in the chat's family owned by ownerIDs. It writes a 403
+// response and returns false otherwise.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, user *models.User, chatID int64, ownerIDs ...int64) bool {
+	err := s.svc.AuthorizeChat(r.Context(), chatID, user.ID, ownerIDs...)
+	if errors.Is(err, service.ErrForbidden) {
+		s.respondError(w, http.StatusForbidden, "not allowed")
+		return false
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check permissions")
+		s.respondError(w, http.StatusInternalServerError, "failed to check permissions")
+		return false
+	}
+	return true
+}
+
+// requireMember writes a 404 response and returns false unless the user
+// belongs to the family. Not found is used so that outsiders cannot probe
+// which IDs exist.
+func (s *Server) requireMember(w http.ResponseWriter, r *http.Request, user *models.User, familyID int64, what string) bool {
+	member, err := s.svc.IsFamilyMember(r.Context(), familyID, user.ID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to check family membership")
+		s.respondError(w, http.StatusInternalServerError, "failed to check permissions")
+		return false
+	}
+	if !member {
+		s.respondError(w, http.StatusNotFound, what+" not found")
+		return false
+	}
+	return true
+}
+
+// optionalUser is like requireUser but lets anonymous requests through with
+// a nil user. Init data that is present but invalid is still rejected.
+func (s *Server) optionalUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	initData := r.Header.Get(initDataHeader)
+	if initData == "" {
+		return nil, true
+	}
+
+	tgUser, err := validateInitData(initData, s.botToken, time.Now())
+	if err != nil {
+		s.logger.WithError(err).Debug("rejected web app init data")
+		s.respondError(w, http.StatusUnauthorized, "invalid authentication data")
+		return nil, false
+	}
+
+	user, err := s.svc.EnsureUser(r.Context(), tgUser.ID, tgUser.Username, tgUser.FirstName, tgUser.LastName)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to resolve user")
+		s.respondError(w, http.StatusInternalServerError, "failed to resolve user")
+		return nil, false
+	}
+
+	return user, true
+}
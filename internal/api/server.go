@@ -2,13 +2,19 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Kerhoff/TodoboT/internal/i18n"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/repository"
 	"github.com/Kerhoff/TodoboT/internal/service"
@@ -17,14 +23,16 @@ import (
 
 // Server provides the HTTP API and serves the web UI.
 type Server struct {
-	svc    *service.Service
-	logger *logrus.Logger
-	mux    *http.ServeMux
+	svc      *service.Service
+	botToken string
+	logger   *logrus.Logger
+	mux      *http.ServeMux
 }
 
-// NewServer creates a Server, registers all routes, and returns it.
-func NewServer(svc *service.Service, logger *logrus.Logger) *Server {
-	s := &Server{svc: svc, logger: logger, mux: http.NewServeMux()}
+// NewServer creates a Server, registers all routes, and returns it. The bot
+// token is used to verify Telegram Web App init data on protected routes.
+func NewServer(svc *service.Service, botToken string, logger *logrus.Logger) *Server {
+	s := &Server{svc: svc, botToken: botToken, logger: logger, mux: http.NewServeMux()}
 	s.routes()
 	return s
 }
@@ -43,6 +51,7 @@ func (s *Server) routes() {
 	s.mux.HandleFunc("GET /api/todos", s.handleGetTodos)
 	s.mux.HandleFunc("POST /api/todos", s.handleCreateTodo)
 	s.mux.HandleFunc("PUT /api/todos/{id}/done", s.handleCompleteTodo)
+	s.mux.HandleFunc("GET /api/todos/{id}/history", s.handleGetTodoHistory)
 	s.mux.HandleFunc("DELETE /api/todos/{id}", s.handleDeleteTodo)
 
 	// API – Calendar events
@@ -55,11 +64,22 @@ func (s *Server) routes() {
 	s.mux.HandleFunc("POST /api/buying", s.handleAddBuyingItem)
 	s.mux.HandleFunc("PUT /api/buying/{id}/bought", s.handleMarkBought)
 	s.mux.HandleFunc("DELETE /api/buying/{id}", s.handleDeleteBuyingItem)
+	s.mux.HandleFunc("GET /api/buying/history", s.handleGetBuyingHistory)
+	s.mux.HandleFunc("GET /api/buying/stats", s.handleGetBuyingStats)
+	s.mux.HandleFunc("GET /api/buying/trips", s.handleGetShoppingTrips)
+
+	// API – Attachments
+	s.mux.HandleFunc("GET /api/attachments/{id}", s.handleGetAttachment)
 
 	// API – Wish list
 	s.mux.HandleFunc("GET /api/wishes", s.handleGetWishes)
 	s.mux.HandleFunc("POST /api/wishes", s.handleAddWish)
+	s.mux.HandleFunc("PUT /api/wishes/{id}", s.handleUpdateWish)
 	s.mux.HandleFunc("PUT /api/wishes/{id}/reserve", s.handleReserveWish)
+	s.mux.HandleFunc("PUT /api/wishes/{id}/unreserve", s.handleUnreserveWish)
+	s.mux.HandleFunc("PUT /api/wishes/{id}/pledge", s.handlePledgeWish)
+	s.mux.HandleFunc("DELETE /api/wishes/{id}/pledge", s.handleUnpledgeWish)
+	s.mux.HandleFunc("PUT /api/wishes/{id}/purchased", s.handlePurchasedWish)
 	s.mux.HandleFunc("DELETE /api/wishes/{id}", s.handleDeleteWish)
 
 	// API – Reminders
@@ -67,6 +87,9 @@ func (s *Server) routes() {
 	s.mux.HandleFunc("POST /api/reminders", s.handleCreateReminder)
 	s.mux.HandleFunc("DELETE /api/reminders/{id}", s.handleDeleteReminder)
 
+	// API – Search
+	s.mux.HandleFunc("GET /api/search", s.handleSearch)
+
 	// Static files & web UI
 	s.mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.Dir("web/static"))))
 	s.mux.HandleFunc("GET /", s.handleIndex)
@@ -157,22 +180,22 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 // ---------------------------------------------------------------------------
 
 type createTodoRequest struct {
-	Title        string `json:"title"`
-	Description  string `json:"description"`
-	Priority     string `json:"priority"`
-	Deadline     string `json:"deadline"` // RFC 3339
-	CreatedByID  int64  `json:"created_by_id"`
-	AssignedToID *int64 `json:"assigned_to_id"`
-	ChatID       int64  `json:"chat_id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Priority     string   `json:"priority"`
+	Deadline     string   `json:"deadline"` // RFC 3339
+	CreatedByID  int64    `json:"created_by_id"`
+	AssignedToID *int64   `json:"assigned_to_id"`
+	ChatID       int64    `json:"chat_id"`
+	Tags         []string `json:"tags"`
 }
 
-func (s *Server) handleGetTodos(w http.ResponseWriter, r *http.Request) {
-	chatID, ok := s.requireChatID(w, r)
-	if !ok {
-		return
-	}
-
-	q := r.URL.Query()
+// parseTodoFilters reads the todo filters from the query string: status,
+// priority, tags (comma separated or repeated tag), assigned_to, created_by,
+// deadline_from, deadline_to (RFC 3339 or YYYY-MM-DD, a date including the
+// whole day), overdue, sort, limit and offset. It returns a message describing
+// the first invalid parameter.
+func parseTodoFilters(q url.Values) (repository.TodoFilters, string) {
 	var filters repository.TodoFilters
 
 	if status := q.Get("status"); status != "" {
@@ -183,6 +206,59 @@ func (s *Server) handleGetTodos(w http.ResponseWriter, r *http.Request) {
 		pr := models.TodoPriority(priority)
 		filters.Priority = &pr
 	}
+	for _, value := range append(q["tags"], q["tag"]...) {
+		for _, raw := range strings.Split(value, ",") {
+			if strings.TrimSpace(raw) == "" {
+				continue
+			}
+			tag, ok := models.NormalizeTag(strings.TrimSpace(raw))
+			if !ok {
+				return filters, fmt.Sprintf("invalid tag %q", raw)
+			}
+			if !slices.Contains(filters.Tags, tag) {
+				filters.Tags = append(filters.Tags, tag)
+			}
+		}
+	}
+	for name, dst := range map[string]**int64{"assigned_to": &filters.AssignedToID, "created_by": &filters.CreatedByID} {
+		if raw := q.Get(name); raw != "" {
+			id, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return filters, name + " must be an integer"
+			}
+			*dst = &id
+		}
+	}
+	for name, dst := range map[string]**time.Time{"deadline_from": &filters.DeadlineFrom, "deadline_to": &filters.DeadlineTo} {
+		raw := q.Get(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			if t, err = time.ParseInLocation("2006-01-02", raw, time.Local); err != nil {
+				return filters, name + " must be RFC 3339 or YYYY-MM-DD"
+			}
+			if name == "deadline_to" {
+				t = t.AddDate(0, 0, 1)
+			}
+		}
+		*dst = &t
+	}
+	switch sort := repository.TodoSort(q.Get("sort")); sort {
+	case "":
+	case repository.TodoSortCreated, repository.TodoSortDeadline, repository.TodoSortPriority:
+		filters.Sort = sort
+	default:
+		return filters, "sort must be created, deadline or priority"
+	}
+	if overdue := q.Get("overdue"); overdue != "" {
+		v, err := strconv.ParseBool(overdue)
+		if err != nil {
+			return filters, "overdue must be true or false"
+		}
+		filters.OverdueOnly = v
+	}
 	if limit := q.Get("limit"); limit != "" {
 		if v, err := strconv.Atoi(limit); err == nil {
 			filters.Limit = v
@@ -193,6 +269,20 @@ func (s *Server) handleGetTodos(w http.ResponseWriter, r *http.Request) {
 			filters.Offset = v
 		}
 	}
+	return filters, ""
+}
+
+func (s *Server) handleGetTodos(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := s.requireChatID(w, r)
+	if !ok {
+		return
+	}
+
+	filters, problem := parseTodoFilters(r.URL.Query())
+	if problem != "" {
+		s.respondError(w, http.StatusBadRequest, problem)
+		return
+	}
 
 	todos, err := s.svc.Todos.GetByChatID(r.Context(), chatID, filters)
 	if err != nil {
@@ -201,6 +291,20 @@ func (s *Server) handleGetTodos(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ids := make([]int64, len(todos))
+	for i, todo := range todos {
+		ids[i] = todo.ID
+	}
+	attachments, err := s.svc.LoadAttachments(r.Context(), models.AttachmentEntityTodo, ids)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get todo attachments")
+		s.respondError(w, http.StatusInternalServerError, "failed to get todos")
+		return
+	}
+	for _, todo := range todos {
+		todo.Attachments = attachments[todo.ID]
+	}
+
 	s.respondJSON(w, http.StatusOK, todos)
 }
 
@@ -238,6 +342,16 @@ func (s *Server) handleCreateTodo(w http.ResponseWriter, r *http.Request) {
 		AssignedToID: req.AssignedToID,
 		ChatID:       req.ChatID,
 	}
+	for _, raw := range req.Tags {
+		tag, ok := models.NormalizeTag(strings.TrimSpace(raw))
+		if !ok {
+			s.respondError(w, http.StatusBadRequest, fmt.Sprintf("invalid tag %q", raw))
+			return
+		}
+		if !slices.Contains(todo.Tags, tag) {
+			todo.Tags = append(todo.Tags, tag)
+		}
+	}
 
 	if req.Deadline != "" {
 		t, err := time.Parse(time.RFC3339, req.Deadline)
@@ -258,42 +372,103 @@ func (s *Server) handleCreateTodo(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusCreated, created)
 }
 
-func (s *Server) handleCompleteTodo(w http.ResponseWriter, r *http.Request) {
+// loadTodo fetches the todo named in the path, writing an error response
+// and returning nil when it cannot.
+func (s *Server) loadTodo(w http.ResponseWriter, r *http.Request) *models.Todo {
 	id, err := pathID(r)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, "invalid todo id")
-		return
+		return nil
 	}
 
 	todo, err := s.svc.Todos.GetByID(r.Context(), id)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get todo")
+		s.respondError(w, http.StatusInternalServerError, "failed to get todo")
+		return nil
+	}
+	if todo == nil {
 		s.respondError(w, http.StatusNotFound, "todo not found")
+		return nil
+	}
+	return todo
+}
+
+func (s *Server) handleCompleteTodo(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
 		return
 	}
 
-	todo.Status = models.TodoStatusCompleted
-	now := time.Now()
-	todo.UpdatedAt = now
+	todo := s.loadTodo(w, r)
+	if todo == nil {
+		return
+	}
 
-	updated, err := s.svc.Todos.Update(r.Context(), todo)
-	if err != nil {
+	_, err := s.svc.TransitionTodo(r.Context(), todo, user.ID, models.TodoStatusCompleted)
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		s.respondError(w, http.StatusForbidden, "not allowed")
+		return
+	case errors.Is(err, service.ErrTodoTransition):
+		s.respondError(w, http.StatusConflict, fmt.Sprintf("todo is %s", todo.Status))
+		return
+	case err != nil:
 		s.logger.WithError(err).Error("failed to complete todo")
 		s.respondError(w, http.StatusInternalServerError, "failed to complete todo")
 		return
 	}
 
-	s.respondJSON(w, http.StatusOK, updated)
+	s.respondJSON(w, http.StatusOK, todo)
 }
 
-func (s *Server) handleDeleteTodo(w http.ResponseWriter, r *http.Request) {
-	id, err := pathID(r)
+func (s *Server) handleGetTodoHistory(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	todo := s.loadTodo(w, r)
+	if todo == nil {
+		return
+	}
+
+	var familyID int64
+	if family, err := s.svc.Families.GetByChatID(r.Context(), todo.ChatID); err == nil && family != nil {
+		familyID = family.ID
+	}
+	if !s.requireMember(w, r, user, familyID, "todo") {
+		return
+	}
+
+	events, err := s.svc.TodoHistory(r.Context(), todo.ID)
 	if err != nil {
-		s.respondError(w, http.StatusBadRequest, "invalid todo id")
+		s.logger.WithError(err).Error("failed to get todo history")
+		s.respondError(w, http.StatusInternalServerError, "failed to get todo history")
+		return
+	}
+	if events == nil {
+		events = []*models.TodoEvent{}
+	}
+
+	s.respondJSON(w, http.StatusOK, events)
+}
+
+func (s *Server) handleDeleteTodo(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
 		return
 	}
 
-	if err := s.svc.Todos.Delete(r.Context(), id); err != nil {
+	todo := s.loadTodo(w, r)
+	if todo == nil {
+		return
+	}
+	if !s.authorize(w, r, user, todo.ChatID, todo.CreatedByID) {
+		return
+	}
+
+	if err := s.svc.Todos.Delete(r.Context(), todo.ID); err != nil {
 		s.logger.WithError(err).Error("failed to delete todo")
 		s.respondError(w, http.StatusInternalServerError, "failed to delete todo")
 		return
@@ -347,9 +522,49 @@ func (s *Server) handleGetEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Occasions are yearly, so they are generated for the requested range
+	// (today until a year from now by default) and merged in.
+	family, err := s.svc.Families.GetByChatID(r.Context(), chatID)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get family")
+		s.respondError(w, http.StatusInternalServerError, "failed to get events")
+		return
+	}
+	if family != nil {
+		from := parseEventDay(filters.From, time.Now())
+		to := parseEventDay(filters.To, from.AddDate(1, 0, 0))
+
+		occasions, err := s.svc.OccasionEvents(r.Context(), i18n.Default, family.ID, from, to)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to get occasions")
+			s.respondError(w, http.StatusInternalServerError, "failed to get events")
+			return
+		}
+		events = append(events, occasions...)
+		slices.SortStableFunc(events, func(a, b *models.CalendarEvent) int {
+			return a.StartTime.Compare(b.StartTime)
+		})
+		if filters.Limit > 0 && len(events) > filters.Limit {
+			events = events[:filters.Limit]
+		}
+	}
+
 	s.respondJSON(w, http.StatusOK, events)
 }
 
+// parseEventDay reads the date part of a from/to filter such as
+// "2025-01-15" or "2025-01-15 14:00:00", falling back to def.
+func parseEventDay(value *string, def time.Time) time.Time {
+	if value == nil || len(*value) < 10 {
+		return def
+	}
+	t, err := time.ParseInLocation("2006-01-02", (*value)[:10], time.Local)
+	if err != nil {
+		return def
+	}
+	return t
+}
+
 func (s *Server) handleCreateEvent(w http.ResponseWriter, r *http.Request) {
 	var req createEventRequest
 	if ok, msg := s.decodeJSON(r, &req); !ok {
@@ -412,12 +627,31 @@ func (s *Server) handleCreateEvent(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleDeleteEvent(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
 	id, err := pathID(r)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, "invalid event id")
 		return
 	}
 
+	event, err := s.svc.Calendar.GetByID(r.Context(), id)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get event")
+		s.respondError(w, http.StatusInternalServerError, "failed to get event")
+		return
+	}
+	if event == nil {
+		s.respondError(w, http.StatusNotFound, "event not found")
+		return
+	}
+	if !s.authorize(w, r, user, event.ChatID, event.CreatedByID) {
+		return
+	}
+
 	if err := s.svc.Calendar.Delete(r.Context(), id); err != nil {
 		s.logger.WithError(err).Error("failed to delete event")
 		s.respondError(w, http.StatusInternalServerError, "failed to delete event")
@@ -434,12 +668,13 @@ func (s *Server) handleDeleteEvent(w http.ResponseWriter, r *http.Request) {
 type addBuyingItemRequest struct {
 	Name      string `json:"name"`
 	Quantity  string `json:"quantity"`
+	Category  string `json:"category"`
 	AddedByID int64  `json:"added_by_id"`
 	ChatID    int64  `json:"chat_id"`
 }
 
 type markBoughtRequest struct {
-	BoughtByID int64 `json:"bought_by_id"`
+	Price *float64 `json:"price"`
 }
 
 func (s *Server) handleGetBuyingItems(w http.ResponseWriter, r *http.Request) {
@@ -465,6 +700,20 @@ func (s *Server) handleGetBuyingItems(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	attachments, err := s.svc.LoadAttachments(r.Context(), models.AttachmentEntityBuyingItem, ids)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get buying item attachments")
+		s.respondError(w, http.StatusInternalServerError, "failed to get buying items")
+		return
+	}
+	for _, item := range items {
+		item.Attachments = attachments[item.ID]
+	}
+
 	s.respondJSON(w, http.StatusOK, items)
 }
 
@@ -509,6 +758,7 @@ func (s *Server) handleAddBuyingItem(w http.ResponseWriter, r *http.Request) {
 		BuyingListID: list.ID,
 		Name:         strings.TrimSpace(req.Name),
 		Quantity:     strings.TrimSpace(req.Quantity),
+		Category:     strings.ToLower(strings.TrimSpace(req.Category)),
 		AddedByID:    req.AddedByID,
 	}
 
@@ -522,10 +772,42 @@ func (s *Server) handleAddBuyingItem(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusCreated, created)
 }
 
-func (s *Server) handleMarkBought(w http.ResponseWriter, r *http.Request) {
+// loadBuyingItem fetches the buying item named in the path and makes sure
+// the user belongs to the family whose list it is on. It writes an error
+// response and returns nil otherwise.
+func (s *Server) loadBuyingItem(w http.ResponseWriter, r *http.Request, user *models.User) *models.BuyingItem {
 	id, err := pathID(r)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, "invalid buying item id")
+		return nil
+	}
+
+	item, err := s.svc.Buying.GetItemByID(r.Context(), id)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get buying item")
+		s.respondError(w, http.StatusInternalServerError, "failed to get buying item")
+		return nil
+	}
+	if item == nil {
+		s.respondError(w, http.StatusNotFound, "buying item not found")
+		return nil
+	}
+
+	list, err := s.svc.Buying.GetListByID(r.Context(), item.BuyingListID)
+	if err != nil || list == nil {
+		s.logger.WithError(err).Error("failed to get buying list")
+		s.respondError(w, http.StatusInternalServerError, "failed to get buying item")
+		return nil
+	}
+	if !s.requireMember(w, r, user, list.FamilyID, "buying item") {
+		return nil
+	}
+	return item
+}
+
+func (s *Server) handleMarkBought(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
 		return
 	}
 
@@ -534,12 +816,17 @@ func (s *Server) handleMarkBought(w http.ResponseWriter, r *http.Request) {
 		s.respondError(w, http.StatusBadRequest, msg)
 		return
 	}
-	if req.BoughtByID == 0 {
-		s.respondError(w, http.StatusBadRequest, "bought_by_id is required")
+	if req.Price != nil && *req.Price < 0 {
+		s.respondError(w, http.StatusBadRequest, "price must not be negative")
+		return
+	}
+
+	item := s.loadBuyingItem(w, r, user)
+	if item == nil {
 		return
 	}
 
-	if err := s.svc.Buying.MarkBought(r.Context(), id, req.BoughtByID); err != nil {
+	if err := s.svc.Buying.MarkBought(r.Context(), item.ID, user.ID, req.Price); err != nil {
 		s.logger.WithError(err).Error("failed to mark item as bought")
 		s.respondError(w, http.StatusInternalServerError, "failed to mark item as bought")
 		return
@@ -549,13 +836,17 @@ func (s *Server) handleMarkBought(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleDeleteBuyingItem(w http.ResponseWriter, r *http.Request) {
-	id, err := pathID(r)
-	if err != nil {
-		s.respondError(w, http.StatusBadRequest, "invalid buying item id")
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	item := s.loadBuyingItem(w, r, user)
+	if item == nil {
 		return
 	}
 
-	if err := s.svc.Buying.DeleteItem(r.Context(), id); err != nil {
+	if err := s.svc.Buying.DeleteItem(r.Context(), item.ID); err != nil {
 		s.logger.WithError(err).Error("failed to delete buying item")
 		s.respondError(w, http.StatusInternalServerError, "failed to delete buying item")
 		return
@@ -564,6 +855,190 @@ func (s *Server) handleDeleteBuyingItem(w http.ResponseWriter, r *http.Request)
 	s.respondJSON(w, http.StatusNoContent, nil)
 }
 
+func (s *Server) handleGetBuyingHistory(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := s.requireChatID(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	limit, offset := 50, 0
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if v, err := strconv.Atoi(q.Get("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	list, err := s.svc.Buying.GetListByChatID(r.Context(), chatID)
+	if err != nil || list == nil {
+		s.respondJSON(w, http.StatusOK, []*models.BuyingItem{})
+		return
+	}
+
+	items, err := s.svc.Buying.GetHistory(r.Context(), list.ID, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get purchase history")
+		s.respondError(w, http.StatusInternalServerError, "failed to get purchase history")
+		return
+	}
+	if items == nil {
+		items = []*models.BuyingItem{}
+	}
+
+	s.respondJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) handleGetBuyingStats(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := s.requireChatID(w, r)
+	if !ok {
+		return
+	}
+
+	months := 6
+	if raw := r.URL.Query().Get("months"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > 24 {
+			s.respondError(w, http.StatusBadRequest, "months must be an integer between 1 and 24")
+			return
+		}
+		months = v
+	}
+	since := service.SpendingPeriodStart(time.Now(), months)
+
+	list, err := s.svc.Buying.GetListByChatID(r.Context(), chatID)
+	if err != nil || list == nil {
+		s.respondJSON(w, http.StatusOK, &models.SpendingStats{
+			Since:      since,
+			ByMonth:    []models.SpendingBucket{},
+			ByMember:   []models.SpendingBucket{},
+			ByCategory: []models.SpendingBucket{},
+		})
+		return
+	}
+
+	stats, err := s.svc.Buying.GetSpendingStats(r.Context(), list.ID, since)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get spending stats")
+		s.respondError(w, http.StatusInternalServerError, "failed to get spending stats")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) handleGetShoppingTrips(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := s.requireChatID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+
+	list, err := s.svc.Buying.GetListByChatID(r.Context(), chatID)
+	if err != nil || list == nil {
+		s.respondJSON(w, http.StatusOK, []*models.ShoppingTrip{})
+		return
+	}
+
+	trips, err := s.svc.Buying.GetTrips(r.Context(), list.ID, limit)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get shopping trips")
+		s.respondError(w, http.StatusInternalServerError, "failed to get shopping trips")
+		return
+	}
+	if trips == nil {
+		trips = []*models.ShoppingTrip{}
+	}
+
+	for _, trip := range trips {
+		attachments, err := s.svc.Attachments.GetByEntity(r.Context(), models.AttachmentEntityShoppingTrip, trip.ID)
+		if err != nil {
+			s.logger.WithError(err).Error("failed to get trip attachments")
+			s.respondError(w, http.StatusInternalServerError, "failed to get shopping trips")
+			return
+		}
+		trip.Attachments = make([]models.Attachment, 0, len(attachments))
+		for _, a := range attachments {
+			trip.Attachments = append(trip.Attachments, *a)
+		}
+	}
+
+	s.respondJSON(w, http.StatusOK, trips)
+}
+
+// ---------------------------------------------------------------------------
+// Attachments
+// ---------------------------------------------------------------------------
+
+func (s *Server) handleGetAttachment(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid attachment id")
+		return
+	}
+
+	attachment, err := s.svc.Attachments.GetByID(r.Context(), id)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get attachment")
+		s.respondError(w, http.StatusInternalServerError, "failed to get attachment")
+		return
+	}
+	if attachment == nil {
+		s.respondError(w, http.StatusNotFound, "attachment not found")
+		return
+	}
+
+	familyID := attachment.FamilyID
+	if familyID == 0 {
+		family, err := s.svc.Families.GetByChatID(r.Context(), attachment.ChatID)
+		if err == nil && family != nil {
+			familyID = family.ID
+		}
+	}
+
+	if !s.requireMember(w, r, user, familyID, "attachment") {
+		return
+	}
+
+	content, err := s.svc.OpenAttachment(r.Context(), attachment)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to open attachment")
+		s.respondError(w, http.StatusInternalServerError, "failed to open attachment")
+		return
+	}
+	defer content.Close()
+
+	// Anything a browser could run is only served as a download
+	contentType, disposition := attachment.ContentType, "inline"
+	if !models.SafeContentType(contentType) {
+		contentType, disposition = "application/octet-stream", "attachment"
+	}
+	if header := mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}); header != "" {
+		disposition = header
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	if attachment.SizeBytes > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+	}
+
+	if _, err := io.Copy(w, content); err != nil {
+		s.logger.WithError(err).Warn("failed to stream attachment")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Wish List
 // ---------------------------------------------------------------------------
@@ -577,8 +1052,35 @@ type addWishRequest struct {
 	FamilyID int64  `json:"family_id"`
 }
 
-type reserveWishRequest struct {
-	ReservedByID int64 `json:"reserved_by_id"`
+type pledgeWishRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+type purchasedWishRequest struct {
+	Amount *float64 `json:"amount"`
+}
+
+type updateWishRequest struct {
+	Name     *string `json:"name"`
+	URL      *string `json:"url"`
+	Price    *string `json:"price"`
+	Notes    *string `json:"notes"`
+	Priority *int    `json:"priority"`
+}
+
+// respondWishError maps service errors of wish item changes to responses.
+func (s *Server) respondWishError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case errors.Is(err, service.ErrWishNotFound):
+		s.respondError(w, http.StatusNotFound, "wish item not found")
+	case errors.Is(err, service.ErrWishForbidden):
+		s.respondError(w, http.StatusForbidden, "not allowed to "+action+" this wish item")
+	case errors.Is(err, service.ErrWishHasPledges), errors.Is(err, service.ErrWishPurchased):
+		s.respondError(w, http.StatusConflict, err.Error())
+	default:
+		s.logger.WithError(err).Errorf("failed to %s wish item", action)
+		s.respondError(w, http.StatusInternalServerError, "failed to "+action+" wish item")
+	}
 }
 
 func (s *Server) handleGetWishes(w http.ResponseWriter, r *http.Request) {
@@ -587,15 +1089,27 @@ func (s *Server) handleGetWishes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Reservations are hidden from list owners. Anonymous callers could be
+	// anyone, including the owner, so they never see reservation state.
+	viewer, ok := s.optionalUser(w, r)
+	if !ok {
+		return
+	}
+
 	// The wish list repository is keyed by family_id, so we resolve the
 	// family from the chat_id first.
 	family, err := s.svc.Families.GetByChatID(r.Context(), chatID)
-	if err != nil {
+	if err != nil || family == nil {
 		s.logger.WithField("chat_id", chatID).Debug("no family found for chat, returning empty wish lists")
 		s.respondJSON(w, http.StatusOK, []*models.WishList{})
 		return
 	}
 
+	// Reservation and pledge details are only for the family's members
+	if viewer != nil && !s.requireMember(w, r, viewer, family.ID, "wish list") {
+		return
+	}
+
 	lists, err := s.svc.WishList.GetListsByFamily(r.Context(), family.ID)
 	if err != nil {
 		s.logger.WithError(err).Error("failed to get wish lists")
@@ -611,6 +1125,20 @@ func (s *Server) handleGetWishes(w http.ResponseWriter, r *http.Request) {
 			s.logger.WithError(err).WithField("wish_list_id", list.ID).Error("failed to get wish items")
 			continue
 		}
+		viewerID := list.UserID
+		if viewer != nil {
+			viewerID = viewer.ID
+		}
+		if viewerID != list.UserID {
+			for _, item := range items {
+				if item.Pledged > 0 {
+					if err := s.svc.LoadPledges(r.Context(), item); err != nil {
+						s.logger.WithError(err).WithField("wish_item_id", item.ID).Error("failed to get pledges")
+					}
+				}
+			}
+		}
+		service.HideReservations(list, items, viewerID)
 		list.Items = make([]models.WishItem, len(items))
 		for i, item := range items {
 			list.Items[i] = *item
@@ -627,8 +1155,8 @@ func (s *Server) handleAddWish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if strings.TrimSpace(req.Name) == "" {
-		s.respondError(w, http.StatusBadRequest, "name is required")
+	if strings.TrimSpace(req.Name) == "" && strings.TrimSpace(req.URL) == "" {
+		s.respondError(w, http.StatusBadRequest, "name or url is required")
 		return
 	}
 	if req.UserID == 0 {
@@ -664,6 +1192,7 @@ func (s *Server) handleAddWish(w http.ResponseWriter, r *http.Request) {
 		Price:      strings.TrimSpace(req.Price),
 		Notes:      strings.TrimSpace(req.Notes),
 	}
+	s.svc.EnrichWish(r.Context(), item)
 
 	created, err := s.svc.WishList.AddItem(r.Context(), item)
 	if err != nil {
@@ -675,42 +1204,202 @@ func (s *Server) handleAddWish(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusCreated, created)
 }
 
-func (s *Server) handleReserveWish(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleUpdateWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
 	id, err := pathID(r)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
 		return
 	}
 
-	var req reserveWishRequest
+	var req updateWishRequest
 	if ok, msg := s.decodeJSON(r, &req); !ok {
 		s.respondError(w, http.StatusBadRequest, msg)
 		return
 	}
-	if req.ReservedByID == 0 {
-		s.respondError(w, http.StatusBadRequest, "reserved_by_id is required")
+
+	upd := service.WishUpdate{Priority: req.Priority}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			s.respondError(w, http.StatusBadRequest, "name cannot be empty")
+			return
+		}
+		upd.Name = &name
+	}
+	if req.URL != nil {
+		u := strings.TrimSpace(*req.URL)
+		if u != "" && !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			s.respondError(w, http.StatusBadRequest, "url must start with http:// or https://")
+			return
+		}
+		upd.URL = &u
+	}
+	if req.Price != nil {
+		price := strings.TrimSpace(*req.Price)
+		upd.Price = &price
+	}
+	if req.Notes != nil {
+		notes := strings.TrimSpace(*req.Notes)
+		upd.Notes = &notes
+	}
+	if req.Priority != nil && (*req.Priority < 0 || *req.Priority > 99) {
+		s.respondError(w, http.StatusBadRequest, "priority must be between 0 and 99")
+		return
+	}
+
+	item, err := s.svc.UpdateWish(r.Context(), id, user.ID, upd)
+	if err != nil {
+		s.respondWishError(w, err, "update")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleReserveWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
 		return
 	}
 
-	if err := s.svc.WishList.ReserveItem(r.Context(), id, req.ReservedByID); err != nil {
-		s.logger.WithError(err).Error("failed to reserve wish item")
-		s.respondError(w, http.StatusInternalServerError, "failed to reserve wish item")
+	if err := s.svc.ReserveWish(r.Context(), id, user.ID); err != nil {
+		s.respondWishError(w, err, "reserve")
 		return
 	}
 
 	s.respondJSON(w, http.StatusOK, map[string]string{"status": "reserved"})
 }
 
+func (s *Server) handleUnreserveWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
+		return
+	}
+
+	if err := s.svc.UnreserveWish(r.Context(), id, user.ID); err != nil {
+		s.respondWishError(w, err, "unreserve")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]string{"status": "unreserved"})
+}
+
+func (s *Server) handlePledgeWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
+		return
+	}
+
+	var req pledgeWishRequest
+	if ok, msg := s.decodeJSON(r, &req); !ok {
+		s.respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if req.Amount <= 0 {
+		s.respondError(w, http.StatusBadRequest, "amount must be positive")
+		return
+	}
+
+	item, err := s.svc.PledgeWish(r.Context(), id, user.ID, req.Amount)
+	if err != nil {
+		s.respondWishError(w, err, "pledge toward")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, item)
+}
+
+func (s *Server) handleUnpledgeWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
+		return
+	}
+
+	if err := s.svc.UnpledgeWish(r.Context(), id, user.ID); err != nil {
+		s.respondWishError(w, err, "withdraw a pledge for")
+		return
+	}
+
+	s.respondJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) handlePurchasedWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := pathID(r)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
+		return
+	}
+
+	// The body is optional; without an amount the pledged total is used
+	var req purchasedWishRequest
+	if r.ContentLength > 0 {
+		if ok, msg := s.decodeJSON(r, &req); !ok {
+			s.respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+	}
+	if req.Amount != nil && *req.Amount < 0 {
+		s.respondError(w, http.StatusBadRequest, "amount must not be negative")
+		return
+	}
+
+	settlement, err := s.svc.MarkWishPurchased(r.Context(), id, user.ID, req.Amount)
+	if err != nil {
+		s.respondWishError(w, err, "mark as purchased")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, settlement)
+}
+
 func (s *Server) handleDeleteWish(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
 	id, err := pathID(r)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, "invalid wish item id")
 		return
 	}
 
-	if err := s.svc.WishList.DeleteItem(r.Context(), id); err != nil {
-		s.logger.WithError(err).Error("failed to delete wish item")
-		s.respondError(w, http.StatusInternalServerError, "failed to delete wish item")
+	if err := s.svc.DeleteWish(r.Context(), id, user.ID); err != nil {
+		s.respondWishError(w, err, "delete")
 		return
 	}
 
@@ -802,12 +1491,31 @@ func (s *Server) handleCreateReminder(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleDeleteReminder(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+
 	id, err := pathID(r)
 	if err != nil {
 		s.respondError(w, http.StatusBadRequest, "invalid reminder id")
 		return
 	}
 
+	reminder, err := s.svc.Reminders.GetByID(r.Context(), id)
+	if err != nil {
+		s.logger.WithError(err).Error("failed to get reminder")
+		s.respondError(w, http.StatusInternalServerError, "failed to get reminder")
+		return
+	}
+	if reminder == nil {
+		s.respondError(w, http.StatusNotFound, "reminder not found")
+		return
+	}
+	if !s.authorize(w, r, user, reminder.ChatID, reminder.UserID) {
+		return
+	}
+
 	if err := s.svc.Reminders.Delete(r.Context(), id); err != nil {
 		s.logger.WithError(err).Error("failed to delete reminder")
 		s.respondError(w, http.StatusInternalServerError, "failed to delete reminder")
@@ -816,3 +1524,50 @@ func (s *Server) handleDeleteReminder(w http.ResponseWriter, r *http.Request) {
 
 	s.respondJSON(w, http.StatusNoContent, nil)
 }
+
+// ---------------------------------------------------------------------------
+// Search
+// ---------------------------------------------------------------------------
+
+// handleSearch searches the data of the chat's family for q. Results are
+// paged with limit (default 20, at most 100) and offset.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.requireUser(w, r)
+	if !ok {
+		return
+	}
+	chatID, ok := s.requireChatID(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	limit, offset := 20, 0
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+	if v, err := strconv.Atoi(q.Get("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	var familyID int64
+	if family, err := s.svc.Families.GetByChatID(r.Context(), chatID); err == nil && family != nil {
+		familyID = family.ID
+	}
+	if !s.requireMember(w, r, user, familyID, "chat") {
+		return
+	}
+
+	page, err := s.svc.Search(r.Context(), chatID, q.Get("q"), limit, offset)
+	if errors.Is(err, service.ErrEmptySearch) {
+		s.respondError(w, http.StatusBadRequest, "q must contain words to search for")
+		return
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("failed to search")
+		s.respondError(w, http.StatusInternalServerError, "failed to search")
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, page)
+}
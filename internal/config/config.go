@@ -12,13 +12,19 @@ type Config struct {
 	LogLevel      string
 	Port          string
 	WebhookURL    string
+	StorageDir    string
+	// DownloadMedia keeps copies of photos, voice notes and documents
+	// attached to todos and shopping items instead of only their
+	// Telegram file_id
+	DownloadMedia bool
 }
 
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	cfg := &Config{
-		LogLevel: getEnvOrDefault("LOG_LEVEL", "info"),
-		Port:     getEnvOrDefault("PORT", "8080"),
+		LogLevel:   getEnvOrDefault("LOG_LEVEL", "info"),
+		Port:       getEnvOrDefault("PORT", "8080"),
+		StorageDir: getEnvOrDefault("STORAGE_DIR", "data/attachments"),
 	}
 
 	if cfg.TelegramToken = os.Getenv("TELEGRAM_TOKEN"); cfg.TelegramToken == "" {
@@ -30,6 +36,7 @@ func Load() (*Config, error) {
 	}
 
 	cfg.WebhookURL = os.Getenv("WEBHOOK_URL")
+	cfg.DownloadMedia = os.Getenv("DOWNLOAD_MEDIA") == "true"
 
 	return cfg, nil
 }
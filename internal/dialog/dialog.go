@@ -0,0 +1,92 @@
+// Package dialog keeps track of multi-step conversations the bot holds with
+// users, e.g. asking for an event's title, then its date, then its place.
+//
+// A conversation belongs to one user in one chat, so several family members
+// can be asked things in the same group at once. State is kept in Storage
+// rather than in the process, so conversations survive restarts and every
+// instance of the bot sees them, whether updates arrive by polling or by
+// webhook; conversations that go quiet expire and are forgotten.
+package dialog
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTimeout is how long a conversation waits for the user's next answer.
+const DefaultTimeout = 10 * time.Minute
+
+// State is where a conversation stands.
+type State struct {
+	ChatID int64
+	// UserID is the Telegram ID of the user being asked.
+	UserID int64
+	// Name identifies the conversation, e.g. "event".
+	Name string
+	// Step is the question the user is expected to answer next.
+	Step string
+	// Data holds the answers collected so far.
+	Data map[string]string
+}
+
+// Storage keeps conversations until they expire.
+type Storage interface {
+	// Get returns the user's conversation in the chat, or nil if they are
+	// not in one or it expired before now.
+	Get(ctx context.Context, chatID, userID int64, now time.Time) (*State, error)
+	// Save stores the conversation, replacing the user's previous one in
+	// the chat, to expire at expiresAt.
+	Save(ctx context.Context, state *State, expiresAt time.Time) error
+	// Delete forgets the user's conversation in the chat. It reports
+	// whether there was one that had not expired before now.
+	Delete(ctx context.Context, chatID, userID int64, now time.Time) (bool, error)
+}
+
+// Store holds the ongoing conversations.
+type Store struct {
+	storage Storage
+	timeout time.Duration
+}
+
+// NewStore creates a Store keeping conversations in storage, where they
+// expire after timeout without an answer.
+func NewStore(storage Storage, timeout time.Duration) *Store {
+	return &Store{storage: storage, timeout: timeout}
+}
+
+// Start begins a conversation of the user in the chat at the given step,
+// replacing the one they were in.
+func (s *Store) Start(ctx context.Context, chatID, userID int64, name, step string) (*State, error) {
+	state := &State{
+		ChatID: chatID,
+		UserID: userID,
+		Name:   name,
+		Step:   step,
+		Data:   make(map[string]string),
+	}
+	if err := s.Save(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Get returns the user's conversation in the chat, or nil if they are not
+// in one or it has expired.
+func (s *Store) Get(ctx context.Context, chatID, userID int64) (*State, error) {
+	return s.storage.Get(ctx, chatID, userID, time.Now())
+}
+
+// Save stores the conversation after it moved on, giving the user the full
+// timeout again to answer.
+func (s *Store) Save(ctx context.Context, state *State) error {
+	if state.Data == nil {
+		state.Data = make(map[string]string)
+	}
+	return s.storage.Save(ctx, state, time.Now().Add(s.timeout))
+}
+
+// End finishes the user's conversation in the chat. It reports whether there
+// was one to finish.
+func (s *Store) End(ctx context.Context, chatID, userID int64) (bool, error) {
+	return s.storage.Delete(ctx, chatID, userID, time.Now())
+}
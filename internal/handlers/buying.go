@@ -4,25 +4,70 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
 )
 
-var quantityRegex = regexp.MustCompile(`^x(\d+)$`)
+var (
+	quantityRegex = regexp.MustCompile(`^x(\d+)$`)
+	categoryRegex = regexp.MustCompile(`^#([\p{L}\p{N}_-]+)$`)
+	priceRegex    = regexp.MustCompile(`^\d+([.,]\d{1,2})?$`)
+)
+
+// parsePrice parses a price argument such as "3.49" or "3,49".
+func parsePrice(s string) (float64, bool) {
+	if !priceRegex.MatchString(s) {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// ensureBuyingList returns the shopping list of the chat, creating it on
+// behalf of the user if the chat has none yet.
+func ensureBuyingList(ctx context.Context, svc *service.Service, lang string, familyID, chatID, userID int64) (*models.BuyingList, error) {
+	list, err := svc.Buying.GetListByChatID(ctx, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("get buying list: %w", err)
+	}
+	if list != nil {
+		return list, nil
+	}
+
+	list, err = svc.Buying.CreateList(ctx, &models.BuyingList{
+		FamilyID:    familyID,
+		ChatID:      chatID,
+		Name:        i18n.T(lang, "buy.list_name"),
+		CreatedByID: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create buying list: %w", err)
+	}
+	return list, nil
+}
 
 // ---------------------------------------------------------------------------
-// BuyAddHandler – /buy <item> [x quantity]
+// BuyAddHandler – /buy <item> [x quantity] [#category]
 // ---------------------------------------------------------------------------
 
 // BuyAddHandler handles the /buy command to add an item to the shopping list.
 // If no shopping list exists for the chat, one is created automatically.
-// An optional quantity suffix like "x2" can be appended at the end.
+// An optional quantity suffix like "x2" and a category tag like "#dairy"
+// can be appended at the end.
 type BuyAddHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -35,17 +80,28 @@ func NewBuyAddHandler(svc *service.Service, logger *logrus.Logger) *BuyAddHandle
 
 // Handle processes the /buy command.
 func (h *BuyAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	// Media alone makes an item named after it
+	media := mediaFile(message)
+	if len(args) == 0 && media != nil {
+		args = strings.Fields(mediaTitle(lang, media))
+	}
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide an item name.\n\n"+
-				"*Usage:*\n"+
-				"`/buy Milk x2`\n"+
-				"`/buy Whole wheat bread`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
+	// Parse optional category tag (e.g. "#dairy") from the end
+	var category string
+	if matches := categoryRegex.FindStringSubmatch(args[len(args)-1]); matches != nil && len(args) > 1 {
+		category = strings.ToLower(matches[1])
+		args = args[:len(args)-1]
+	}
+
 	// Parse optional quantity suffix (e.g. "x2", "x12")
 	var itemName, quantity string
 	lastArg := args[len(args)-1]
@@ -58,45 +114,28 @@ func (h *BuyAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		quantity = "1"
 	}
 
-	ctx := context.Background()
-
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	chatTitle := message.Chat.Title
-	if chatTitle == "" {
-		chatTitle = message.From.FirstName + "'s list"
-	}
-	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, chatTitle)
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
 	if err != nil {
 		return fmt.Errorf("ensure family: %w", err)
 	}
 	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
 
-	// Get or auto-create the shopping list for this chat
-	list, err := h.svc.Buying.GetListByChatID(ctx, message.Chat.ID)
+	list, err := ensureBuyingList(ctx, h.svc, lang, family.ID, chatID, user.ID)
 	if err != nil {
-		return fmt.Errorf("get buying list: %w", err)
-	}
-	if list == nil {
-		list = &models.BuyingList{
-			FamilyID:    family.ID,
-			ChatID:      message.Chat.ID,
-			Name:        "Shopping List",
-			CreatedByID: user.ID,
-		}
-		list, err = h.svc.Buying.CreateList(ctx, list)
-		if err != nil {
-			return fmt.Errorf("create buying list: %w", err)
-		}
+		return err
 	}
 
 	item := &models.BuyingItem{
 		BuyingListID: list.ID,
 		Name:         itemName,
 		Quantity:     quantity,
+		Category:     category,
 		AddedByID:    user.ID,
 	}
 
@@ -110,10 +149,17 @@ func (h *BuyAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		quantityDisplay = fmt.Sprintf(" (x%s)", quantity)
 	}
 
-	text := fmt.Sprintf("🛒 *Added to shopping list!*\n\n⬜ *#%d* — %s%s", item.ID, itemName, quantityDisplay)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	if category != "" {
+		quantityDisplay += fmt.Sprintf(" _#%s_", markup.Escape(category))
+	}
+
+	if media != nil && attachMedia(ctx, h.svc, h.logger, media, family.ID, chatID, models.AttachmentEntityBuyingItem, item.ID, user.ID) {
+		quantityDisplay += " 📎"
+	}
+
+	text := i18n.T(lang, "buy.added", item.ID, markup.Escape(itemName), quantityDisplay)
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
@@ -129,7 +175,8 @@ func (h *BuyAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 // ---------------------------------------------------------------------------
 
 // BuyListHandler handles the /buylist command to display the shopping list,
-// showing both bought and unbought items with their status.
+// showing both bought and unbought items with their status. Items are in
+// the order they were added, or alphabetical with sort:name.
 type BuyListHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -144,74 +191,119 @@ func NewBuyListHandler(svc *service.Service, logger *logrus.Logger) *BuyListHand
 func (h *BuyListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
 
-	list, err := h.svc.Buying.GetListByChatID(ctx, message.Chat.ID)
-	if err != nil || list == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"🛒 *No shopping list yet!*\n\nStart one with `/buy <item>`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	out, count, err := h.output(ctx, message, args)
+	if err != nil {
+		return err
+	}
+	if err := sendPaged(bot, message.Chat.ID, out, "buylist", args); err != nil {
+		return err
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"total":   count,
+	}).Info("Listed shopping list")
+
+	return nil
+}
+
+// HandleCallback processes a press on a page button of /buylist.
+func (h *BuyListHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
 		return nil
 	}
+	page, args, err := parsePageCallback(data)
+	if err != nil {
+		return err
+	}
+
+	message := pageCallbackMessage(query)
+	out, _, err := h.output(context.Background(), message, args)
+	if err != nil {
+		return err
+	}
+	return editPaged(bot, message, out, page, "buylist", args)
+}
+
+// output renders the shopping list for args, and how many items it has.
+func (h *BuyListHandler) output(ctx context.Context, message *tgbotapi.Message, args []string) (pagedOutput, int, error) {
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
+
+	sort, _, problem := splitSort(lang, args, "created", "created", "name")
+	if problem != "" {
+		return pagedOutput{header: problem}, 0, nil
+	}
+
+	list, err := h.svc.Buying.GetListByChatID(ctx, chatID)
+	if err != nil || list == nil {
+		return pagedOutput{header: i18n.T(lang, "buy.no_list")}, 0, nil
+	}
 
 	// Get all items (both bought and unbought)
 	items, err := h.svc.Buying.GetItems(ctx, list.ID, false)
 	if err != nil {
-		return fmt.Errorf("get buying items: %w", err)
+		return pagedOutput{}, 0, fmt.Errorf("get buying items: %w", err)
 	}
 
 	if len(items) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"🛒 *Shopping list is empty!*\n\nAdd items with `/buy <item>`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+		return pagedOutput{header: i18n.T(lang, "buylist.empty")}, 0, nil
 	}
 
-	var sb strings.Builder
-	sb.WriteString("🛒 *Shopping List*\n\n")
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	attachments, err := h.svc.LoadAttachments(ctx, models.AttachmentEntityBuyingItem, ids)
+	if err != nil {
+		return pagedOutput{}, 0, fmt.Errorf("load attachments: %w", err)
+	}
+
+	if sort == "name" {
+		slices.SortStableFunc(items, func(a, b *models.BuyingItem) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+
+	out := pagedOutput{header: i18n.T(lang, "buylist.heading") + "\n\n", lang: lang}
 
 	var unboughtCount, boughtCount int
 	for _, item := range items {
 		var quantityDisplay string
 		if item.Quantity != "" && item.Quantity != "1" {
-			quantityDisplay = fmt.Sprintf(" (x%s)", item.Quantity)
+			quantityDisplay = fmt.Sprintf(" (x%s)", markup.Escape(item.Quantity))
 		}
+		quantityDisplay += attachmentIndicator(attachments[item.ID])
 
 		if item.Bought {
 			boughtCount++
 			boughtBy := ""
 			if item.BoughtBy != nil {
-				boughtBy = fmt.Sprintf(" — _by %s_", item.BoughtBy.DisplayName())
+				boughtBy = " — _" + i18n.T(lang, "buylist.bought_by", markup.Escape(item.BoughtBy.DisplayName())) + "_"
+			}
+			if item.Price != nil {
+				boughtBy += fmt.Sprintf(" — %.2f", *item.Price)
 			}
-			sb.WriteString(fmt.Sprintf("✅ ~%s%s~%s\n", item.Name, quantityDisplay, boughtBy))
+			out.entries = append(out.entries, fmt.Sprintf("✅ ~%s%s~%s", markup.Escape(item.Name), quantityDisplay, boughtBy))
 		} else {
 			unboughtCount++
-			sb.WriteString(fmt.Sprintf("⬜ *#%d* %s%s\n", item.ID, item.Name, quantityDisplay))
+			out.entries = append(out.entries, fmt.Sprintf("⬜ *#%d* %s%s", item.ID, markup.Escape(item.Name), quantityDisplay))
 		}
 	}
 
-	sb.WriteString(fmt.Sprintf("\n_%d remaining, %d bought_", unboughtCount, boughtCount))
+	out.footer = "\n_" + i18n.T(lang, "buylist.count", unboughtCount, boughtCount) + "_"
 	if boughtCount > 0 {
-		sb.WriteString("\n\n_Use_ `/buyclear` _to remove bought items_")
+		out.footer += "\n\n" + i18n.T(lang, "buylist.clear_hint")
 	}
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
-
-	h.logger.WithFields(logrus.Fields{
-		"chat_id": message.Chat.ID,
-		"total":   len(items),
-	}).Info("Listed shopping list")
-
-	return nil
+	return out, len(items), nil
 }
 
 // ---------------------------------------------------------------------------
-// BuyDoneHandler – /bought <id>
+// BuyDoneHandler – /bought <id> [price]
 // ---------------------------------------------------------------------------
 
 // BuyDoneHandler handles the /bought command to mark an item as bought.
+// An optional price can be given to track family spending.
 type BuyDoneHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -224,42 +316,50 @@ func NewBuyDoneHandler(svc *service.Service, logger *logrus.Logger) *BuyDoneHand
 
 // Handle processes the /bought command.
 func (h *BuyDoneHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide an item ID.\nUsage: `/bought 3`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "bought.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	itemID, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid ID. Please provide a numeric item ID.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
+	var price *float64
+	if len(args) > 1 {
+		p, ok := parsePrice(args[1])
+		if !ok {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "bought.price_invalid"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		price = &p
+	}
 
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	if err = h.svc.Buying.MarkBought(ctx, itemID, user.ID); err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Could not mark item *#%d* as bought. It may not exist.", itemID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	if err = h.svc.Buying.MarkBought(ctx, itemID, user.ID, price); err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "bought.failed", itemID))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	text := fmt.Sprintf("✅ Item *#%d* marked as bought!", itemID)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	text := i18n.T(lang, "bought.done", itemID)
+	if price != nil {
+		text += fmt.Sprintf("\n💰 %.2f", *price)
+	}
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
@@ -275,7 +375,7 @@ func (h *BuyDoneHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 // ---------------------------------------------------------------------------
 
 // BuyClearHandler handles the /buyclear command to clear all bought items
-// from the shopping list.
+// from the shopping list. Cleared items are archived as purchase history.
 type BuyClearHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -289,29 +389,128 @@ func NewBuyClearHandler(svc *service.Service, logger *logrus.Logger) *BuyClearHa
 // Handle processes the /buyclear command.
 func (h *BuyClearHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
 
-	list, err := h.svc.Buying.GetListByChatID(ctx, message.Chat.ID)
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	list, err := h.svc.Buying.GetListByChatID(ctx, chatID)
 	if err != nil || list == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ No shopping list found for this chat.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buyclear.no_list"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	if err = h.svc.Buying.ClearBought(ctx, list.ID); err != nil {
+	trip, err := h.svc.Buying.ClearBought(ctx, list.ID, user.ID)
+	if err != nil {
 		return fmt.Errorf("clear bought items: %w", err)
 	}
 
-	msg := tgbotapi.NewMessage(message.Chat.ID,
-		"🧹 All bought items have been cleared from the shopping list!")
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	if trip == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buyclear.nothing"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	text := i18n.T(lang, "buyclear.done", trip.ID, i18n.N(lang, "common.items", trip.ItemCount), trip.Total)
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
 		"list_id": list.ID,
+		"trip_id": trip.ID,
 	}).Info("Cleared bought items")
 
 	return nil
 }
+
+// ---------------------------------------------------------------------------
+// SpentHandler – /spent [months]
+// ---------------------------------------------------------------------------
+
+// SpentHandler handles the /spent command to report family spending from
+// the purchase history, broken down per month, per member and per category.
+// By default it covers the current month; "/spent 3" covers the last three.
+type SpentHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewSpentHandler creates a new SpentHandler.
+func NewSpentHandler(svc *service.Service, logger *logrus.Logger) *SpentHandler {
+	return &SpentHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /spent command.
+func (h *SpentHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	months := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > 24 {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "spent.usage"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		months = n
+	}
+
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+
+	list, err := h.svc.Buying.GetListByChatID(ctx, chatID)
+	if err != nil || list == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.no_list"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	since := service.SpendingPeriodStart(time.Now(), months)
+	stats, err := h.svc.Buying.GetSpendingStats(ctx, list.ID, since)
+	if err != nil {
+		return fmt.Errorf("get spending stats: %w", err)
+	}
+
+	if stats.Items == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "spent.none", i18n.Date(lang, since, "02 Jan 2006")))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "spent.heading", i18n.Date(lang, since, "02 Jan 2006")) + "\n\n")
+	sb.WriteString(i18n.T(lang, "spent.total", stats.Total, i18n.N(lang, "common.items", stats.Items)) + "\n")
+
+	if len(stats.ByMonth) > 1 {
+		sb.WriteString("\n" + i18n.T(lang, "spent.per_month") + "\n")
+		for _, b := range stats.ByMonth {
+			sb.WriteString(fmt.Sprintf("  %s — %.2f\n", b.Key, b.Total))
+		}
+	}
+
+	sb.WriteString("\n" + i18n.T(lang, "spent.per_member") + "\n")
+	for _, b := range stats.ByMember {
+		sb.WriteString(fmt.Sprintf("  %s — %.2f (%d)\n", markup.Escape(b.Key), b.Total, b.Items))
+	}
+
+	sb.WriteString("\n" + i18n.T(lang, "spent.per_category") + "\n")
+	for _, b := range stats.ByCategory {
+		sb.WriteString(fmt.Sprintf("  %s — %.2f (%d)\n", markup.Escape(b.Key), b.Total, b.Items))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"months":  months,
+		"items":   stats.Items,
+	}).Info("Reported spending")
+
+	return nil
+}
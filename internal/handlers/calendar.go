@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,9 +13,13 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Kerhoff/TodoboT/internal/dialog"
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/repository"
 	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
 )
 
 var (
@@ -21,13 +27,22 @@ var (
 	calTimeRegex = regexp.MustCompile(`^\d{1,2}:\d{2}$`)
 )
 
+// eventWhen describes when an event starts, e.g. "Mon, 02 Jan 2006 at 15:04".
+func eventWhen(lang string, start time.Time, allDay bool) string {
+	if allDay {
+		return i18n.T(lang, "event.all_day", i18n.Date(lang, start, "Mon, 02 Jan 2006"))
+	}
+	return i18n.T(lang, "event.at", i18n.Date(lang, start, "Mon, 02 Jan 2006"), start.Format("15:04"))
+}
+
 // ---------------------------------------------------------------------------
-// CalendarAddHandler – /event <title> <date> [time]
+// CalendarAddHandler – /event [<title> <date> [time]]
 // ---------------------------------------------------------------------------
 
 // CalendarAddHandler handles the /event command to create a calendar event.
 // It parses the date (YYYY-MM-DD) and optional time (HH:MM) from the end of
 // the argument list; everything before is treated as the event title.
+// Without arguments it asks for the title, date and location one by one.
 type CalendarAddHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -40,14 +55,21 @@ func NewCalendarAddHandler(svc *service.Service, logger *logrus.Logger) *Calenda
 
 // Handle processes the /event command.
 func (h *CalendarAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		state, err := h.svc.Dialogs.Start(ctx, message.Chat.ID, message.From.ID, "event", "title")
+		if err != nil {
+			return fmt.Errorf("start event dialog: %w", err)
+		}
+		askEventStep(lang, bot, h.logger, message, state)
+		return nil
+	}
+
 	if len(args) < 2 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a title and date.\n\n"+
-				"*Usage:*\n"+
-				"`/event Meeting 2025-01-15 14:00`\n"+
-				"`/event Birthday party 2025-03-20`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
@@ -68,90 +90,78 @@ func (h *CalendarAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Mess
 	}
 
 	if dateStr == "" {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Could not find a date in your command.\n"+
-				"Please use the format `YYYY-MM-DD`.\n"+
-				"Example: `/event Meeting 2025-01-15 14:00`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.no_date"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	titleParts := args[:lastIdx+1]
 	if len(titleParts) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide an event title before the date.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.no_title"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 	title := strings.Join(titleParts, " ")
 
-	// Parse date and optional time
-	var startTime time.Time
-	var allDay bool
-	var parseErr error
-
-	if timeStr != "" {
-		startTime, parseErr = time.ParseInLocation("2006-01-02 15:04", dateStr+" "+timeStr, time.Local)
-		allDay = false
-	} else {
-		startTime, parseErr = time.ParseInLocation("2006-01-02", dateStr, time.Local)
-		allDay = true
-	}
-	if parseErr != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid date/time format.\nDate: `YYYY-MM-DD`, Time: `HH:MM`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	startTime, allDay, err := parseEventStart(dateStr, timeStr)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.bad_date"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
+	return createEvent(ctx, bot, h.svc, h.logger, message, lang, &models.CalendarEvent{
+		Title:     title,
+		StartTime: startTime,
+		AllDay:    allDay,
+	})
+}
 
-	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+// parseEventStart parses a date (YYYY-MM-DD) and an optional time (HH:MM).
+// Events without a time last all day.
+func parseEventStart(dateStr, timeStr string) (time.Time, bool, error) {
+	if timeStr != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04", dateStr+" "+timeStr, time.Local)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+	return t, true, err
+}
+
+// createEvent saves the event in the family of the message's chat and
+// confirms it.
+func createEvent(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, lang string, event *models.CalendarEvent) error {
+	user, err := svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	chatTitle := message.Chat.Title
-	if chatTitle == "" {
-		chatTitle = message.From.FirstName + "'s calendar"
-	}
-	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, chatTitle)
+	chatID, chatTitle := workspaceChat(ctx, svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := svc.EnsureFamily(ctx, chatID, chatTitle)
 	if err != nil {
 		return fmt.Errorf("ensure family: %w", err)
 	}
-	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+	_ = svc.EnsureFamilyMember(ctx, family.ID, user.ID)
 
-	event := &models.CalendarEvent{
-		FamilyID:    family.ID,
-		ChatID:      message.Chat.ID,
-		Title:       title,
-		StartTime:   startTime,
-		AllDay:      allDay,
-		Recurring:   "none",
-		CreatedByID: user.ID,
-	}
+	event.FamilyID = family.ID
+	event.ChatID = chatID
+	event.Recurring = "none"
+	event.CreatedByID = user.ID
 
-	event, err = h.svc.Calendar.Create(ctx, event)
+	event, err = svc.Calendar.Create(ctx, event)
 	if err != nil {
 		return fmt.Errorf("create event: %w", err)
 	}
 
-	var dateDisplay string
-	if allDay {
-		dateDisplay = startTime.Format("Mon, 02 Jan 2006") + " (all day)"
-	} else {
-		dateDisplay = startTime.Format("Mon, 02 Jan 2006 at 15:04")
+	text := i18n.T(lang, "event.created", event.ID, markup.Escape(event.Title), eventWhen(lang, event.StartTime, event.AllDay))
+	if event.Location != "" {
+		text += "\n📍 " + markup.Escape(event.Location)
 	}
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, logger, msg)
 
-	text := fmt.Sprintf("📅 *Event created!*\n\n*#%d* — %s\n📆 %s", event.ID, title, dateDisplay)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
-
-	h.logger.WithFields(logrus.Fields{
+	logger.WithFields(logrus.Fields{
 		"chat_id":  message.Chat.ID,
 		"user_id":  message.From.ID,
 		"event_id": event.ID,
@@ -160,12 +170,94 @@ func (h *CalendarAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Mess
 	return nil
 }
 
+// eventDialog asks for a new event's title, then when it is, then where;
+// the place may be skipped.
+func eventDialog(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, state *dialog.State, answer string) error {
+	lang := messageLang(ctx, svc, message)
+
+	switch state.Step {
+	case "title":
+		state.Data["title"] = answer
+		state.Step = "when"
+
+	case "when":
+		dateStr, timeStr, _ := strings.Cut(answer, " ")
+		timeStr = strings.TrimSpace(timeStr)
+		_, _, err := parseEventStart(dateStr, timeStr)
+		if !calDateRegex.MatchString(dateStr) || (timeStr != "" && !calTimeRegex.MatchString(timeStr)) || err != nil {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.bad_when"))
+			telegram.Send(bot, logger, msg)
+			return nil
+		}
+		state.Data["date"] = dateStr
+		state.Data["time"] = timeStr
+		state.Step = "location"
+
+	case "location":
+		// Of answers arriving at once, only the one that ends the
+		// conversation creates the event
+		if ended, err := svc.Dialogs.End(ctx, state.ChatID, state.UserID); err != nil || !ended {
+			return err
+		}
+		start, allDay, err := parseEventStart(state.Data["date"], state.Data["time"])
+		if err != nil {
+			return fmt.Errorf("parse event start: %w", err)
+		}
+		return createEvent(ctx, bot, svc, logger, message, lang, &models.CalendarEvent{
+			Title:     state.Data["title"],
+			StartTime: start,
+			AllDay:    allDay,
+			Location:  answer,
+		})
+
+	default:
+		svc.Dialogs.End(ctx, state.ChatID, state.UserID)
+		return fmt.Errorf("unknown event dialog step %q", state.Step)
+	}
+
+	if err := svc.Dialogs.Save(ctx, state); err != nil {
+		return fmt.Errorf("save event dialog: %w", err)
+	}
+	askEventStep(lang, bot, logger, message, state)
+	return nil
+}
+
+// askEventStep asks the question of the event dialog's current step.
+func askEventStep(lang string, bot *tgbotapi.BotAPI, logger *logrus.Logger, message *tgbotapi.Message, state *dialog.State) {
+	switch state.Step {
+	case "title":
+		askDialog(bot, logger, message, i18n.T(lang, "event.ask_title"), nil)
+	case "when":
+		keyboard := eventPicker(lang, state).Keyboard(time.Now())
+		askDialog(bot, logger, message, i18n.T(lang, "event.ask_when", markup.Escape(state.Data["title"])), &keyboard)
+	case "location":
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(dialogControls(lang, state, true))
+		askDialog(bot, logger, message, i18n.T(lang, "event.ask_location"), &keyboard)
+	}
+}
+
+// eventPicker is the date picker asking when the event is. The time may be
+// left out for an all-day event.
+func eventPicker(lang string, state *dialog.State) *telegram.DatePicker {
+	if state.Step != "when" {
+		return nil
+	}
+	now := time.Now()
+	picker := dialogPicker(lang, state)
+	picker.Time = true
+	picker.NoTime = i18n.T(lang, "event.all_day_button")
+	picker.From = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return picker
+}
+
 // ---------------------------------------------------------------------------
 // CalendarListHandler – /events
 // ---------------------------------------------------------------------------
 
 // CalendarListHandler handles the /events command to list upcoming events
-// for the current chat.
+// for the current chat. Birthdays and other occasions of the coming year are
+// merged in as all-day events.
 type CalendarListHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -180,60 +272,104 @@ func NewCalendarListHandler(svc *service.Service, logger *logrus.Logger) *Calend
 func (h *CalendarListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
 
+	out, count, err := h.output(ctx, message)
+	if err != nil {
+		return err
+	}
+	if err := sendPaged(bot, message.Chat.ID, out, "events", nil); err != nil {
+		return err
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"count":   count,
+	}).Info("Listed calendar events")
+
+	return nil
+}
+
+// HandleCallback processes a press on a page button of /events.
+func (h *CalendarListHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+	page, _, err := parsePageCallback(data)
+	if err != nil {
+		return err
+	}
+
+	message := pageCallbackMessage(query)
+	out, _, err := h.output(context.Background(), message)
+	if err != nil {
+		return err
+	}
+	return editPaged(bot, message, out, page, "events", nil)
+}
+
+// output renders the upcoming events, and how many there are.
+func (h *CalendarListHandler) output(ctx context.Context, message *tgbotapi.Message) (pagedOutput, int, error) {
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
+
 	now := time.Now().Format("2006-01-02 15:04:05")
 	filters := repository.CalendarFilters{
 		From:  &now,
-		Limit: 20,
+		Limit: 50,
 	}
 
-	events, err := h.svc.Calendar.GetByChatID(ctx, message.Chat.ID, filters)
+	events, err := h.svc.Calendar.GetByChatID(ctx, chatID, filters)
 	if err != nil {
-		return fmt.Errorf("list events: %w", err)
+		return pagedOutput{}, 0, fmt.Errorf("list events: %w", err)
 	}
 
-	if len(events) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"📅 *No upcoming events!*\n\nAdd one with `/event <title> <date> [time]`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+	if family, _ := h.svc.Families.GetByChatID(ctx, chatID); family != nil {
+		start := time.Now()
+		occasions, err := h.svc.OccasionEvents(ctx, lang, family.ID, start, start.AddDate(1, 0, 0))
+		if err != nil {
+			return pagedOutput{}, 0, fmt.Errorf("list occasions: %w", err)
+		}
+		events = append(events, occasions...)
+		slices.SortStableFunc(events, func(a, b *models.CalendarEvent) int {
+			return a.StartTime.Compare(b.StartTime)
+		})
+		if len(events) > filters.Limit {
+			events = events[:filters.Limit]
+		}
 	}
 
-	var sb strings.Builder
-	sb.WriteString("📅 *Upcoming Events*\n\n")
+	if len(events) == 0 {
+		return pagedOutput{header: i18n.T(lang, "events.empty")}, 0, nil
+	}
 
+	out := pagedOutput{
+		header: i18n.T(lang, "events.heading") + "\n\n",
+		footer: "_" + i18n.N(lang, "events.count", len(events)) + "_",
+		lang:   lang,
+	}
 	for i, event := range events {
-		var dateDisplay string
-		if event.AllDay {
-			dateDisplay = event.StartTime.Format("Mon, 02 Jan 2006") + " (all day)"
-		} else {
-			dateDisplay = event.StartTime.Format("Mon, 02 Jan 2006 at 15:04")
-		}
+		dateDisplay := eventWhen(lang, event.StartTime, event.AllDay)
 
 		status := "📆"
 		if event.IsOngoing() {
 			status = "▶️"
 		}
 
-		sb.WriteString(fmt.Sprintf("%d. %s *#%d* %s\n   📆 %s", i+1, status, event.ID, event.Title, dateDisplay))
+		if event.OccasionID != nil {
+			// Occasions are deleted with /deloccasion, so show their own ID
+			out.entries = append(out.entries,
+				fmt.Sprintf("%d. %s\n   📆 %s _(%s)_\n", i+1, markup.Escape(event.Title), dateDisplay, i18n.T(lang, "events.occasion", *event.OccasionID)))
+			continue
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%d. %s *#%d* %s\n   📆 %s", i+1, status, event.ID, markup.Escape(event.Title), dateDisplay))
 		if event.Location != "" {
-			sb.WriteString(fmt.Sprintf("\n   📍 %s", event.Location))
+			sb.WriteString(fmt.Sprintf("\n   📍 %s", markup.Escape(event.Location)))
 		}
-		sb.WriteString("\n\n")
+		sb.WriteString("\n")
+		out.entries = append(out.entries, sb.String())
 	}
-
-	sb.WriteString(fmt.Sprintf("_%d upcoming events_", len(events)))
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
-
-	h.logger.WithFields(logrus.Fields{
-		"chat_id": message.Chat.ID,
-		"count":   len(events),
-	}).Info("Listed calendar events")
-
-	return nil
+	return out, len(events), nil
 }
 
 // ---------------------------------------------------------------------------
@@ -241,7 +377,7 @@ func (h *CalendarListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Mes
 // ---------------------------------------------------------------------------
 
 // CalendarDeleteHandler handles the /delevent command to delete a calendar event.
-// Only the creator of the event is allowed to delete it.
+// Only the creator of the event or a family admin is allowed to delete it.
 type CalendarDeleteHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -254,24 +390,23 @@ func NewCalendarDeleteHandler(svc *service.Service, logger *logrus.Logger) *Cale
 
 // Handle processes the /delevent command.
 func (h *CalendarDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide an event ID.\nUsage: `/delevent 3`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "delevent.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	eventID, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid ID. Please provide a numeric event ID.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
 
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
@@ -280,27 +415,24 @@ func (h *CalendarDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.M
 
 	event, err := h.svc.Calendar.GetByID(ctx, eventID)
 	if err != nil || event == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Event *#%d* not found.", eventID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.not_found", eventID))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	if event.ChatID != message.Chat.ID {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Event *#%d* not found in this chat.", eventID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	if same, _ := h.svc.SameFamily(ctx, event.ChatID, chatID); !same {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.not_in_chat", eventID))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	// Only the creator can delete the event
-	if event.CreatedByID != user.ID {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ You can only delete events you created.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	// Only the creator or a family admin can delete the event
+	if err := authorize(ctx, bot, h.svc, message, user.ID, event.CreatedByID); err != nil {
+		if !errors.Is(err, service.ErrForbidden) {
+			return fmt.Errorf("authorize: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "event.delete_forbidden"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
@@ -308,10 +440,9 @@ func (h *CalendarDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.M
 		return fmt.Errorf("delete event: %w", err)
 	}
 
-	text := fmt.Sprintf("🗑 Event *#%d* deleted: %s", event.ID, event.Title)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	text := i18n.T(lang, "event.deleted", event.ID, markup.Escape(event.Title))
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id":  message.Chat.ID,
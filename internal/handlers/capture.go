@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/intent"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// parseIntent reads what the text asks for with the rules of lang first,
+// then with those of the other languages, as families often mix them.
+func parseIntent(lang, text string, now time.Time) *intent.Intent {
+	if parsed := intent.Parse(lang, text, now); parsed != nil {
+		return parsed
+	}
+	for _, code := range intent.Languages() {
+		if code == lang {
+			continue
+		}
+		if parsed := intent.Parse(code, text, now); parsed != nil {
+			return parsed
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// CaptureHandler – /capture [on|off] and plain messages
+// ---------------------------------------------------------------------------
+
+// CaptureHandler notices things to buy, do or be reminded of in plain
+// messages, such as "we need milk and bread" or "remind me tomorrow 8am to
+// call grandma", and offers to save them. Nothing is saved until the author
+// confirms with the button under the offer; the other button dismisses it.
+//
+// It is off until a family admin turns it on for the chat's family with
+// /capture on. In groups the bot only sees plain messages if it is an admin
+// or its privacy mode is turned off with BotFather.
+//
+// The offer replies to the message it is about, so pressing a button reads
+// the message again rather than keeping what was found anywhere. Callback
+// data has the form "<user id>:ok" or "<user id>:no".
+type CaptureHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewCaptureHandler creates a new CaptureHandler.
+func NewCaptureHandler(svc *service.Service, logger *logrus.Logger) *CaptureHandler {
+	return &CaptureHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /capture command.
+func (h *CaptureHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	// Capturing reads the messages of this very chat, so it is set for the
+	// chat's own family even in a private chat working on another one.
+	title := message.Chat.Title
+	if message.Chat.IsPrivate() {
+		title = i18n.T(lang, "family.private_title", message.From.FirstName)
+	}
+	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, title)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	var on bool
+	switch {
+	case len(args) == 0:
+		enabled, err := h.svc.Families.GetCapture(ctx, family.ID)
+		if err != nil {
+			return fmt.Errorf("get capture: %w", err)
+		}
+		text := i18n.T(lang, "capture.status_off")
+		if enabled {
+			text = i18n.T(lang, "capture.status_on")
+		}
+		msg := markup.NewMessage(message.Chat.ID, text+"\n\n"+i18n.T(lang, "capture.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	case strings.EqualFold(args[0], "on"):
+		on = true
+	case strings.EqualFold(args[0], "off"):
+		on = false
+	default:
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "capture.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	err = h.svc.SetFamilyCapture(ctx, family.ID, user.ID, on)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		err = h.svc.SetFamilyCapture(ctx, family.ID, user.ID, on)
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "capture.forbidden"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("set capture: %w", err)
+	}
+
+	text := i18n.T(lang, "capture.off")
+	if on {
+		text = i18n.T(lang, "capture.on")
+	}
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"capture": on,
+	}).Info("Capture changed")
+
+	return nil
+}
+
+// HandleReply offers to save what a plain message asks for, if capturing
+// is on for the chat's family.
+func (h *CaptureHandler) HandleReply(bot *tgbotapi.BotAPI, message *tgbotapi.Message) (bool, error) {
+	if message.From == nil || message.From.IsBot || message.ForwardDate != 0 || message.Caption != "" {
+		return false, nil
+	}
+
+	ctx := context.Background()
+	family, err := h.svc.Families.GetByChatID(ctx, message.Chat.ID)
+	if err != nil {
+		return false, fmt.Errorf("get family: %w", err)
+	}
+	if family == nil {
+		return false, nil
+	}
+	on, err := h.svc.Families.GetCapture(ctx, family.ID)
+	if err != nil {
+		return false, fmt.Errorf("get capture: %w", err)
+	}
+	if !on {
+		return false, nil
+	}
+
+	lang := messageLang(ctx, h.svc, message)
+	parsed := parseIntent(lang, message.Text, message.Time())
+	if parsed == nil {
+		return false, nil
+	}
+
+	var text string
+	switch parsed.Kind {
+	case intent.Buying:
+		names := make([]string, len(parsed.Items))
+		for i, item := range parsed.Items {
+			names[i] = markup.Escape(item)
+		}
+		text = i18n.T(lang, "capture.buy_offer", strings.Join(names, ", "))
+	case intent.Todo:
+		text = i18n.T(lang, "capture.todo_offer", markup.Escape(parsed.Text))
+	case intent.Remind:
+		text = i18n.T(lang, "capture.remind_offer", markup.Escape(parsed.Text), formatReminderTime(lang, parsed.At))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	msg.ReplyToMessageID = message.MessageID
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "capture.confirm_button"), fmt.Sprintf("capture:%d:ok", message.From.ID)),
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "capture.dismiss_button"), fmt.Sprintf("capture:%d:no", message.From.ID)),
+	))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"intent":  parsed.Kind,
+	}).Info("Capture offered")
+
+	return true, nil
+}
+
+// HandleCallback processes a press on a button under an offer. Only the
+// author of the message the offer is about may answer it.
+func (h *CaptureHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+	userPart, answer, _ := strings.Cut(data, ":")
+	userID, err := strconv.ParseInt(userPart, 10, 64)
+	if err != nil || userID != query.From.ID {
+		return nil
+	}
+
+	ctx := context.Background()
+	message := pageCallbackMessage(query)
+	lang := messageLang(ctx, h.svc, message)
+
+	// Either way the offer has served its purpose
+	bot.Request(tgbotapi.NewDeleteMessage(message.Chat.ID, message.MessageID))
+	if answer != "ok" {
+		return nil
+	}
+
+	var parsed *intent.Intent
+	if original := query.Message.ReplyToMessage; original != nil {
+		parsed = parseIntent(lang, original.Text, original.Time())
+	}
+	if parsed == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "capture.gone"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	switch parsed.Kind {
+	case intent.Buying:
+		return h.addItems(ctx, bot, message, lang, parsed.Items)
+	case intent.Todo:
+		return createTodo(ctx, bot, h.svc, h.logger, message, lang, parsed.Text, nil, nil)
+	case intent.Remind:
+		if !parsed.At.After(time.Now()) {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "capture.past"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		return createReminder(ctx, bot, h.svc, h.logger, message, lang, parsed.Text, parsed.At)
+	}
+	return nil
+}
+
+// addItems puts the items on the shopping list of the chat the message
+// works on and confirms it.
+func (h *CaptureHandler) addItems(ctx context.Context, bot *tgbotapi.BotAPI, message *tgbotapi.Message, lang string, names []string) error {
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	list, err := ensureBuyingList(ctx, h.svc, lang, family.ID, chatID, user.ID)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, name := range names {
+		item, err := h.svc.Buying.AddItem(ctx, &models.BuyingItem{
+			BuyingListID: list.ID,
+			Name:         name,
+			Quantity:     "1",
+			AddedByID:    user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("add buying item: %w", err)
+		}
+		lines = append(lines, fmt.Sprintf("⬜ *#%d* — %s", item.ID, markup.Escape(item.Name)))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "capture.buy_added", strings.Join(lines, "\n")))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"items":   len(names),
+	}).Info("Captured items added to shopping list")
+
+	return nil
+}
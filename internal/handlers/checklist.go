@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// formatChecklist renders a todo's checklist, one numbered item per line.
+func formatChecklist(todoID int64, items []*models.ChecklistItem) string {
+	var sb strings.Builder
+	for _, item := range items {
+		box := "⬜"
+		if item.Done {
+			box = "☑️"
+		}
+		sb.WriteString(fmt.Sprintf("\n%s `%d.%d` %s", box, todoID, item.Position, markup.Escape(item.Text)))
+	}
+	return sb.String()
+}
+
+// checklistProgress renders e.g. " ☑️ 3/7" for todos with a checklist.
+func checklistProgress(t *models.Todo) string {
+	if t.ChecklistTotal == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" ☑️ %d/%d", t.ChecklistDone, t.ChecklistTotal)
+}
+
+// ---------------------------------------------------------------------------
+// SubHandler – /sub <id> <text>
+// ---------------------------------------------------------------------------
+
+// SubHandler handles the /sub command, which adds an item to a todo's
+// checklist. `/sub <id>` shows the checklist and `/sub <id> auto on`
+// makes the todo complete itself once everything is ticked off.
+type SubHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewSubHandler creates a new SubHandler.
+func NewSubHandler(svc *service.Service, logger *logrus.Logger) *SubHandler {
+	return &SubHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /sub command.
+func (h *SubHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	todo, err := loadChatTodo(ctx, bot, h.svc, h.logger, lang, message, args)
+	if err != nil || todo == nil {
+		return err
+	}
+
+	if len(args) == 3 && args[1] == "auto" && (args[2] == "on" || args[2] == "off") {
+		return h.setAuto(ctx, bot, lang, message, todo, user.ID, args[2] == "on")
+	}
+
+	if len(args) == 1 {
+		items, err := h.svc.Todos.GetChecklist(ctx, todo.ID)
+		if err != nil {
+			return fmt.Errorf("get checklist: %w", err)
+		}
+		text := i18n.T(lang, "checklist.none", todo.ID, markup.Escape(todo.Title), todo.ID)
+		if len(items) > 0 {
+			text = i18n.T(lang, "checklist.show",
+				todo.ID, markup.Escape(todo.Title), checklistProgress(todo), formatChecklist(todo.ID, items), todo.ID)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	item, err := h.svc.AddChecklistItem(ctx, todo, strings.Join(args[1:], " "))
+	if errors.Is(err, service.ErrTodoTransition) {
+		msg := markup.NewMessage(message.Chat.ID,
+			i18n.T(lang, "checklist.closed", todo.ID, todoStatusName(lang, todo.Status), todo.ID))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("add checklist item: %w", err)
+	}
+
+	msg := markup.NewMessage(message.Chat.ID,
+		i18n.T(lang, "checklist.added",
+			todo.ID, markup.Escape(todo.Title), checklistProgress(todo), todo.ID, item.Position, markup.Escape(item.Text)))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"todo_id": todo.ID,
+		"item_id": item.ID,
+	}).Info("Checklist item added")
+
+	return nil
+}
+
+func (h *SubHandler) setAuto(ctx context.Context, bot *tgbotapi.BotAPI, lang string, message *tgbotapi.Message, todo *models.Todo, userID int64, on bool) error {
+	err := h.svc.SetAutoComplete(ctx, todo, userID, on)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, userID) {
+		err = h.svc.SetAutoComplete(ctx, todo, userID, on)
+	}
+
+	var text string
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		text = i18n.T(lang, "todo.change_forbidden")
+	case err != nil:
+		return fmt.Errorf("set auto-complete: %w", err)
+	case on:
+		text = i18n.T(lang, "checklist.auto_on", todo.ID)
+	default:
+		text = i18n.T(lang, "checklist.auto_off", todo.ID, todo.ID)
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// CheckHandler – /check <id>.<n>
+// ---------------------------------------------------------------------------
+
+// CheckHandler handles the /check command, which ticks a checklist item off
+// or, if it is already ticked, back on.
+type CheckHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewCheckHandler creates a new CheckHandler.
+func NewCheckHandler(svc *service.Service, logger *logrus.Logger) *CheckHandler {
+	return &CheckHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /check command.
+func (h *CheckHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	// Accept both "/check 12.3" and "/check 12 3"
+	if len(args) == 1 {
+		args = strings.SplitN(args[0], ".", 2)
+	}
+	var n int
+	var err error
+	if len(args) == 2 {
+		n, err = strconv.Atoi(args[1])
+	}
+	if len(args) != 2 || err != nil || n < 1 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "checklist.check_usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	todo, err := loadChatTodo(ctx, bot, h.svc, h.logger, lang, message, args[:1])
+	if err != nil || todo == nil {
+		return err
+	}
+
+	result, err := h.svc.ToggleChecklistItem(ctx, todo, user.ID, n)
+	if errors.Is(err, service.ErrChecklistItemNotFound) {
+		msg := markup.NewMessage(message.Chat.ID,
+			i18n.T(lang, "checklist.no_item", todo.ID, n, todo.ID))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("toggle checklist item: %w", err)
+	}
+
+	box := "⬜"
+	if result.Item.Done {
+		box = "☑️"
+	}
+	text := fmt.Sprintf("%s `%d.%d` %s\n\n*#%d* %s%s",
+		box, todo.ID, result.Item.Position, markup.Escape(result.Item.Text), todo.ID, markup.Escape(todo.Title), checklistProgress(todo))
+	switch {
+	case result.Completed && result.Next != nil:
+		text += "\n\n" + i18n.T(lang, "checklist.completed_next", result.Next.ID,
+			assigneeName(ctx, h.svc, lang, result.Next.AssignedToID), i18n.Date(lang, *result.Next.Deadline, "Mon, 02 Jan"))
+	case result.Completed:
+		text += "\n\n" + i18n.T(lang, "checklist.completed")
+	case todo.IsPending() && todo.ChecklistDone == todo.ChecklistTotal:
+		text += "\n\n" + i18n.T(lang, "checklist.all_ticked", todo.ID)
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   message.Chat.ID,
+		"user_id":   message.From.ID,
+		"todo_id":   todo.ID,
+		"item":      n,
+		"done":      result.Item.Done,
+		"completed": result.Completed,
+	}).Info("Checklist item toggled")
+
+	return nil
+}
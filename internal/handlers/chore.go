@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/repository"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// parseRecurrence parses the interval of a chore.
+func parseRecurrence(s string) (models.TodoRecurrence, bool) {
+	switch strings.ToLower(s) {
+	case "daily", "day", "ежедневно":
+		return models.TodoRecurrenceDaily, true
+	case "weekly", "week", "еженедельно":
+		return models.TodoRecurrenceWeekly, true
+	case "monthly", "month", "ежемесячно":
+		return models.TodoRecurrenceMonthly, true
+	}
+	return "", false
+}
+
+// splitMentions separates @username arguments from the rest and looks the
+// users up. It returns a message for the chat in lang when a user is
+// unknown.
+func splitMentions(ctx context.Context, svc *service.Service, lang string, args []string) ([]*models.User, []string, string) {
+	var users []*models.User
+	var rest []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "@") || len(arg) == 1 {
+			rest = append(rest, arg)
+			continue
+		}
+		username := strings.TrimPrefix(arg, "@")
+		user, err := svc.Users.GetByUsername(ctx, username)
+		if err != nil || user == nil {
+			return nil, nil, i18n.T(lang, "user.not_found", markup.Escape(username))
+		}
+		users = append(users, user)
+	}
+	return users, rest, ""
+}
+
+// rotationNames lists the members of a rotation in order.
+func rotationNames(ctx context.Context, svc *service.Service, lang string, rotation []int64) string {
+	names := make([]string, 0, len(rotation))
+	for _, id := range rotation {
+		names = append(names, assigneeName(ctx, svc, lang, &id))
+	}
+	return strings.Join(names, " → ")
+}
+
+// ---------------------------------------------------------------------------
+// ChoreAddHandler – /chore <interval> <title> [@user ...]
+// ---------------------------------------------------------------------------
+
+// ChoreAddHandler handles the /chore command, which adds a recurring todo.
+// Completing it creates the next occurrence, assigned to the next of the
+// mentioned members in turn.
+type ChoreAddHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewChoreAddHandler creates a new ChoreAddHandler.
+func NewChoreAddHandler(svc *service.Service, logger *logrus.Logger) *ChoreAddHandler {
+	return &ChoreAddHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /chore command.
+func (h *ChoreAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+	usage := i18n.T(lang, "chore.usage")
+
+	var recurrence models.TodoRecurrence
+	ok := len(args) >= 2
+	if ok {
+		recurrence, ok = parseRecurrence(args[0])
+	}
+	if !ok {
+		msg := markup.NewMessage(message.Chat.ID, usage)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	members, words, problem := splitMentions(ctx, h.svc, lang, args[1:])
+	if problem == "" && len(words) == 0 {
+		problem = usage
+	}
+	if problem != "" {
+		msg := markup.NewMessage(message.Chat.ID, problem)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	rotation := []int64{user.ID}
+	if len(members) > 0 {
+		rotation = rotation[:0]
+		for _, m := range members {
+			rotation = append(rotation, m.ID)
+		}
+	}
+
+	todo, err := h.svc.CreateChore(ctx, &models.Todo{
+		Title:       strings.Join(words, " "),
+		Priority:    models.TodoPriorityMedium,
+		CreatedByID: user.ID,
+		ChatID:      chatID,
+		Recurrence:  recurrence,
+		Rotation:    rotation,
+	})
+	if errors.Is(err, service.ErrNotFamilyMember) {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "chore.rotation_members"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("create chore: %w", err)
+	}
+
+	text := i18n.T(lang, "chore.added",
+		priorityEmoji(todo.Priority), todo.ID, markup.Escape(todo.Title), i18n.T(lang, "recurrence."+string(todo.Recurrence)),
+		rotationNames(ctx, h.svc, lang, todo.Rotation), assigneeName(ctx, h.svc, lang, todo.AssignedToID),
+		i18n.Date(lang, *todo.Deadline, "Mon, 02 Jan"))
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":    message.Chat.ID,
+		"user_id":    message.From.ID,
+		"todo_id":    todo.ID,
+		"recurrence": todo.Recurrence,
+	}).Info("Chore created")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// RotationHandler – /rotation <id> @user ...
+// ---------------------------------------------------------------------------
+
+// RotationHandler handles the /rotation command, which changes who takes
+// turns on a chore and in which order. The change applies from the next
+// occurrence on.
+type RotationHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewRotationHandler creates a new RotationHandler.
+func NewRotationHandler(svc *service.Service, logger *logrus.Logger) *RotationHandler {
+	return &RotationHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /rotation command.
+func (h *RotationHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	todo, err := loadChatTodo(ctx, bot, h.svc, h.logger, lang, message, args)
+	if err != nil || todo == nil {
+		return err
+	}
+	if !todo.IsRecurring() {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "chore.not_chore", todo.ID))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	members, _, problem := splitMentions(ctx, h.svc, lang, args[1:])
+	if problem == "" && len(members) == 0 {
+		problem = i18n.T(lang, "chore.rotation_show",
+			todo.ID, markup.Escape(todo.Title), rotationNames(ctx, h.svc, lang, todo.Rotation), todo.ID)
+	}
+	if problem != "" {
+		msg := markup.NewMessage(message.Chat.ID, problem)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	rotation := make([]int64, 0, len(members))
+	for _, m := range members {
+		rotation = append(rotation, m.ID)
+	}
+
+	err = h.svc.SetRotation(ctx, todo, user.ID, rotation)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		err = h.svc.SetRotation(ctx, todo, user.ID, rotation)
+	}
+
+	var text string
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		text = i18n.T(lang, "chore.rotation_forbidden")
+	case errors.Is(err, service.ErrNotFamilyMember):
+		text = i18n.T(lang, "chore.rotation_members")
+	case err != nil:
+		return fmt.Errorf("set rotation: %w", err)
+	default:
+		text = i18n.T(lang, "chore.rotation_set", todo.ID, markup.Escape(todo.Title), rotationNames(ctx, h.svc, lang, rotation))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"todo_id": todo.ID,
+	}).Info("Processed chore rotation change")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// ChoresHandler – /chores
+// ---------------------------------------------------------------------------
+
+// ChoresHandler handles the /chores command: an overview of who has which
+// chore this week, followed by the chores due later.
+type ChoresHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewChoresHandler creates a new ChoresHandler.
+func NewChoresHandler(svc *service.Service, logger *logrus.Logger) *ChoresHandler {
+	return &ChoresHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /chores command.
+func (h *ChoresHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
+
+	status := models.TodoStatusPending
+	todos, err := h.svc.Todos.GetByChatID(ctx, chatID, repository.TodoFilters{Status: &status})
+	if err != nil {
+		return fmt.Errorf("list todos: %w", err)
+	}
+
+	// The week ends on Sunday night
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekEnd := today.AddDate(0, 0, (7-int(today.Weekday()))%7+1)
+
+	var names []string
+	thisWeek := make(map[string][]string)
+	var later []string
+	for _, t := range todos {
+		if !t.IsRecurring() {
+			continue
+		}
+		line := fmt.Sprintf("• *#%d* %s — %s", t.ID, markup.Escape(t.Title), i18n.Date(lang, *t.Deadline, "Mon 02 Jan"))
+		if t.IsOverdue() {
+			line += " ⚠️"
+		}
+		if !t.Deadline.Before(weekEnd) {
+			later = append(later, fmt.Sprintf("%s (%s)", line, assigneeName(ctx, h.svc, lang, t.AssignedToID)))
+			continue
+		}
+		name := assigneeName(ctx, h.svc, lang, t.AssignedToID)
+		if _, seen := thisWeek[name]; !seen {
+			names = append(names, name)
+		}
+		thisWeek[name] = append(thisWeek[name], line)
+	}
+
+	if len(names) == 0 && len(later) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "chores.empty"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "chores.heading") + "\n")
+	if len(names) == 0 {
+		sb.WriteString("\n_" + i18n.T(lang, "chores.none_this_week") + "_\n")
+	}
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("\n👤 *%s*\n%s\n", name, strings.Join(thisWeek[name], "\n")))
+	}
+	if len(later) > 0 {
+		sb.WriteString(fmt.Sprintf("\n📆 *%s*\n%s\n", i18n.T(lang, "chores.later"), strings.Join(later, "\n")))
+	}
+	sb.WriteString("\n" + i18n.T(lang, "chores.footer"))
+
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"count":   len(names) + len(later),
+	}).Info("Listed chores")
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/dialog"
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// Answers carried by the buttons under a question besides the choices
+// themselves. Typed answers are never empty, so a skipped question is
+// answered with "".
+const (
+	dialogSkip   = "skip"
+	dialogCancel = "cancel"
+)
+
+// dialogFlow continues a conversation with the user's answer to the
+// question at state.Step: the text they typed or the choice they pressed.
+// message is the user's reply, or the question for pressed buttons.
+type dialogFlow func(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, state *dialog.State, answer string) error
+
+// dialogFlows maps conversation names to the flows that continue them.
+var dialogFlows = map[string]dialogFlow{
+	"event":  eventDialog,
+	"remind": remindDialog,
+}
+
+// dialogPickers maps conversation names to the date pickers they ask their
+// current question with, if any. Presses on a picker's pages are handled
+// here; the flow gets the picked date as "YYYY-MM-DD" or "YYYY-MM-DD HH:MM",
+// just as it could have been typed.
+var dialogPickers = map[string]func(lang string, state *dialog.State) *telegram.DatePicker{
+	"event":  eventPicker,
+	"remind": remindPicker,
+}
+
+// ---------------------------------------------------------------------------
+// DialogHandler – answers in multi-step conversations
+// ---------------------------------------------------------------------------
+
+// DialogHandler passes the user's answers on to the conversation they are
+// in, whether typed or pressed as a button under the question. In groups
+// only replies to the bot count as answers, so talking to the family in
+// between does not get mistaken for one.
+type DialogHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewDialogHandler creates a new DialogHandler.
+func NewDialogHandler(svc *service.Service, logger *logrus.Logger) *DialogHandler {
+	return &DialogHandler{svc: svc, logger: logger}
+}
+
+// HandleReply continues the conversation the message answers, if any.
+func (h *DialogHandler) HandleReply(bot *tgbotapi.BotAPI, message *tgbotapi.Message) (bool, error) {
+	if message.From == nil {
+		return false, nil
+	}
+	if !message.Chat.IsPrivate() {
+		reply := message.ReplyToMessage
+		if reply == nil || reply.From == nil || reply.From.ID != bot.Self.ID {
+			return false, nil
+		}
+	}
+
+	state, err := h.svc.Dialogs.Get(context.Background(), message.Chat.ID, message.From.ID)
+	if err != nil {
+		return true, fmt.Errorf("get dialog: %w", err)
+	}
+	if state == nil {
+		return false, nil
+	}
+	answer := strings.TrimSpace(message.Text)
+	if answer == "" {
+		return false, nil
+	}
+
+	return true, h.continueDialog(bot, message, state, answer)
+}
+
+// HandleCallback processes a press on a button under a question. The data
+// has the form "<user id>:<step>:<answer>"; only the user who was asked
+// may answer, and only the question they are at.
+func (h *DialogHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+	parts := strings.SplitN(data, ":", 3)
+	if len(parts) != 3 {
+		return nil
+	}
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || userID != query.From.ID {
+		return nil
+	}
+	step, answer := parts[1], parts[2]
+
+	ctx := context.Background()
+	message := pageCallbackMessage(query)
+	lang := messageLang(ctx, h.svc, message)
+
+	state, err := h.svc.Dialogs.Get(ctx, message.Chat.ID, query.From.ID)
+	if err != nil {
+		return fmt.Errorf("get dialog: %w", err)
+	}
+	if state == nil {
+		clearKeyboard(bot, h.logger, message)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "dialog.expired"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if state.Step != step {
+		clearKeyboard(bot, h.logger, message)
+		return nil
+	}
+
+	switch answer {
+	case dialogCancel:
+		clearKeyboard(bot, h.logger, message)
+		if _, err := h.svc.Dialogs.End(ctx, message.Chat.ID, query.From.ID); err != nil {
+			return fmt.Errorf("end dialog: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "dialog.cancelled"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	case dialogSkip:
+		answer = ""
+	default:
+		if picker, ok := dialogPickers[state.Name]; ok {
+			if p := picker(lang, state); p != nil {
+				next, picked, withTime := p.Update(answer)
+				if next != nil {
+					telegram.Send(bot, h.logger, tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID, *next))
+					return nil
+				}
+				if picked.IsZero() {
+					return nil
+				}
+				answer = pickedAnswer(picked, withTime)
+			}
+		}
+	}
+
+	// The question is answered, so its buttons go.
+	clearKeyboard(bot, h.logger, message)
+	return h.continueDialog(bot, message, state, answer)
+}
+
+// continueDialog hands the answer to the conversation's flow.
+func (h *DialogHandler) continueDialog(bot *tgbotapi.BotAPI, message *tgbotapi.Message, state *dialog.State, answer string) error {
+	ctx := context.Background()
+	flow, ok := dialogFlows[state.Name]
+	if !ok {
+		h.svc.Dialogs.End(ctx, state.ChatID, state.UserID)
+		return fmt.Errorf("unknown dialog %q", state.Name)
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": state.ChatID,
+		"user_id": state.UserID,
+		"dialog":  state.Name,
+		"step":    state.Step,
+	}).Info("Dialog answered")
+
+	return flow(ctx, bot, h.svc, h.logger, message, state, answer)
+}
+
+// clearKeyboard removes the buttons under the message.
+func clearKeyboard(bot *tgbotapi.BotAPI, logger *logrus.Logger, message *tgbotapi.Message) {
+	telegram.Send(bot, logger, tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID,
+		tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+}
+
+// pickedAnswer writes what was picked on a date picker the way it would be
+// typed.
+func pickedAnswer(picked time.Time, withTime bool) string {
+	if withTime {
+		return picked.Format("2006-01-02 15:04")
+	}
+	return picked.Format("2006-01-02")
+}
+
+// dialogPicker returns a date picker answering the conversation's current
+// question, with the controls to stop it underneath.
+func dialogPicker(lang string, state *dialog.State) *telegram.DatePicker {
+	return &telegram.DatePicker{
+		Prefix: fmt.Sprintf("dialog:%d:%s:", state.UserID, state.Step),
+		Lang:   lang,
+		Footer: dialogControls(lang, state, false),
+	}
+}
+
+// cancelDialog ends the user's conversation in the message's chat and says
+// so. It reports whether they were in one.
+func cancelDialog(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger, message *tgbotapi.Message) (bool, error) {
+	ended, err := svc.Dialogs.End(ctx, message.Chat.ID, message.From.ID)
+	if err != nil || !ended {
+		return false, err
+	}
+	msg := markup.NewMessage(message.Chat.ID, i18n.T(messageLang(ctx, svc, message), "dialog.cancelled"))
+	telegram.Send(bot, logger, msg)
+	return true, nil
+}
+
+// askDialog sends the question for the conversation's current step. Without
+// buttons of its own, the question asks for a reply so the answer reaches
+// the bot in groups too.
+func askDialog(bot *tgbotapi.BotAPI, logger *logrus.Logger, message *tgbotapi.Message, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	msg := markup.NewMessage(message.Chat.ID, text)
+	switch {
+	case keyboard != nil:
+		msg.ReplyMarkup = *keyboard
+	case !message.Chat.IsPrivate():
+		msg.ReplyToMessageID = message.MessageID
+		msg.ReplyMarkup = tgbotapi.ForceReply{ForceReply: true, Selective: true}
+	}
+	telegram.Send(bot, logger, msg)
+}
+
+// dialogButton answers the conversation's current question with answer.
+func dialogButton(state *dialog.State, label, answer string) tgbotapi.InlineKeyboardButton {
+	data := fmt.Sprintf("dialog:%d:%s:%s", state.UserID, state.Step, answer)
+	return tgbotapi.NewInlineKeyboardButtonData(label, data)
+}
+
+// dialogControls is the row under a question that skips it, if skippable,
+// or stops the conversation.
+func dialogControls(lang string, state *dialog.State, skippable bool) []tgbotapi.InlineKeyboardButton {
+	var row []tgbotapi.InlineKeyboardButton
+	if skippable {
+		row = append(row, dialogButton(state, i18n.T(lang, "dialog.skip_button"), dialogSkip))
+	}
+	return append(row, dialogButton(state, i18n.T(lang, "dialog.cancel_button"), dialogCancel))
+}
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// syncChatAdmin makes the sender a family admin if they administer the
+// group chat in Telegram, unless they were demoted in the family. It only
+// asks Telegram in group chats and reports whether the sender is an admin.
+func syncChatAdmin(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, message *tgbotapi.Message, userID int64) bool {
+	if !message.Chat.IsGroup() && !message.Chat.IsSuperGroup() {
+		return false
+	}
+
+	member, err := bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: message.Chat.ID, UserID: message.From.ID},
+	})
+	if err != nil || (!member.IsCreator() && !member.IsAdministrator()) {
+		return false
+	}
+
+	family, err := svc.Families.GetByChatID(ctx, message.Chat.ID)
+	if err != nil || family == nil {
+		return false
+	}
+	granted, err := svc.GrantChatAdmin(ctx, family.ID, userID)
+	return err == nil && granted
+}
+
+// authorize checks that the sender may change something in the chat's
+// family owned by ownerIDs. Telegram group administrators are made family
+// admins on the way, so they never need to be promoted by hand, unless they
+// were demoted.
+func authorize(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, message *tgbotapi.Message, userID int64, ownerIDs ...int64) error {
+	chatID, _ := workspaceChat(ctx, svc, message, "")
+	err := svc.AuthorizeChat(ctx, chatID, userID, ownerIDs...)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, svc, message, userID) {
+		return nil
+	}
+	return err
+}
+
+// roleTarget resolves whose role /promote and /demote change: the author of
+// the replied-to message, or the @username argument.
+func roleTarget(ctx context.Context, svc *service.Service, lang string, message *tgbotapi.Message, args []string) (*models.User, string) {
+	if reply := message.ReplyToMessage; reply != nil && reply.From != nil && !reply.From.IsBot {
+		user, err := svc.EnsureUser(ctx, reply.From.ID, reply.From.UserName, reply.From.FirstName, reply.From.LastName)
+		if err != nil {
+			return nil, i18n.T(lang, "role.lookup_failed")
+		}
+		return user, ""
+	}
+
+	if len(args) == 0 || !strings.HasPrefix(args[0], "@") {
+		return nil, ""
+	}
+	username := strings.TrimPrefix(args[0], "@")
+	user, err := svc.Users.GetByUsername(ctx, username)
+	if err != nil || user == nil {
+		return nil, i18n.T(lang, "user.not_found", markup.Escape(username))
+	}
+	return user, ""
+}
+
+// changeRole implements /promote and /demote.
+func changeRole(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, args []string, role string) error {
+	lang := messageLang(ctx, svc, message)
+	target, problem := roleTarget(ctx, svc, lang, message, args)
+	if target == nil {
+		if problem == "" {
+			problem = i18n.T(lang, "role.usage", message.Command())
+		}
+		msg := markup.NewMessage(message.Chat.ID, problem)
+		telegram.Send(bot, logger, msg)
+		return nil
+	}
+
+	user, err := svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, _ := workspaceChat(ctx, svc, message, "")
+	family, err := svc.Families.GetByChatID(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("get family: %w", err)
+	}
+	if family == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "role.no_family"))
+		telegram.Send(bot, logger, msg)
+		return nil
+	}
+
+	err = svc.SetFamilyRole(ctx, family.ID, user.ID, target.ID, role)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, svc, message, user.ID) {
+		err = svc.SetFamilyRole(ctx, family.ID, user.ID, target.ID, role)
+	}
+
+	var text string
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		text = i18n.T(lang, "role.forbidden")
+	case errors.Is(err, service.ErrNotFamilyMember):
+		text = i18n.T(lang, "role.not_member", markup.Escape(target.DisplayName()))
+	case errors.Is(err, service.ErrLastAdmin):
+		text = i18n.T(lang, "role.last_admin")
+	case err != nil:
+		return fmt.Errorf("set family role: %w", err)
+	case role == models.FamilyRoleAdmin:
+		text = i18n.T(lang, "role.admin", markup.Escape(target.DisplayName()))
+	default:
+		text = i18n.T(lang, "role.member", markup.Escape(target.DisplayName()))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, logger, msg)
+
+	logger.WithFields(logrus.Fields{
+		"chat_id":   message.Chat.ID,
+		"user_id":   message.From.ID,
+		"target_id": target.ID,
+		"role":      role,
+	}).Info("Processed family role change")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// PromoteHandler – /promote @user
+// ---------------------------------------------------------------------------
+
+// PromoteHandler handles the /promote command. Family admins can make
+// another member an admin, who may then change and delete anything in the
+// family.
+type PromoteHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewPromoteHandler creates a new PromoteHandler.
+func NewPromoteHandler(svc *service.Service, logger *logrus.Logger) *PromoteHandler {
+	return &PromoteHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /promote command.
+func (h *PromoteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	return changeRole(context.Background(), bot, h.svc, h.logger, message, args, models.FamilyRoleAdmin)
+}
+
+// ---------------------------------------------------------------------------
+// DemoteHandler – /demote @user
+// ---------------------------------------------------------------------------
+
+// DemoteHandler handles the /demote command. Family admins can turn another
+// admin, or themselves, back into a regular member as long as one admin
+// remains.
+type DemoteHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewDemoteHandler creates a new DemoteHandler.
+func NewDemoteHandler(svc *service.Service, logger *logrus.Logger) *DemoteHandler {
+	return &DemoteHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /demote command.
+func (h *DemoteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	return changeRole(context.Background(), bot, h.svc, h.logger, message, args, models.FamilyRoleMember)
+}
+
+// ---------------------------------------------------------------------------
+// LinkHandler – /link
+// ---------------------------------------------------------------------------
+
+// LinkHandler handles the /link command. Family admins get a one-time code
+// that attaches another chat, e.g. a private chat with the bot, to the
+// family so both share todos, the shopping list, the calendar and reminders.
+type LinkHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewLinkHandler creates a new LinkHandler.
+func NewLinkHandler(svc *service.Service, logger *logrus.Logger) *LinkHandler {
+	return &LinkHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /link command.
+func (h *LinkHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, message.Chat.Title)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	code, expiresAt, err := h.svc.CreateLinkCode(ctx, family.ID, user.ID)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		code, expiresAt, err = h.svc.CreateLinkCode(ctx, family.ID, user.ID)
+	}
+	if errors.Is(err, service.ErrForbidden) {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "link.forbidden"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("create link code: %w", err)
+	}
+
+	msg := markup.NewMessage(message.Chat.ID,
+		i18n.T(lang, "link.code", markup.Escape(family.Name), code, code, i18n.Date(lang, expiresAt, "02 Jan 15:04")))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   message.Chat.ID,
+		"user_id":   message.From.ID,
+		"family_id": family.ID,
+	}).Info("Created family link code")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// JoinHandler – /join <code>
+// ---------------------------------------------------------------------------
+
+// JoinHandler handles the /join command, which attaches the chat to the
+// family a /link code was created for. If the chat had a family of its own,
+// only its admins can move it; a family left without chats is merged into
+// the new one.
+type JoinHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewJoinHandler creates a new JoinHandler.
+func NewJoinHandler(svc *service.Service, logger *logrus.Logger) *JoinHandler {
+	return &JoinHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /join command.
+func (h *JoinHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "join.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	current, err := h.svc.Families.GetByChatID(ctx, message.Chat.ID)
+	if err != nil {
+		return fmt.Errorf("get family: %w", err)
+	}
+	if current != nil {
+		err := h.svc.Authorize(ctx, current.ID, user.ID)
+		if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+			err = nil
+		}
+		if err != nil {
+			if !errors.Is(err, service.ErrForbidden) {
+				return fmt.Errorf("authorize: %w", err)
+			}
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "join.forbidden"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+	}
+
+	family, err := h.svc.JoinFamily(ctx, args[0], message.Chat.ID, user.ID)
+	if errors.Is(err, service.ErrInvalidLinkCode) {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "join.invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("join family: %w", err)
+	}
+
+	text := i18n.T(lang, "join.done", markup.Escape(family.Name))
+	if current != nil && current.ID == family.ID {
+		text = i18n.T(lang, "join.already", markup.Escape(family.Name))
+	}
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   message.Chat.ID,
+		"user_id":   message.From.ID,
+		"family_id": family.ID,
+	}).Info("Linked chat to family")
+
+	return nil
+}
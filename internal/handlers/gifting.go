@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// progressBarWidth is the number of cells in a pledge progress bar.
+const progressBarWidth = 10
+
+// pledgeProgress renders the funding state of a group gift, e.g.
+// "💰 30.00 / 100.00 ▓▓▓░░░░░░░ 30%". It returns an empty string for items
+// nobody has pledged toward.
+func pledgeProgress(lang string, item *models.WishItem, indent string) string {
+	if item.Pledged <= 0 {
+		return ""
+	}
+
+	target, ok := service.WishTarget(item.Price)
+	if !ok {
+		return fmt.Sprintf("%s💰 _%s_\n", indent, i18n.T(lang, "pledge.pledged", item.Pledged))
+	}
+
+	ratio := item.Pledged / target
+	filled := int(ratio * progressBarWidth)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	bar := strings.Repeat("▓", filled) + strings.Repeat("░", progressBarWidth-filled)
+
+	return fmt.Sprintf("%s💰 %.2f / %.2f %s %d%%\n", indent, item.Pledged, target, bar, int(ratio*100))
+}
+
+// ---------------------------------------------------------------------------
+// PledgeHandler – /pledge <id> <amount>
+// ---------------------------------------------------------------------------
+
+// PledgeHandler handles the /pledge command to contribute toward a wish item
+// together with other family members. The first contributor reserves the
+// item and becomes the organizer who buys it.
+type PledgeHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewPledgeHandler creates a new PledgeHandler.
+func NewPledgeHandler(svc *service.Service, logger *logrus.Logger) *PledgeHandler {
+	return &PledgeHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /pledge command.
+func (h *PledgeHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) < 2 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "pledge.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	itemID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	amount, ok := parsePrice(args[1])
+	if !ok || amount <= 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "pledge.amount_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	item, err := h.svc.PledgeWish(ctx, itemID, user.ID, amount)
+	if err != nil {
+		var text string
+		switch {
+		case errors.Is(err, service.ErrWishNotFound):
+			text = i18n.T(lang, "wish.not_found", itemID)
+		case errors.Is(err, service.ErrWishForbidden):
+			text = i18n.T(lang, "pledge.own")
+		case errors.Is(err, service.ErrWishPurchased):
+			text = i18n.T(lang, "wish.purchased_already", itemID)
+		default:
+			return fmt.Errorf("pledge wish item: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "pledge.done", amount, item.ID, markup.Escape(item.Name)) + "\n\n")
+	sb.WriteString(pledgeProgress(lang, item, ""))
+	if item.ReservedByID != nil && *item.ReservedByID == user.ID {
+		sb.WriteString("\n" + i18n.T(lang, "pledge.organizer", item.ID))
+	} else if item.ReservedBy != nil {
+		sb.WriteString("\n_" + i18n.T(lang, "pledge.organized_by", markup.Escape(item.ReservedBy.DisplayName())) + "_")
+	}
+
+	replyPrivately(bot, h.logger, message, sb.String(), i18n.T(lang, "pledge.done_public"))
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"item_id": itemID,
+		"amount":  amount,
+	}).Info("Wish item pledge saved")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// UnpledgeHandler – /unpledge <id>
+// ---------------------------------------------------------------------------
+
+// UnpledgeHandler handles the /unpledge command to withdraw a pledge.
+type UnpledgeHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewUnpledgeHandler creates a new UnpledgeHandler.
+func NewUnpledgeHandler(svc *service.Service, logger *logrus.Logger) *UnpledgeHandler {
+	return &UnpledgeHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /unpledge command.
+func (h *UnpledgeHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wish.id_missing", "unpledge"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	itemID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	if err = h.svc.UnpledgeWish(ctx, itemID, user.ID); err != nil {
+		var text string
+		switch {
+		case errors.Is(err, service.ErrWishNotFound):
+			text = i18n.T(lang, "wish.not_found", itemID)
+		case errors.Is(err, service.ErrWishForbidden):
+			text = i18n.T(lang, "unpledge.not_yours", itemID)
+		case errors.Is(err, service.ErrWishHasPledges):
+			text = i18n.T(lang, "unreserve.pledged")
+		case errors.Is(err, service.ErrWishPurchased):
+			text = i18n.T(lang, "wish.purchased_already", itemID)
+		default:
+			return fmt.Errorf("unpledge wish item: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	replyPrivately(bot, h.logger, message, i18n.T(lang, "unpledge.done", itemID), i18n.T(lang, "unpledge.done_public"))
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"item_id": itemID,
+	}).Info("Wish item pledge withdrawn")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// PurchasedHandler – /purchased <id> [amount]
+// ---------------------------------------------------------------------------
+
+// PurchasedHandler handles the /purchased command. The organizer of a gift
+// marks it as bought and everyone who pledged is told what they owe.
+type PurchasedHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewPurchasedHandler creates a new PurchasedHandler.
+func NewPurchasedHandler(svc *service.Service, logger *logrus.Logger) *PurchasedHandler {
+	return &PurchasedHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /purchased command.
+func (h *PurchasedHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "purchased.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	itemID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var paid *float64
+	if len(args) > 1 {
+		v, ok := parsePrice(args[1])
+		if !ok {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "purchased.amount_invalid"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		paid = &v
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	settlement, err := h.svc.MarkWishPurchased(ctx, itemID, user.ID, paid)
+	if err != nil {
+		var text string
+		switch {
+		case errors.Is(err, service.ErrWishNotFound):
+			text = i18n.T(lang, "wish.not_found", itemID)
+		case errors.Is(err, service.ErrWishForbidden):
+			text = i18n.T(lang, "purchased.forbidden")
+		case errors.Is(err, service.ErrWishPurchased):
+			text = i18n.T(lang, "wish.purchased_already", itemID)
+		default:
+			return fmt.Errorf("mark wish item purchased: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	organizer := markup.Escape(user.DisplayName())
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "purchased.heading", settlement.Item.ID, markup.Escape(settlement.Item.Name)) + "\n\n")
+	sb.WriteString(i18n.T(lang, "purchased.paid_by", organizer, settlement.Paid))
+	if settlement.Pledged > 0 && settlement.Pledged != settlement.Paid {
+		sb.WriteString(" (" + i18n.T(lang, "purchased.pledged", settlement.Pledged) + ")")
+	}
+	sb.WriteString("\n\n")
+
+	if len(settlement.Shares) == 0 {
+		sb.WriteString(i18n.T(lang, "purchased.nothing"))
+	} else {
+		sb.WriteString(i18n.T(lang, "purchased.settle") + "\n")
+		for _, share := range settlement.Shares {
+			sb.WriteString("• " + i18n.T(lang, "purchased.owes", markup.Escape(share.User.DisplayName()), organizer, share.Amount) + "\n")
+		}
+	}
+
+	replyPrivately(bot, h.logger, message, sb.String(), i18n.T(lang, "purchased.done_public"))
+
+	// Tell each contributor their share privately
+	for _, share := range settlement.Shares {
+		if share.User.TelegramID == 0 {
+			continue
+		}
+		shareLang := h.svc.ChatLanguage(ctx, share.User.TelegramID, share.User.ID)
+		msg := markup.NewMessage(share.User.TelegramID,
+			i18n.T(shareLang, "purchased.share", organizer, markup.Escape(settlement.Item.Name), share.Amount))
+		if _, sendErr := bot.Send(msg); sendErr != nil {
+			h.logger.WithError(sendErr).WithField("user_id", share.User.ID).Warn("Failed to send settle-up message")
+		}
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"item_id": itemID,
+		"paid":    settlement.Paid,
+		"shares":  len(settlement.Shares),
+	}).Info("Group gift purchased")
+
+	return nil
+}
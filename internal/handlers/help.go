@@ -1,56 +1,40 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
 )
 
-// HelpHandler handles the /help command
+// CommandLister lists the registered commands.
+type CommandLister interface {
+	Commands() []telegram.Command
+}
+
+// HelpHandler handles the /help command. The help lists the registered
+// commands by section, the same ones the command menus show.
 type HelpHandler struct {
-	logger *logrus.Logger
+	svc      *service.Service
+	logger   *logrus.Logger
+	commands CommandLister
 }
 
-func NewHelpHandler(logger *logrus.Logger) *HelpHandler {
-	return &HelpHandler{logger: logger}
+func NewHelpHandler(svc *service.Service, logger *logrus.Logger, commands CommandLister) *HelpHandler {
+	return &HelpHandler{svc: svc, logger: logger, commands: commands}
 }
 
 func (h *HelpHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
-	helpText := `📚 *TodoboT Help*
-
-*Todos:*
-• /add <text> - Add a new todo
-• /list - Show pending todos
-• /done <id> - Complete a todo
-• /delete <id> - Delete a todo
-• /my - Show your assigned todos
-
-*Calendar:*
-• /event <title> <YYYY-MM-DD> [HH:MM] - Add event
-• /events - Show upcoming events
-• /delevent <id> - Delete an event
-
-*Shopping List:*
-• /buy <item> [x qty] - Add to shopping list
-• /buylist - Show shopping list
-• /bought <id> - Mark item as bought
-• /buyclear - Clear bought items
-
-*Wish Lists:*
-• /wish <item> - Add to your wish list
-• /wishlist [@user] - View wish lists
-• /reserve <id> - Reserve a wish item
+	helpText := h.help(messageLang(context.Background(), h.svc, message))
 
-*Reminders:*
-• /remind <time> <text> - Set reminder
-• /reminders - Show your reminders
-• /delremind <id> - Delete reminder
-
-_Time formats: 10m, 2h, 1d, 15:30, 2025-01-15 15:30_`
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, helpText)
-	msg.ParseMode = tgbotapi.ModeMarkdown
+	msg := markup.NewMessage(message.Chat.ID, helpText)
 
 	_, err := bot.Send(msg)
 	if err != nil {
@@ -64,3 +48,27 @@ _Time formats: 10m, 2h, 1d, 15:30, 2025-01-15 15:30_`
 
 	return nil
 }
+
+// help returns the help text in lang: the lines "help.<name>" of every
+// command with a section, under the section headings in the order the
+// sections were first registered.
+func (h *HelpHandler) help(lang string) string {
+	var sections []string
+	lines := make(map[string][]string)
+	for _, command := range h.commands.Commands() {
+		if command.Section == "" {
+			continue
+		}
+		if _, ok := lines[command.Section]; !ok {
+			sections = append(sections, command.Section)
+		}
+		lines[command.Section] = append(lines[command.Section], i18n.T(lang, "help."+command.Name))
+	}
+
+	parts := []string{i18n.T(lang, "help.title")}
+	for _, section := range sections {
+		parts = append(parts, i18n.T(lang, "help.section."+section)+"\n"+strings.Join(lines[section], "\n"))
+	}
+	parts = append(parts, i18n.T(lang, "help.footer"))
+	return strings.Join(parts, "\n\n")
+}
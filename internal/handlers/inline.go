@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// inlineResultLimit caps how many results an inline query shows.
+const inlineResultLimit = 20
+
+// inlineTypes are the kinds of items inline queries look for, with the
+// letter naming them in callback data.
+var inlineTypes = map[models.SearchResultType]string{
+	models.SearchResultTodo:       "t",
+	models.SearchResultBuyingItem: "b",
+	models.SearchResultWishItem:   "w",
+}
+
+// inlineCard is an item shared through inline mode.
+type inlineCard struct {
+	// kind is the item's letter in inlineTypes.
+	kind string
+	id   int64
+	// title and description are shown in the list of results, text is the
+	// template sent to the chat.
+	title, description, text string
+}
+
+// ---------------------------------------------------------------------------
+// InlineHandler – @TodoboT <query>
+// ---------------------------------------------------------------------------
+
+// InlineHandler answers inline queries, which users type as "@TodoboT milk"
+// in any chat. It searches the todos, shopping items and wish lists of all
+// families the user belongs to, whatever the chat, and shares the chosen
+// item as a card. An empty query lists what is still on the shopping lists.
+//
+// Cards carry a button that adds the item to the shopping list of whoever
+// presses it: to the item's family if they belong to it, otherwise to the
+// family they work on in their private chat.
+type InlineHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewInlineHandler creates a new InlineHandler.
+func NewInlineHandler(svc *service.Service, logger *logrus.Logger) *InlineHandler {
+	return &InlineHandler{svc: svc, logger: logger}
+}
+
+// HandleInlineQuery answers the inline query with matching items.
+func (h *InlineHandler) HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) error {
+	ctx := context.Background()
+	lang := h.svc.Language(ctx, query.From.ID, query.From.ID, query.From.LanguageCode)
+
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		IsPersonal:    true,
+		Results:       []interface{}{},
+	}
+
+	user, err := h.svc.Users.GetByTelegramID(ctx, query.From.ID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	var cards []inlineCard
+	if user != nil {
+		cards, err = h.cards(ctx, lang, user.ID, query.Query)
+		if err != nil {
+			return err
+		}
+	}
+	if user == nil || cards == nil {
+		answer.SwitchPMText = i18n.T(lang, "inline.open_bot")
+		answer.SwitchPMParameter = "inline"
+	}
+
+	for _, card := range cards {
+		result := markup.NewInlineQueryResultArticle(fmt.Sprintf("%s%d", card.kind, card.id), card.title, card.text)
+		result.Description = card.description
+		keyboard := inlineCardKeyboard(lang, card.kind, card.id, "")
+		result.ReplyMarkup = &keyboard
+		answer.Results = append(answer.Results, result)
+	}
+
+	if _, err := bot.Request(answer); err != nil {
+		return fmt.Errorf("answer inline query: %w", err)
+	}
+	return nil
+}
+
+// cards returns the cards of the user's families' items matching text, or
+// of the items still on their shopping lists when text is empty.
+func (h *InlineHandler) cards(ctx context.Context, lang string, userID int64, text string) ([]inlineCard, error) {
+	var cards []inlineCard
+
+	if strings.TrimSpace(text) == "" {
+		families, err := h.svc.Families.GetByUser(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("get families: %w", err)
+		}
+		for _, family := range families {
+			list, err := h.svc.Buying.GetListByChatID(ctx, family.ChatID)
+			if err != nil {
+				return nil, fmt.Errorf("get buying list: %w", err)
+			}
+			if list == nil {
+				continue
+			}
+			items, err := h.svc.Buying.GetItems(ctx, list.ID, true)
+			if err != nil {
+				return nil, fmt.Errorf("get buying items: %w", err)
+			}
+			for _, item := range items {
+				cards = append(cards, buyingCard(lang, item, family))
+				if len(cards) == inlineResultLimit {
+					return cards, nil
+				}
+			}
+		}
+		return cards, nil
+	}
+
+	results, err := h.svc.SearchUserFamilies(ctx, userID, text, inlineResultLimit,
+		models.SearchResultTodo, models.SearchResultBuyingItem, models.SearchResultWishItem)
+	if errors.Is(err, service.ErrEmptySearch) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	for _, result := range results {
+		card, err := h.card(ctx, lang, result)
+		if err != nil {
+			return nil, err
+		}
+		if card != nil {
+			cards = append(cards, *card)
+		}
+	}
+	return cards, nil
+}
+
+// card loads the item a search result points to and describes it, or
+// returns nil if it is gone.
+func (h *InlineHandler) card(ctx context.Context, lang string, result service.FamilySearchResult) (*inlineCard, error) {
+	switch result.Type {
+	case models.SearchResultTodo:
+		todo, err := h.svc.Todos.GetByID(ctx, result.ID)
+		if err != nil || todo == nil {
+			return nil, err
+		}
+		status := todoStatusEmoji(todo.Status) + " " + todoStatusName(lang, todo.Status)
+		if todo.Deadline != nil {
+			status += " · 📅 " + i18n.Date(lang, *todo.Deadline, "Mon, 02 Jan")
+		}
+		return &inlineCard{
+			kind:        inlineTypes[result.Type],
+			id:          todo.ID,
+			title:       "📋 " + todo.Title,
+			description: status + " · " + result.Family.Name,
+			text:        i18n.T(lang, "inline.todo_card", markup.Escape(todo.Title), status, markup.Escape(result.Family.Name)),
+		}, nil
+
+	case models.SearchResultBuyingItem:
+		item, err := h.svc.Buying.GetItemByID(ctx, result.ID)
+		if err != nil || item == nil {
+			return nil, err
+		}
+		card := buyingCard(lang, item, result.Family)
+		return &card, nil
+
+	case models.SearchResultWishItem:
+		item, err := h.svc.WishList.GetItemByID(ctx, result.ID)
+		if err != nil || item == nil {
+			return nil, err
+		}
+		list, err := h.svc.WishList.GetListByID(ctx, item.WishListID)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		owner, err := h.svc.Users.GetByID(ctx, list.UserID)
+		if err != nil || owner == nil {
+			return nil, err
+		}
+
+		detail := i18n.T(lang, "inline.wish_of", owner.FirstName)
+		if item.Price != "" {
+			detail += " · " + item.Price
+		}
+		text := i18n.T(lang, "inline.wish_card", markup.Escape(item.Name), markup.Escape(detail), markup.Escape(result.Family.Name))
+		if item.URL != "" {
+			text += "\n🔗 " + markup.Escape(item.URL)
+		}
+		return &inlineCard{
+			kind:        inlineTypes[result.Type],
+			id:          item.ID,
+			title:       "🎁 " + item.Name,
+			description: detail + " · " + result.Family.Name,
+			text:        text,
+		}, nil
+	}
+	return nil, nil
+}
+
+// buyingCard describes a shopping item of the family.
+func buyingCard(lang string, item *models.BuyingItem, family *models.Family) inlineCard {
+	name := item.Name
+	if item.Quantity != "" && item.Quantity != "1" {
+		name += " ×" + item.Quantity
+	}
+	status := i18n.T(lang, "inline.buy_needed")
+	if item.Bought {
+		status = i18n.T(lang, "inline.buy_bought")
+	}
+	return inlineCard{
+		kind:        inlineTypes[models.SearchResultBuyingItem],
+		id:          item.ID,
+		title:       "🛒 " + name,
+		description: status + " · " + family.Name,
+		text:        i18n.T(lang, "inline.buy_card", markup.Escape(name), status, markup.Escape(family.Name)),
+	}
+}
+
+// inlineCardKeyboard is the button under a shared card, with a note of who
+// last added the item to their shopping list.
+func inlineCardKeyboard(lang, kind string, id int64, addedBy string) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "inline.add_button"), fmt.Sprintf("inline:%s:%d", kind, id)),
+	)}
+	if addedBy != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "inline.added", addedBy), "inline:noop"),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// HandleCallback processes a press on the button under a shared card. The
+// data has the form "<kind>:<id>" with kind a letter from inlineTypes.
+func (h *InlineHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	kind, idPart, _ := strings.Cut(data, ":")
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	lang := h.svc.Language(ctx, query.From.ID, query.From.ID, query.From.LanguageCode)
+
+	name, quantity, itemFamilyID, err := h.item(ctx, kind, id)
+	if err != nil || name == "" {
+		return err
+	}
+
+	user, err := h.svc.EnsureUser(ctx, query.From.ID, query.From.UserName, query.From.FirstName, query.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+	family, err := h.targetFamily(ctx, user.ID, itemFamilyID)
+	if err != nil {
+		return err
+	}
+	if family == nil {
+		h.logger.WithField("user_id", query.From.ID).Info("Inline card pressed by a user without a family")
+		return nil
+	}
+
+	list, err := ensureBuyingList(ctx, h.svc, lang, family.ID, family.ChatID, user.ID)
+	if err != nil {
+		return err
+	}
+	item, err := h.svc.Buying.AddItem(ctx, &models.BuyingItem{
+		BuyingListID: list.ID,
+		Name:         name,
+		Quantity:     quantity,
+		AddedByID:    user.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("add buying item: %w", err)
+	}
+
+	// Show who added it; cards in chats the bot is not in can only be
+	// edited through the inline message ID.
+	keyboard := inlineCardKeyboard(lang, kind, id, query.From.FirstName)
+	edit := tgbotapi.EditMessageReplyMarkupConfig{BaseEdit: tgbotapi.BaseEdit{ReplyMarkup: &keyboard}}
+	if query.InlineMessageID != "" {
+		edit.InlineMessageID = query.InlineMessageID
+	} else if query.Message != nil {
+		edit.ChatID = query.Message.Chat.ID
+		edit.MessageID = query.Message.MessageID
+	}
+	telegram.Send(bot, h.logger, edit)
+
+	h.logger.WithFields(logrus.Fields{
+		"user_id":   query.From.ID,
+		"family_id": family.ID,
+		"item_id":   item.ID,
+	}).Info("Shopping item added from inline card")
+
+	return nil
+}
+
+// item returns the name and quantity to put on the shopping list for the
+// shared item, and the family it belongs to. The name is empty if the item
+// is gone.
+func (h *InlineHandler) item(ctx context.Context, kind string, id int64) (string, string, int64, error) {
+	switch kind {
+	case inlineTypes[models.SearchResultTodo]:
+		todo, err := h.svc.Todos.GetByID(ctx, id)
+		if err != nil || todo == nil {
+			return "", "", 0, err
+		}
+		family, err := h.svc.Families.GetByChatID(ctx, todo.ChatID)
+		if err != nil || family == nil {
+			return "", "", 0, err
+		}
+		return todo.Title, "1", family.ID, nil
+
+	case inlineTypes[models.SearchResultBuyingItem]:
+		item, err := h.svc.Buying.GetItemByID(ctx, id)
+		if err != nil || item == nil {
+			return "", "", 0, err
+		}
+		list, err := h.svc.Buying.GetListByID(ctx, item.BuyingListID)
+		if err != nil || list == nil {
+			return "", "", 0, err
+		}
+		return item.Name, item.Quantity, list.FamilyID, nil
+
+	case inlineTypes[models.SearchResultWishItem]:
+		item, err := h.svc.WishList.GetItemByID(ctx, id)
+		if err != nil || item == nil {
+			return "", "", 0, err
+		}
+		list, err := h.svc.WishList.GetListByID(ctx, item.WishListID)
+		if err != nil || list == nil {
+			return "", "", 0, err
+		}
+		return item.Name, "1", list.FamilyID, nil
+	}
+	return "", "", 0, nil
+}
+
+// targetFamily picks whose shopping list an item shared from the family
+// itemFamilyID goes on when the user presses the card's button: that family
+// if the user belongs to it, else the family they picked with /family, else
+// the first they belong to. It returns nil if they are in no family.
+func (h *InlineHandler) targetFamily(ctx context.Context, userID, itemFamilyID int64) (*models.Family, error) {
+	families, err := h.svc.Families.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get families: %w", err)
+	}
+	if len(families) == 0 {
+		return nil, nil
+	}
+	for _, family := range families {
+		if family.ID == itemFamilyID {
+			return family, nil
+		}
+	}
+
+	selected, err := h.svc.SelectedFamily(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get selected family: %w", err)
+	}
+	if selected != nil {
+		return selected, nil
+	}
+	return families[0], nil
+}
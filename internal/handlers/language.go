@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// messageLang returns the language to answer the message in.
+func messageLang(ctx context.Context, svc *service.Service, message *tgbotapi.Message) string {
+	if message.From == nil {
+		return svc.Language(ctx, message.Chat.ID, 0, "")
+	}
+	return svc.Language(ctx, message.Chat.ID, message.From.ID, message.From.LanguageCode)
+}
+
+// ---------------------------------------------------------------------------
+// LanguageHandler – /lang [code|auto]
+// ---------------------------------------------------------------------------
+
+// LanguageHandler handles the /lang command. In a private chat it sets the
+// language the bot talks to the user in; in a group it sets the language of
+// the family's group chats, which only family admins may do. "auto" goes
+// back to the language of each user's Telegram app. Without an argument the
+// languages are offered as buttons.
+//
+// It also tells the router which language to report errors in.
+type LanguageHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewLanguageHandler creates a new LanguageHandler.
+func NewLanguageHandler(svc *service.Service, logger *logrus.Logger) *LanguageHandler {
+	return &LanguageHandler{svc: svc, logger: logger}
+}
+
+// Language returns the language to talk to the user in the chat.
+func (h *LanguageHandler) Language(chat *tgbotapi.Chat, user *tgbotapi.User) string {
+	if user == nil {
+		return h.svc.Language(context.Background(), chat.ID, 0, "")
+	}
+	return h.svc.Language(context.Background(), chat.ID, user.ID, user.LanguageCode)
+}
+
+// Handle processes the /lang command.
+func (h *LanguageHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+
+	if len(args) == 0 {
+		lang := messageLang(ctx, h.svc, message)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "lang.pick", i18n.Name(lang)))
+		msg.ReplyMarkup = languageKeyboard(lang)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	text, err := h.set(ctx, bot, message, strings.ToLower(args[0]))
+	if err != nil {
+		return err
+	}
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+	return nil
+}
+
+// HandleCallback processes a press on a language button.
+func (h *LanguageHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+
+	message := pageCallbackMessage(query)
+	text, err := h.set(context.Background(), bot, message, data)
+	if err != nil {
+		return err
+	}
+	edit := markup.NewEditMessageText(message.Chat.ID, message.MessageID, text)
+	telegram.Send(bot, h.logger, edit)
+	return nil
+}
+
+// set changes the language for the message's chat and returns the reply,
+// in the new language when it worked.
+func (h *LanguageHandler) set(ctx context.Context, bot *tgbotapi.BotAPI, message *tgbotapi.Message, code string) (string, error) {
+	lang := messageLang(ctx, h.svc, message)
+
+	choice := i18n.Match(code)
+	if code == "auto" {
+		choice = ""
+	} else if choice == "" {
+		return i18n.T(lang, "lang.unknown", markup.Escape(code), strings.Join(i18n.Languages(), ", ")), nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return "", fmt.Errorf("ensure user: %w", err)
+	}
+
+	if message.Chat.IsPrivate() {
+		if err := h.svc.SetUserLanguage(ctx, user.ID, choice); err != nil {
+			return "", fmt.Errorf("set user language: %w", err)
+		}
+	} else {
+		family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, message.Chat.Title)
+		if err != nil {
+			return "", fmt.Errorf("ensure family: %w", err)
+		}
+		err = h.svc.SetFamilyLanguage(ctx, family.ID, user.ID, choice)
+		if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+			err = h.svc.SetFamilyLanguage(ctx, family.ID, user.ID, choice)
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			return i18n.T(lang, "lang.forbidden"), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("set family language: %w", err)
+		}
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":  message.Chat.ID,
+		"user_id":  message.From.ID,
+		"language": choice,
+	}).Info("Language changed")
+
+	lang = messageLang(ctx, h.svc, message)
+	if choice == "" {
+		return i18n.T(lang, "lang.auto", i18n.Name(lang)), nil
+	}
+	return i18n.T(lang, "lang.set", i18n.Name(lang)), nil
+}
+
+// languageKeyboard offers the supported languages, marking the current one.
+func languageKeyboard(current string) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, code := range i18n.Languages() {
+		label := i18n.Name(code)
+		if code == current {
+			label = "✅ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, "lang:"+code))
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.T(current, "lang.auto_button"), "lang:auto"))
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+)
+
+// telegramMedia is a photo, voice note or document to attach to a todo or
+// shopping item.
+type telegramMedia struct {
+	telegramFile
+	// Kind is "photo", "voice" or "document".
+	Kind string
+	// Caption is the text sent along with the media, unless it was the
+	// command itself.
+	Caption string
+}
+
+// mediaFile returns the photo, voice note or document carried by the
+// message or, if there is none, by the message it replies to.
+func mediaFile(message *tgbotapi.Message) *telegramMedia {
+	for m := message; m != nil; m = m.ReplyToMessage {
+		var media *telegramMedia
+		switch {
+		case len(m.Photo) > 0:
+			// Telegram sends several sizes, the last one is the largest
+			photo := m.Photo[len(m.Photo)-1]
+			media = &telegramMedia{
+				telegramFile: telegramFile{FileID: photo.FileID, FileName: "photo.jpg", ContentType: "image/jpeg"},
+				Kind:         "photo",
+			}
+		case m.Voice != nil:
+			contentType := m.Voice.MimeType
+			if contentType == "" {
+				contentType = "audio/ogg"
+			}
+			media = &telegramMedia{
+				telegramFile: telegramFile{FileID: m.Voice.FileID, FileName: "voice.ogg", ContentType: contentType},
+				Kind:         "voice",
+			}
+		case m.Document != nil:
+			contentType := m.Document.MimeType
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			media = &telegramMedia{
+				telegramFile: telegramFile{FileID: m.Document.FileID, FileName: m.Document.FileName, ContentType: contentType},
+				Kind:         "document",
+			}
+		}
+		if media != nil {
+			if !models.SafeContentType(media.ContentType) {
+				media.ContentType = "application/octet-stream"
+			}
+			if m != message && !strings.HasPrefix(m.Caption, "/") {
+				media.Caption = strings.TrimSpace(m.Caption)
+			}
+			return media
+		}
+		if m != message {
+			break
+		}
+	}
+	return nil
+}
+
+// mediaTitle names a todo or shopping item made from media alone: after its
+// caption, the document's file name or the kind of media.
+func mediaTitle(lang string, media *telegramMedia) string {
+	if media.Caption != "" {
+		return media.Caption
+	}
+	if media.Kind == "document" && media.FileName != "" {
+		return media.FileName
+	}
+	return i18n.T(lang, "media."+media.Kind)
+}
+
+// attachMedia links the media to the record and reports whether that
+// worked. Failures are logged; the record stays without it.
+func attachMedia(ctx context.Context, svc *service.Service, logger *logrus.Logger, media *telegramMedia,
+	familyID, chatID int64, entityType models.AttachmentEntity, entityID, userID int64) bool {
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+
+	uploadedBy := userID
+	attachment, err := svc.AttachTelegramFile(ctx, &models.Attachment{
+		FamilyID:       familyID,
+		ChatID:         chatID,
+		EntityType:     entityType,
+		EntityID:       entityID,
+		FileName:       media.FileName,
+		ContentType:    media.ContentType,
+		TelegramFileID: media.FileID,
+		UploadedByID:   &uploadedBy,
+	})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id":     chatID,
+			"entity_type": entityType,
+			"entity_id":   entityID,
+			"error":       err,
+		}).Error("Failed to attach media")
+		return false
+	}
+
+	logger.WithFields(logrus.Fields{
+		"chat_id":       chatID,
+		"attachment_id": attachment.ID,
+		"entity_type":   entityType,
+		"entity_id":     entityID,
+	}).Info("Attached media")
+	return true
+}
+
+// attachmentIndicator marks records in lists that have files attached.
+func attachmentIndicator(attachments []models.Attachment) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	return " 📎"
+}
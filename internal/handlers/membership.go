@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// ---------------------------------------------------------------------------
+// MembershipHandler – users joining and leaving the group
+// ---------------------------------------------------------------------------
+
+// MembershipHandler keeps family membership in sync with the Telegram group.
+// People joining the group join the family; people leaving it, and no other
+// group of the family, leave the family, their pending todos become
+// unassigned and their wish reservations are given up. When the bot itself
+// is added, the group admins become family admins.
+type MembershipHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewMembershipHandler creates a new MembershipHandler.
+func NewMembershipHandler(svc *service.Service, logger *logrus.Logger) *MembershipHandler {
+	return &MembershipHandler{svc: svc, logger: logger}
+}
+
+// HandleMember processes a join or leave in a chat.
+func (h *MembershipHandler) HandleMember(bot *tgbotapi.BotAPI, chat *tgbotapi.Chat, user *tgbotapi.User, joined bool) error {
+	if !chat.IsGroup() && !chat.IsSuperGroup() {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	if user.ID == bot.Self.ID {
+		if joined {
+			return h.botAdded(ctx, bot, chat)
+		}
+		// Keep the family's data in case the bot is added back
+		h.logger.WithField("chat_id", chat.ID).Info("Bot removed from chat")
+		return nil
+	}
+	if user.IsBot {
+		return nil
+	}
+
+	if joined {
+		return h.memberJoined(ctx, bot, chat, user)
+	}
+	return h.memberLeft(ctx, bot, chat, user)
+}
+
+// botAdded sets up the family for a group the bot was just added to and
+// makes the group's admins family admins.
+func (h *MembershipHandler) botAdded(ctx context.Context, bot *tgbotapi.BotAPI, chat *tgbotapi.Chat) error {
+	family, err := h.svc.EnsureFamily(ctx, chat.ID, chat.Title)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+
+	admins, err := bot.GetChatAdministrators(tgbotapi.ChatAdministratorsConfig{
+		ChatConfig: tgbotapi.ChatConfig{ChatID: chat.ID},
+	})
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get chat administrators")
+	}
+	for _, admin := range admins {
+		if admin.User == nil || admin.User.IsBot {
+			continue
+		}
+		user, err := h.svc.EnsureUser(ctx, admin.User.ID, admin.User.UserName, admin.User.FirstName, admin.User.LastName)
+		if err != nil {
+			return fmt.Errorf("ensure user: %w", err)
+		}
+		if _, err := h.svc.GrantChatAdmin(ctx, family.ID, user.ID); err != nil {
+			return fmt.Errorf("grant chat admin: %w", err)
+		}
+	}
+
+	lang := h.svc.ChatLanguage(ctx, chat.ID, 0)
+	msg := markup.NewMessage(chat.ID, i18n.T(lang, "member.bot_added", markup.Escape(family.Name)))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   chat.ID,
+		"family_id": family.ID,
+		"admins":    len(admins),
+	}).Info("Bot added to chat")
+
+	return nil
+}
+
+func (h *MembershipHandler) memberJoined(ctx context.Context, bot *tgbotapi.BotAPI, chat *tgbotapi.Chat, from *tgbotapi.User) error {
+	user, err := h.svc.EnsureUser(ctx, from.ID, from.UserName, from.FirstName, from.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	family, err := h.svc.EnsureFamily(ctx, chat.ID, chat.Title)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+
+	added, err := h.svc.MemberJoined(ctx, family.ID, user.ID)
+	if err != nil {
+		return fmt.Errorf("add member: %w", err)
+	}
+	if !added {
+		return nil
+	}
+
+	lang := h.svc.Language(ctx, chat.ID, from.ID, from.LanguageCode)
+	msg := markup.NewMessage(chat.ID, i18n.T(lang, "member.joined", markup.Escape(family.Name), markup.Escape(user.FirstName)))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   chat.ID,
+		"family_id": family.ID,
+		"user_id":   user.ID,
+	}).Info("Member joined family")
+
+	return nil
+}
+
+func (h *MembershipHandler) memberLeft(ctx context.Context, bot *tgbotapi.BotAPI, chat *tgbotapi.Chat, from *tgbotapi.User) error {
+	family, err := h.svc.Families.GetByChatID(ctx, chat.ID)
+	if err != nil {
+		return fmt.Errorf("get family: %w", err)
+	}
+	user, err := h.svc.Users.GetByTelegramID(ctx, from.ID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+	if family == nil || user == nil {
+		return nil
+	}
+	if h.inOtherGroup(ctx, bot, family.ID, chat.ID, from.ID) {
+		h.logger.WithFields(logrus.Fields{
+			"chat_id":   chat.ID,
+			"family_id": family.ID,
+			"user_id":   user.ID,
+		}).Info("Member left one of the family's groups")
+		return nil
+	}
+
+	summary, err := h.svc.MemberLeft(ctx, family, user.ID)
+	if err != nil {
+		return fmt.Errorf("remove member: %w", err)
+	}
+	if summary == nil {
+		return nil
+	}
+
+	lang := h.svc.ChatLanguage(ctx, chat.ID, 0)
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "member.left", markup.Escape(user.FirstName)))
+	if summary.UnassignedTodos > 0 {
+		sb.WriteString("\n• " + i18n.N(lang, "member.left_todos", int(summary.UnassignedTodos)))
+	}
+	if summary.ReleasedWishes > 0 {
+		sb.WriteString("\n• " + i18n.N(lang, "member.left_wishes", int(summary.ReleasedWishes)))
+	}
+	if summary.StoppedReminders > 0 {
+		sb.WriteString("\n• " + i18n.N(lang, "member.left_reminders", summary.StoppedReminders))
+	}
+	if summary.NewAdmin != nil {
+		sb.WriteString("\n• " + i18n.T(lang, "member.new_admin", markup.Escape(summary.NewAdmin.DisplayName())))
+	}
+
+	msg := markup.NewMessage(chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   chat.ID,
+		"family_id": family.ID,
+		"user_id":   user.ID,
+	}).Info("Member left family")
+
+	return nil
+}
+
+// inOtherGroup reports whether the Telegram user is still in another group
+// chat of the family than the one they left. Chats the bot cannot ask
+// about count as left.
+func (h *MembershipHandler) inOtherGroup(ctx context.Context, bot *tgbotapi.BotAPI, familyID, leftChatID, telegramID int64) bool {
+	chatIDs, err := h.svc.Families.GetChats(ctx, familyID)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to get family chats")
+		return false
+	}
+	for _, chatID := range chatIDs {
+		// Group chats have negative IDs, private chats those of their user
+		if chatID == leftChatID || chatID > 0 {
+			continue
+		}
+		member, err := bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+			ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: telegramID},
+		})
+		if err == nil && telegram.IsChatMember(member) {
+			return true
+		}
+	}
+	return false
+}
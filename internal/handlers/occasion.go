@@ -0,0 +1,450 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// defaultOccasionRemindDays is how many days ahead occasions are announced
+// unless days:N is given.
+const defaultOccasionRemindDays = 7
+
+// parseOccasionDate accepts YYYY-MM-DD, DD.MM.YYYY, MM-DD and DD.MM. The
+// year is optional and only kept when given.
+func parseOccasionDate(s string) (month, day int, year *int, ok bool) {
+	var t time.Time
+	var err error
+	withYear := true
+	switch {
+	case strings.Count(s, "-") == 2:
+		t, err = time.Parse("2006-01-02", s)
+	case strings.Count(s, ".") == 2:
+		t, err = time.Parse("2.1.2006", s)
+	case strings.Count(s, "-") == 1:
+		// Parse in a leap year so February 29 is accepted
+		t, err = time.Parse("2006-01-02", "2000-"+s)
+		withYear = false
+	case strings.Count(s, ".") == 1:
+		t, err = time.Parse("2.1.2006", s+".2000")
+		withYear = false
+	default:
+		return 0, 0, nil, false
+	}
+	if err != nil {
+		return 0, 0, nil, false
+	}
+	if withYear {
+		y := t.Year()
+		year = &y
+	}
+	return int(t.Month()), t.Day(), year, true
+}
+
+// splitRemindDays removes a days:N argument and returns the remaining
+// arguments with N, or the default when absent.
+func splitRemindDays(args []string) ([]string, int, error) {
+	days := defaultOccasionRemindDays
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if v, found := strings.CutPrefix(strings.ToLower(arg), "days:"); found {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 || n > 60 {
+				return nil, 0, fmt.Errorf("invalid days: %s", v)
+			}
+			days = n
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, days, nil
+}
+
+// ---------------------------------------------------------------------------
+// BirthdayHandler – /birthday <date> [@user] [days:N]
+// ---------------------------------------------------------------------------
+
+// BirthdayHandler handles the /birthday command. It records the birthday of
+// the sender or of the mentioned member; everyone else is reminded of it
+// ahead of time together with the unreserved items on the celebrant's wish
+// list.
+type BirthdayHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewBirthdayHandler creates a new BirthdayHandler.
+func NewBirthdayHandler(svc *service.Service, logger *logrus.Logger) *BirthdayHandler {
+	return &BirthdayHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /birthday command.
+func (h *BirthdayHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+	args, days, daysErr := splitRemindDays(args)
+
+	var month, day int
+	var year *int
+	ok := daysErr == nil && len(args) > 0 && len(args) <= 2
+	if ok {
+		month, day, year, ok = parseOccasionDate(args[0])
+	}
+	if ok && len(args) == 2 && !strings.HasPrefix(args[1], "@") {
+		ok = false
+	}
+	if !ok {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "birthday.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	celebrant := user
+	if len(args) == 2 {
+		username := strings.TrimPrefix(args[1], "@")
+		celebrant, err = h.svc.Users.GetByUsername(ctx, username)
+		if err != nil || celebrant == nil {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "user.not_found", markup.Escape(username)))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+	}
+
+	occasion, err := h.svc.SaveBirthday(ctx, &models.Occasion{
+		FamilyID:         family.ID,
+		ChatID:           chatID,
+		Title:            i18n.T(lang, "occasion.birthday_of", celebrant.FirstName),
+		CelebrantID:      &celebrant.ID,
+		Month:            month,
+		Day:              day,
+		Year:             year,
+		RemindDaysBefore: days,
+		CreatedByID:      user.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("save birthday: %w", err)
+	}
+	occasion.Celebrant = celebrant
+
+	next := occasion.NextDate(time.Now())
+	text := i18n.T(lang, "birthday.saved", occasion.ID, markup.Escape(service.OccasionTitle(lang, occasion, next)),
+		i18n.Date(lang, next, "Mon, 02 Jan 2006"), i18n.N(lang, "birthday.remind_days", days))
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":     message.Chat.ID,
+		"user_id":     message.From.ID,
+		"occasion_id": occasion.ID,
+	}).Info("Birthday saved")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// OccasionAddHandler – /occasion <date> <title> [days:N]
+// ---------------------------------------------------------------------------
+
+// OccasionAddHandler handles the /occasion command to add a yearly family
+// occasion such as a holiday. Members link their wish lists to it with
+// /wishfor.
+type OccasionAddHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewOccasionAddHandler creates a new OccasionAddHandler.
+func NewOccasionAddHandler(svc *service.Service, logger *logrus.Logger) *OccasionAddHandler {
+	return &OccasionAddHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /occasion command.
+func (h *OccasionAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+	args, days, daysErr := splitRemindDays(args)
+
+	// Holidays recur every year, so a given year is ignored
+	var month, day int
+	ok := daysErr == nil && len(args) >= 2
+	if ok {
+		month, day, _, ok = parseOccasionDate(args[0])
+	}
+	if !ok {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "occasion.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	title := strings.Join(args[1:], " ")
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	occasion, err := h.svc.Occasions.Create(ctx, &models.Occasion{
+		FamilyID:         family.ID,
+		ChatID:           chatID,
+		Kind:             models.OccasionHoliday,
+		Title:            title,
+		Month:            month,
+		Day:              day,
+		RemindDaysBefore: days,
+		CreatedByID:      user.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("create occasion: %w", err)
+	}
+
+	next := occasion.NextDate(time.Now())
+	text := i18n.T(lang, "occasion.added", occasion.ID, markup.Escape(title),
+		i18n.Date(lang, next, "Mon, 02 Jan 2006"), occasion.ID)
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":     message.Chat.ID,
+		"user_id":     message.From.ID,
+		"occasion_id": occasion.ID,
+	}).Info("Occasion created")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// OccasionListHandler – /occasions
+// ---------------------------------------------------------------------------
+
+// OccasionListHandler handles the /occasions command to list the family's
+// birthdays and holidays, soonest first.
+type OccasionListHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewOccasionListHandler creates a new OccasionListHandler.
+func NewOccasionListHandler(svc *service.Service, logger *logrus.Logger) *OccasionListHandler {
+	return &OccasionListHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /occasions command.
+func (h *OccasionListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
+
+	family, err := h.svc.Families.GetByChatID(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("get family: %w", err)
+	}
+
+	now := time.Now()
+	var events []*models.CalendarEvent
+	if family != nil {
+		events, err = h.svc.OccasionEvents(ctx, lang, family.ID, now, now.AddDate(1, 0, -1))
+		if err != nil {
+			return fmt.Errorf("list occasions: %w", err)
+		}
+	}
+
+	if len(events) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "occasions.empty"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "occasions.heading") + "\n\n")
+	for _, event := range events {
+		sb.WriteString(fmt.Sprintf("*#%d* %s\n   📆 %s\n", *event.OccasionID, markup.Escape(event.Title), i18n.Date(lang, event.StartTime, "Mon, 02 Jan 2006")))
+	}
+	sb.WriteString("\n" + i18n.T(lang, "occasions.footer"))
+
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"count":   len(events),
+	}).Info("Listed occasions")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// OccasionDeleteHandler – /deloccasion <id>
+// ---------------------------------------------------------------------------
+
+// OccasionDeleteHandler handles the /deloccasion command. Birthdays can be
+// removed by the celebrant or whoever added them, holidays by their creator,
+// and anything by family admins.
+type OccasionDeleteHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewOccasionDeleteHandler creates a new OccasionDeleteHandler.
+func NewOccasionDeleteHandler(svc *service.Service, logger *logrus.Logger) *OccasionDeleteHandler {
+	return &OccasionDeleteHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /deloccasion command.
+func (h *OccasionDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "deloccasion.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	occasionID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "occasion.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	var familyID int64
+	if family, _ := h.svc.Families.GetByChatID(ctx, chatID); family != nil {
+		familyID = family.ID
+	}
+
+	err = h.svc.DeleteOccasion(ctx, occasionID, familyID, user.ID)
+	if errors.Is(err, service.ErrOccasionForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		err = h.svc.DeleteOccasion(ctx, occasionID, familyID, user.ID)
+	}
+	var text string
+	switch {
+	case errors.Is(err, service.ErrOccasionNotFound):
+		text = i18n.T(lang, "occasion.not_in_chat", occasionID)
+	case errors.Is(err, service.ErrOccasionForbidden):
+		text = i18n.T(lang, "occasion.delete_forbidden")
+	case err != nil:
+		return fmt.Errorf("delete occasion: %w", err)
+	default:
+		text = i18n.T(lang, "occasion.deleted", occasionID)
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":     message.Chat.ID,
+		"user_id":     message.From.ID,
+		"occasion_id": occasionID,
+	}).Info("Processed occasion delete")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// WishForHandler – /wishfor <occasion id|off>
+// ---------------------------------------------------------------------------
+
+// WishForHandler handles the /wishfor command to link the sender's wish list
+// to an occasion. Members are then reminded of the list's free items before
+// that occasion.
+type WishForHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewWishForHandler creates a new WishForHandler.
+func NewWishForHandler(svc *service.Service, logger *logrus.Logger) *WishForHandler {
+	return &WishForHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /wishfor command.
+func (h *WishForHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	var occasionID *int64
+	ok := len(args) == 1
+	if ok && args[0] != "off" {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		ok = err == nil
+		occasionID = &id
+	}
+	if !ok {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wishfor.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	occasion, err := h.svc.LinkWishList(ctx, family.ID, user.ID, occasionID)
+	var text string
+	switch {
+	case errors.Is(err, service.ErrOccasionNotFound):
+		text = i18n.T(lang, "occasion.not_in_chat", *occasionID)
+	case err != nil:
+		return fmt.Errorf("link wish list: %w", err)
+	case occasion == nil:
+		text = i18n.T(lang, "wishfor.unlinked")
+	default:
+		next := occasion.NextDate(time.Now())
+		text = i18n.T(lang, "wishfor.linked", markup.Escape(occasion.Title), i18n.Date(lang, next, "02 Jan"))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+	}).Info("Processed wish list occasion link")
+
+	return nil
+}
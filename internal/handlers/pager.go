@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+)
+
+const (
+	// maxPageLength is how long a page may get, in the UTF-16 code units
+	// Telegram counts. Messages are limited to 4096; the rest is left for
+	// the page indicator.
+	maxPageLength = 4000
+	// maxPageEntries is how many entries a page shows at most, so that
+	// long lists stay readable in a chat.
+	maxPageEntries = 15
+	// maxCallbackData is how many bytes of callback data Telegram allows.
+	maxCallbackData = 64
+)
+
+// pagedOutput is the output of a list command: a header, one entry per item
+// and a footer. The header and footer are repeated on every page.
+type pagedOutput struct {
+	header  string
+	entries []string
+	footer  string
+	// lang is the language of the page indicator and buttons
+	lang string
+}
+
+// textLength measures s the way Telegram limits message length.
+func textLength(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// pages splits the output into the texts of its pages. Entries too long
+// for a page of their own are split into their lines, and lines that are
+// still too long are cut.
+func (o pagedOutput) pages() []string {
+	room := maxPageLength - textLength(o.header) - textLength(o.footer)
+
+	var entries []string
+	for _, e := range o.entries {
+		if textLength(e) <= room {
+			entries = append(entries, e)
+			continue
+		}
+		for _, line := range strings.Split(e, "\n") {
+			for textLength(line) > room {
+				_, size := utf8.DecodeLastRuneInString(line)
+				line = line[:len(line)-size]
+			}
+			entries = append(entries, line)
+		}
+	}
+
+	var pages []string
+	var page strings.Builder
+	count := 0
+	flush := func() {
+		pages = append(pages, o.header+page.String()+o.footer)
+		page.Reset()
+		count = 0
+	}
+	for _, e := range entries {
+		if count > 0 && (count == maxPageEntries || textLength(page.String())+textLength(e)+1 > room) {
+			flush()
+		}
+		page.WriteString(e)
+		page.WriteString("\n")
+		count++
+	}
+	if count > 0 || len(pages) == 0 {
+		flush()
+	}
+	return pages
+}
+
+// pageMarkup renders page of pages, with buttons to the neighbouring pages
+// whose callback data is "<prefix>:<page>:<args>".
+func pageMarkup(lang string, pages []string, page int, prefix string, args []string) (string, *tgbotapi.InlineKeyboardMarkup) {
+	page = max(0, min(page, len(pages)-1))
+	if len(pages) == 1 {
+		return pages[0], nil
+	}
+
+	text := pages[page] + "\n\n_" + i18n.T(lang, "page.of", page+1, len(pages)) + "_"
+
+	var buttons []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "page.prev"), pageCallbackData(prefix, page-1, args)))
+	}
+	if page < len(pages)-1 {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "page.next"), pageCallbackData(prefix, page+1, args)))
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons)
+	return text, &keyboard
+}
+
+// pageCallbackData encodes a page button. Arguments that do not fit into
+// the callback data are left out.
+func pageCallbackData(prefix string, page int, args []string) string {
+	data := fmt.Sprintf("%s:%d:", prefix, page)
+	for i, arg := range args {
+		if i > 0 {
+			arg = " " + arg
+		}
+		if len(data)+len(arg) > maxCallbackData {
+			break
+		}
+		data += arg
+	}
+	return data
+}
+
+// parsePageCallback decodes the "<page>:<args>" callback data of a page
+// button.
+func parsePageCallback(data string) (int, []string, error) {
+	rawPage, args, _ := strings.Cut(data, ":")
+	page, err := strconv.Atoi(rawPage)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid page callback %q", data)
+	}
+	return page, strings.Fields(args), nil
+}
+
+// sendPaged sends the first page of the output to the chat. prefix is the
+// callback prefix the command's handler is registered under, and args are
+// passed back to it to render other pages.
+func sendPaged(bot *tgbotapi.BotAPI, chatID int64, out pagedOutput, prefix string, args []string) error {
+	text, keyboard := pageMarkup(out.lang, out.pages(), 0, prefix, args)
+
+	msg := markup.NewMessage(chatID, text)
+	msg.DisableWebPagePreview = true
+	if keyboard != nil {
+		msg.ReplyMarkup = *keyboard
+	}
+	if _, err := bot.Send(msg); err != nil {
+		return fmt.Errorf("send page: %w", err)
+	}
+	return nil
+}
+
+// editPaged replaces the message with the given page of the output.
+func editPaged(bot *tgbotapi.BotAPI, message *tgbotapi.Message, out pagedOutput, page int, prefix string, args []string) error {
+	text, keyboard := pageMarkup(out.lang, out.pages(), page, prefix, args)
+
+	edit := markup.NewEditMessageText(message.Chat.ID, message.MessageID, text)
+	edit.DisableWebPagePreview = true
+	edit.ReplyMarkup = keyboard
+	if _, err := bot.Send(edit); err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		return fmt.Errorf("edit page: %w", err)
+	}
+	return nil
+}
+
+// pageCallbackMessage returns the message a page button belongs to as if
+// the user who pressed it had sent it, so the page is rendered for them.
+func pageCallbackMessage(query *tgbotapi.CallbackQuery) *tgbotapi.Message {
+	message := *query.Message
+	message.From = query.From
+	return &message
+}
+
+// splitSort takes a "sort:<key>" option out of the arguments. It returns
+// the key, def when there is none, and a problem message in lang when the
+// key is not one of keys.
+func splitSort(lang string, args []string, def string, keys ...string) (string, []string, string) {
+	key := def
+	var rest []string
+	for _, arg := range args {
+		value, ok := strings.CutPrefix(strings.ToLower(arg), "sort:")
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+		if !slices.Contains(keys, value) {
+			return "", nil, i18n.T(lang, "sort.unknown", markup.Escape(value), "`sort:"+strings.Join(keys, "`, `sort:")+"`")
+		}
+		key = value
+	}
+	return key, rest, ""
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// downloadTimeout bounds downloading a file sent to the bot.
+const downloadTimeout = 30 * time.Second
+
+// telegramFile describes a file sent to the bot that can be downloaded.
+type telegramFile struct {
+	FileID      string
+	FileName    string
+	ContentType string
+}
+
+// receiptFile returns the photo or image/PDF document carried by the message
+// or, if there is none, by the message it replies to.
+func receiptFile(message *tgbotapi.Message) *telegramFile {
+	for m := message; m != nil; m = m.ReplyToMessage {
+		if len(m.Photo) > 0 {
+			// Telegram sends several sizes, the last one is the largest
+			photo := m.Photo[len(m.Photo)-1]
+			return &telegramFile{
+				FileID:      photo.FileID,
+				FileName:    "receipt.jpg",
+				ContentType: "image/jpeg",
+			}
+		}
+		if doc := m.Document; doc != nil && models.SafeContentType(doc.MimeType) &&
+			(strings.HasPrefix(doc.MimeType, "image/") || doc.MimeType == "application/pdf") {
+			return &telegramFile{
+				FileID:      doc.FileID,
+				FileName:    doc.FileName,
+				ContentType: doc.MimeType,
+			}
+		}
+		if m != message {
+			break
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// ReceiptHandler – /receipt [item id | trip <id>]
+// ---------------------------------------------------------------------------
+
+// ReceiptHandler handles the /receipt command, sent as the caption of a photo
+// or as a reply to one. Without arguments the receipt is attached to the most
+// recent shopping trip; an item ID attaches it to a single bought item.
+type ReceiptHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewReceiptHandler creates a new ReceiptHandler.
+func NewReceiptHandler(svc *service.Service, logger *logrus.Logger) *ReceiptHandler {
+	return &ReceiptHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /receipt command.
+func (h *ReceiptHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	file := receiptFile(message)
+	if file == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	list, err := h.svc.Buying.GetListByChatID(ctx, chatID)
+	if err != nil || list == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.no_list"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var (
+		entityType models.AttachmentEntity
+		entityID   int64
+		label      string
+	)
+
+	switch {
+	case len(args) == 0:
+		trips, err := h.svc.Buying.GetTrips(ctx, list.ID, 1)
+		if err != nil {
+			return fmt.Errorf("get trips: %w", err)
+		}
+		if len(trips) == 0 {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.no_trip"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		entityType, entityID = models.AttachmentEntityShoppingTrip, trips[0].ID
+		label = i18n.T(lang, "receipt.trip_label", trips[0].ID)
+
+	case strings.EqualFold(args[0], "trip"):
+		if len(args) < 2 {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.trip_missing"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		tripID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.trip_invalid"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		trip, err := h.svc.Buying.GetTripByID(ctx, tripID)
+		if err != nil {
+			return fmt.Errorf("get trip: %w", err)
+		}
+		if trip == nil || trip.BuyingListID != list.ID {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.trip_not_found", tripID))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		entityType, entityID = models.AttachmentEntityShoppingTrip, trip.ID
+		label = i18n.T(lang, "receipt.trip_label", trip.ID)
+
+	default:
+		itemID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.item_invalid"))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		item, err := h.svc.Buying.GetItemByID(ctx, itemID)
+		if err != nil {
+			return fmt.Errorf("get item: %w", err)
+		}
+		if item == nil || item.BuyingListID != list.ID {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.item_not_found", itemID))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		if !item.Bought {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.not_bought", itemID, itemID))
+			telegram.Send(bot, h.logger, msg)
+			return nil
+		}
+		entityType, entityID = models.AttachmentEntityBuyingItem, item.ID
+		label = i18n.T(lang, "receipt.item_label", item.ID)
+	}
+
+	dlCtx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+
+	content, err := h.svc.TelegramFiles.Open(dlCtx, file.FileID)
+	if err != nil {
+		return fmt.Errorf("download receipt: %w", err)
+	}
+	defer content.Close()
+
+	uploadedBy := user.ID
+	attachment, err := h.svc.SaveAttachment(dlCtx, &models.Attachment{
+		FamilyID:       family.ID,
+		ChatID:         chatID,
+		EntityType:     entityType,
+		EntityID:       entityID,
+		FileName:       file.FileName,
+		ContentType:    file.ContentType,
+		TelegramFileID: file.FileID,
+		UploadedByID:   &uploadedBy,
+	}, content)
+	if err != nil {
+		return fmt.Errorf("save receipt: %w", err)
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "receipt.attached", label))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":       message.Chat.ID,
+		"attachment_id": attachment.ID,
+		"entity_type":   entityType,
+		"entity_id":     entityID,
+	}).Info("Attached receipt")
+
+	return nil
+}
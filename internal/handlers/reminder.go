@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -11,8 +12,12 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Kerhoff/TodoboT/internal/dialog"
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
 )
 
 var (
@@ -78,37 +83,39 @@ func parseRemindTime(args []string) (time.Time, int, error) {
 	return time.Time{}, 0, fmt.Errorf("unrecognized time format: %s", args[0])
 }
 
-// formatReminderTime produces a human-readable string for when a reminder
-// is scheduled to fire. For times less than 24 h away it shows a relative
-// duration together with the clock time; otherwise it shows the full date.
-func formatReminderTime(t time.Time) string {
+// formatReminderTime produces a human-readable string in lang for when a
+// reminder is scheduled to fire. For times less than 24 h away it shows a
+// relative duration together with the clock time; otherwise it shows the
+// full date.
+func formatReminderTime(lang string, t time.Time) string {
 	now := time.Now()
 	diff := t.Sub(now)
 
 	if diff < 0 {
-		return t.Format("2006-01-02 15:04") + " (overdue)"
+		return i18n.T(lang, "remind.overdue", t.Format("2006-01-02 15:04"))
 	}
 
 	if diff < 24*time.Hour {
 		hours := int(diff.Hours())
 		minutes := int(diff.Minutes()) % 60
 		if hours > 0 {
-			return fmt.Sprintf("in %dh %dm (%s)", hours, minutes, t.Format("15:04"))
+			return i18n.T(lang, "remind.in_hours", hours, minutes, t.Format("15:04"))
 		}
 		if minutes > 0 {
-			return fmt.Sprintf("in %dm (%s)", minutes, t.Format("15:04"))
+			return i18n.T(lang, "remind.in_minutes", minutes, t.Format("15:04"))
 		}
-		return fmt.Sprintf("in less than a minute (%s)", t.Format("15:04"))
+		return i18n.T(lang, "remind.in_moment", t.Format("15:04"))
 	}
 
-	return t.Format("Mon, 02 Jan 2006 at 15:04")
+	return eventWhen(lang, t, false)
 }
 
 // ---------------------------------------------------------------------------
-// RemindHandler – /remind <time> <text>
+// RemindHandler – /remind [<time> <text>]
 // ---------------------------------------------------------------------------
 
-// RemindHandler handles the /remind command to create a reminder.
+// RemindHandler handles the /remind command to create a reminder. Without
+// arguments it asks what to remind of and then when, offering a date picker.
 type RemindHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -121,60 +128,59 @@ func NewRemindHandler(svc *service.Service, logger *logrus.Logger) *RemindHandle
 
 // Handle processes the /remind command.
 func (h *RemindHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		state, err := h.svc.Dialogs.Start(ctx, message.Chat.ID, message.From.ID, "remind", "text")
+		if err != nil {
+			return fmt.Errorf("start remind dialog: %w", err)
+		}
+		askRemindStep(lang, bot, h.logger, message, state)
+		return nil
+	}
+
 	if len(args) < 2 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a time and reminder text.\n\n"+
-				"*Usage:*\n"+
-				"`/remind 10m Take out trash`\n"+
-				"`/remind 2h Call dentist`\n"+
-				"`/remind 1d Pay bills`\n"+
-				"`/remind 15:30 Pick up kids`\n"+
-				"`/remind 2025-12-31 15:30 New Year party`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	remindAt, textStart, err := parseRemindTime(args)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Could not parse time.\n\n"+
-				"Supported formats: `10m`, `2h`, `1d`, `15:30`, `2025-12-31 15:30`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.bad_time"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	if textStart >= len(args) {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a reminder text after the time.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.no_text"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	reminderText := strings.Join(args[textStart:], " ")
-
-	ctx := context.Background()
+	return createReminder(ctx, bot, h.svc, h.logger, message, lang, strings.Join(args[textStart:], " "), remindAt)
+}
 
-	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+// createReminder saves a one-time reminder for the message's author in the
+// family of the message's chat and confirms it.
+func createReminder(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, lang, reminderText string, remindAt time.Time) error {
+	user, err := svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	chatTitle := message.Chat.Title
-	if chatTitle == "" {
-		chatTitle = message.From.FirstName + "'s reminders"
-	}
-	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, chatTitle)
+	chatID, chatTitle := workspaceChat(ctx, svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := svc.EnsureFamily(ctx, chatID, chatTitle)
 	if err != nil {
 		return fmt.Errorf("ensure family: %w", err)
 	}
-	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+	_ = svc.EnsureFamilyMember(ctx, family.ID, user.ID)
 
 	reminder := &models.Reminder{
 		FamilyID: family.ID,
-		ChatID:   message.Chat.ID,
+		ChatID:   chatID,
 		UserID:   user.ID,
 		Text:     reminderText,
 		RemindAt: remindAt,
@@ -182,18 +188,16 @@ func (h *RemindHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		Active:   true,
 	}
 
-	reminder, err = h.svc.Reminders.Create(ctx, reminder)
+	reminder, err = svc.Reminders.Create(ctx, reminder)
 	if err != nil {
 		return fmt.Errorf("create reminder: %w", err)
 	}
 
-	text := fmt.Sprintf("⏰ *Reminder set!*\n\n*#%d* — %s\n📅 %s",
-		reminder.ID, reminderText, formatReminderTime(remindAt))
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	text := i18n.T(lang, "remind.set", reminder.ID, markup.Escape(reminderText), formatReminderTime(lang, remindAt))
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, logger, msg)
 
-	h.logger.WithFields(logrus.Fields{
+	logger.WithFields(logrus.Fields{
 		"chat_id":     message.Chat.ID,
 		"user_id":     message.From.ID,
 		"reminder_id": reminder.ID,
@@ -203,6 +207,192 @@ func (h *RemindHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 	return nil
 }
 
+// remindDialog asks what to remind of, then when.
+func remindDialog(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, state *dialog.State, answer string) error {
+	lang := messageLang(ctx, svc, message)
+
+	switch state.Step {
+	case "text":
+		state.Data["text"] = answer
+		state.Step = "when"
+		if err := svc.Dialogs.Save(ctx, state); err != nil {
+			return fmt.Errorf("save remind dialog: %w", err)
+		}
+		askRemindStep(lang, bot, logger, message, state)
+		return nil
+
+	case "when":
+		fields := strings.Fields(answer)
+		remindAt, n, err := parseRemindTime(fields)
+		if err != nil || n != len(fields) || remindAt.Before(time.Now()) {
+			msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.bad_when"))
+			telegram.Send(bot, logger, msg)
+			return nil
+		}
+		// Of answers arriving at once, only the one that ends the
+		// conversation creates the reminder
+		if ended, err := svc.Dialogs.End(ctx, state.ChatID, state.UserID); err != nil || !ended {
+			return err
+		}
+		return createReminder(ctx, bot, svc, logger, message, lang, state.Data["text"], remindAt)
+	}
+
+	svc.Dialogs.End(ctx, state.ChatID, state.UserID)
+	return fmt.Errorf("unknown remind dialog step %q", state.Step)
+}
+
+// askRemindStep asks the question of the remind dialog's current step.
+func askRemindStep(lang string, bot *tgbotapi.BotAPI, logger *logrus.Logger, message *tgbotapi.Message, state *dialog.State) {
+	switch state.Step {
+	case "text":
+		askDialog(bot, logger, message, i18n.T(lang, "remind.ask_text"), nil)
+	case "when":
+		keyboard := remindPicker(lang, state).Keyboard(time.Now())
+		askDialog(bot, logger, message, i18n.T(lang, "remind.ask_when", markup.Escape(state.Data["text"])), &keyboard)
+	}
+}
+
+// remindPicker is the date picker asking when to remind.
+func remindPicker(lang string, state *dialog.State) *telegram.DatePicker {
+	if state.Step != "when" {
+		return nil
+	}
+	picker := dialogPicker(lang, state)
+	picker.Time = true
+	picker.From = time.Now()
+	return picker
+}
+
+// ---------------------------------------------------------------------------
+// SnoozeHandler – buttons under reminders that went off
+// ---------------------------------------------------------------------------
+
+// snoozeDelays are the quick snooze buttons, in parseRemindTime's relative
+// format.
+var snoozeDelays = []string{"15m", "1h", "3h", "1d"}
+
+// SnoozeHandler handles the buttons under a reminder that went off, which
+// bring it back after a while or at a time picked on a date picker.
+// Callback data has the form "<reminder id>:<delay>", "<reminder id>:pick"
+// to show the picker, "<reminder id>:back" to hide it again and
+// "<reminder id>:p:<picker data>" for presses on the picker.
+type SnoozeHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewSnoozeHandler creates a new SnoozeHandler.
+func NewSnoozeHandler(svc *service.Service, logger *logrus.Logger) *SnoozeHandler {
+	return &SnoozeHandler{svc: svc, logger: logger}
+}
+
+// Keyboard returns the snooze buttons to send with the reminder.
+func (h *SnoozeHandler) Keyboard(reminder *models.Reminder) tgbotapi.InlineKeyboardMarkup {
+	lang := h.svc.ChatLanguage(context.Background(), reminder.ChatID, reminder.UserID)
+	return snoozeKeyboard(lang, reminder.ID)
+}
+
+// snoozeKeyboard offers the quick snooze delays and the date picker.
+func snoozeKeyboard(lang string, reminderID int64) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, delay := range snoozeDelays {
+		data := fmt.Sprintf("snooze:%d:%s", reminderID, delay)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "snooze."+delay), data))
+	}
+	pick := tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "snooze.pick"), fmt.Sprintf("snooze:%d:pick", reminderID))
+	return tgbotapi.NewInlineKeyboardMarkup(row, tgbotapi.NewInlineKeyboardRow(pick))
+}
+
+// snoozePicker is the date picker for snoozing the reminder until a given
+// time.
+func snoozePicker(lang string, reminderID int64) telegram.DatePicker {
+	return telegram.DatePicker{
+		Prefix: fmt.Sprintf("snooze:%d:p:", reminderID),
+		Lang:   lang,
+		Time:   true,
+		From:   time.Now(),
+		Footer: tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "snooze.back"), fmt.Sprintf("snooze:%d:back", reminderID)),
+		),
+	}
+}
+
+// HandleCallback processes a press on a snooze button.
+func (h *SnoozeHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+	idPart, action, _ := strings.Cut(data, ":")
+	reminderID, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	message := pageCallbackMessage(query)
+	lang := messageLang(ctx, h.svc, message)
+
+	var remindAt time.Time
+	switch {
+	case action == "pick":
+		keyboard := snoozePicker(lang, reminderID).Keyboard(time.Now())
+		telegram.Send(bot, h.logger, tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID, keyboard))
+		return nil
+	case action == "back":
+		telegram.Send(bot, h.logger, tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID, snoozeKeyboard(lang, reminderID)))
+		return nil
+	case strings.HasPrefix(action, "p:"):
+		next, picked, _ := snoozePicker(lang, reminderID).Update(strings.TrimPrefix(action, "p:"))
+		if next != nil {
+			telegram.Send(bot, h.logger, tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID, *next))
+			return nil
+		}
+		if picked.IsZero() {
+			return nil
+		}
+		remindAt = picked
+	default:
+		if remindAt, _, err = parseRemindTime([]string{action}); err != nil {
+			return nil
+		}
+	}
+
+	user, err := h.svc.EnsureUser(ctx, query.From.ID, query.From.UserName, query.From.FirstName, query.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	reminder, err := h.svc.SnoozeReminder(ctx, reminderID, user.ID, remindAt)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		reminder, err = h.svc.SnoozeReminder(ctx, reminderID, user.ID, remindAt)
+	}
+
+	var text string
+	switch {
+	case errors.Is(err, service.ErrReminderNotFound):
+		text = i18n.T(lang, "remind.not_found", reminderID)
+	case errors.Is(err, service.ErrForbidden):
+		text = i18n.T(lang, "snooze.forbidden")
+	case err != nil:
+		return fmt.Errorf("snooze reminder: %w", err)
+	default:
+		clearKeyboard(bot, h.logger, message)
+		text = i18n.T(lang, "snooze.done", reminder.ID, formatReminderTime(lang, remindAt))
+
+		h.logger.WithFields(logrus.Fields{
+			"chat_id":     message.Chat.ID,
+			"user_id":     query.From.ID,
+			"reminder_id": reminder.ID,
+			"remind_at":   remindAt,
+		}).Info("Reminder snoozed")
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // RemindersListHandler – /reminders
 // ---------------------------------------------------------------------------
@@ -222,6 +412,7 @@ func NewRemindersListHandler(svc *service.Service, logger *logrus.Logger) *Remin
 // Handle processes the /reminders command.
 func (h *RemindersListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
 
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
@@ -242,29 +433,26 @@ func (h *RemindersListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Me
 	}
 
 	if len(active) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"⏰ *No active reminders!*\n\nCreate one with `/remind <time> <text>`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "reminders.empty"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	var sb strings.Builder
-	sb.WriteString("⏰ *Your Reminders*\n\n")
+	sb.WriteString(i18n.T(lang, "reminders.heading") + "\n\n")
 
 	for i, r := range active {
-		sb.WriteString(fmt.Sprintf("%d. *#%d* %s\n   📅 %s", i+1, r.ID, r.Text, formatReminderTime(r.RemindAt)))
+		sb.WriteString(fmt.Sprintf("%d. *#%d* %s\n   📅 %s", i+1, r.ID, markup.Escape(r.Text), formatReminderTime(lang, r.RemindAt)))
 		if r.Repeat != models.ReminderRepeatNone {
-			sb.WriteString(fmt.Sprintf(" (🔁 %s)", string(r.Repeat)))
+			sb.WriteString(fmt.Sprintf(" (🔁 %s)", i18n.T(lang, "recurrence."+string(r.Repeat))))
 		}
 		sb.WriteString("\n\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("_%d active reminders_\n\n_Delete with_ `/delremind <id>`", len(active)))
+	sb.WriteString("_" + i18n.N(lang, "reminders.count", len(active)) + "_\n\n" + i18n.T(lang, "reminders.footer"))
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
@@ -293,25 +481,22 @@ func NewRemindDeleteHandler(svc *service.Service, logger *logrus.Logger) *Remind
 
 // Handle processes the /delremind command.
 func (h *RemindDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a reminder ID.\nUsage: `/delremind 3`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "delremind.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	reminderID, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid ID. Please provide a numeric reminder ID.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
-
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
@@ -319,19 +504,18 @@ func (h *RemindDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Mes
 
 	reminder, err := h.svc.Reminders.GetByID(ctx, reminderID)
 	if err != nil || reminder == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Reminder *#%d* not found.", reminderID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.not_found", reminderID))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	// Only the owner can delete their reminder
-	if reminder.UserID != user.ID {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ You can only delete your own reminders.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	// Only the owner or a family admin can delete a reminder
+	if err := authorize(ctx, bot, h.svc, message, user.ID, reminder.UserID); err != nil {
+		if !errors.Is(err, service.ErrForbidden) {
+			return fmt.Errorf("authorize: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "remind.delete_forbidden"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
@@ -339,10 +523,9 @@ func (h *RemindDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Mes
 		return fmt.Errorf("delete reminder: %w", err)
 	}
 
-	text := fmt.Sprintf("🗑 Reminder *#%d* deleted: %s", reminder.ID, reminder.Text)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	text := i18n.T(lang, "remind.deleted", reminder.ID, markup.Escape(reminder.Text))
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id":     message.Chat.ID,
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// searchPageSize is how many results a page of /search shows.
+const searchPageSize = 8
+
+// searchGroupTitles are the message keys of the titles heading the groups
+// of search results.
+var searchGroupTitles = map[models.SearchResultType]string{
+	models.SearchResultTodo:       "search.group.todo",
+	models.SearchResultEvent:      "search.group.event",
+	models.SearchResultBuyingItem: "search.group.buying",
+	models.SearchResultWishItem:   "search.group.wish",
+	models.SearchResultReminder:   "search.group.reminder",
+}
+
+// ---------------------------------------------------------------------------
+// SearchHandler – /search <query>
+// ---------------------------------------------------------------------------
+
+// SearchHandler handles the /search command, which finds todos (also by
+// their comments), events, shopping items, wishes and reminders of the
+// family. Results come in pages with buttons to move between them.
+type SearchHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewSearchHandler creates a new SearchHandler.
+func NewSearchHandler(svc *service.Service, logger *logrus.Logger) *SearchHandler {
+	return &SearchHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /search command.
+func (h *SearchHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	query := strings.Join(args, " ")
+	if strings.TrimSpace(query) == "" {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "search.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+
+	text, keyboard, err := h.page(ctx, lang, chatID, query, 0)
+	if err != nil {
+		return err
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	if keyboard != nil {
+		msg.ReplyMarkup = *keyboard
+	}
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+	}).Info("Searched")
+
+	return nil
+}
+
+// HandleCallback processes a press on a search page button. The data is
+// "<offset>:<query>".
+func (h *SearchHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+
+	rawOffset, text, ok := strings.Cut(data, ":")
+	offset, err := strconv.Atoi(rawOffset)
+	if !ok || err != nil {
+		return fmt.Errorf("invalid search callback %q", data)
+	}
+
+	ctx := context.Background()
+	// Work on the family of whoever pressed the button, as /search would
+	message := *query.Message
+	message.From = query.From
+	chatID, _ := workspaceChat(ctx, h.svc, &message, "")
+
+	page, keyboard, err := h.page(ctx, messageLang(ctx, h.svc, &message), chatID, text, offset)
+	if err != nil {
+		return err
+	}
+
+	var edit tgbotapi.EditMessageTextConfig
+	if keyboard != nil {
+		edit = markup.NewEditMessageTextAndMarkup(message.Chat.ID, message.MessageID, page, *keyboard)
+	} else {
+		edit = markup.NewEditMessageText(message.Chat.ID, message.MessageID, page)
+	}
+	telegram.Send(bot, h.logger, edit)
+
+	return nil
+}
+
+// page renders the search results starting at offset in lang, with the
+// buttons to the previous and next page if there are any.
+func (h *SearchHandler) page(ctx context.Context, lang string, chatID int64, query string, offset int) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	result, err := h.svc.Search(ctx, chatID, query, searchPageSize, offset)
+	if errors.Is(err, service.ErrEmptySearch) {
+		return i18n.T(lang, "search.empty_query"), nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("search: %w", err)
+	}
+
+	shown := markup.Escape(query)
+	if len(result.Results) == 0 {
+		return i18n.T(lang, "search.nothing", shown), nil, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "search.heading", shown, offset+1, offset+len(result.Results), result.Total))
+
+	var group models.SearchResultType
+	for _, r := range result.Results {
+		if r.Type != group {
+			group = r.Type
+			sb.WriteString("\n\n" + i18n.T(lang, searchGroupTitles[group]))
+		}
+		sb.WriteString(fmt.Sprintf("\n• *#%d* %s", r.ID, markup.Escape(r.Title)))
+	}
+
+	var buttons []tgbotapi.InlineKeyboardButton
+	if offset > 0 {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "page.prev"),
+			searchCallbackData(max(offset-searchPageSize, 0), query)))
+	}
+	if offset+len(result.Results) < result.Total {
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "page.next"),
+			searchCallbackData(offset+searchPageSize, query)))
+	}
+	if len(buttons) == 0 {
+		return sb.String(), nil, nil
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(buttons)
+	return sb.String(), &keyboard, nil
+}
+
+// searchCallbackData encodes a search page for a button. Telegram allows 64
+// bytes of callback data, so long queries are cut short.
+func searchCallbackData(offset int, query string) string {
+	data := fmt.Sprintf("search:%d:%s", offset, query)
+	for len(data) > 64 {
+		_, size := utf8.DecodeLastRuneInString(data)
+		data = data[:len(data)-size]
+	}
+	return data
+}
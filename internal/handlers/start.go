@@ -1,39 +1,31 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/service"
 )
 
 // StartHandler handles the /start command
 type StartHandler struct {
+	svc    *service.Service
 	logger *logrus.Logger
 }
 
-func NewStartHandler(logger *logrus.Logger) *StartHandler {
-	return &StartHandler{logger: logger}
+func NewStartHandler(svc *service.Service, logger *logrus.Logger) *StartHandler {
+	return &StartHandler{svc: svc, logger: logger}
 }
 
 func (h *StartHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
-	welcomeText := `
-🎯 *Welcome to TodoboT!*
-
-Your family assistant for tasks, events, shopping, wishes, and reminders.
-
-*Quick Start:*
-• /add Buy groceries - Add a todo
-• /event Birthday 2025-03-15 - Add event
-• /buy Milk x 2 - Add to shopping list
-• /wish New headphones - Add to wish list
-• /remind 2h Take medicine - Set reminder
-
-Type /help for the full command list!
-	`
+	welcomeText := i18n.T(messageLang(context.Background(), h.svc, message), "start.text")
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, welcomeText)
-	msg.ParseMode = tgbotapi.ModeMarkdown
+	msg := markup.NewMessage(message.Chat.ID, welcomeText)
 
 	_, err := bot.Send(msg)
 	if err != nil {
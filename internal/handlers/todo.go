@@ -2,16 +2,22 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/repository"
 	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
 )
 
 // priorityEmoji returns an emoji representing the todo priority level.
@@ -28,6 +34,158 @@ func priorityEmoji(p models.TodoPriority) string {
 	}
 }
 
+// todoStatusEmoji returns an emoji representing the todo status.
+func todoStatusEmoji(s models.TodoStatus) string {
+	switch s {
+	case models.TodoStatusCompleted:
+		return "✅"
+	case models.TodoStatusCancelled:
+		return "🚫"
+	default:
+		return "⏳"
+	}
+}
+
+// todoStatusName returns the status as a word in lang.
+func todoStatusName(lang string, s models.TodoStatus) string {
+	return i18n.T(lang, "todo.status."+string(s))
+}
+
+// assigneeName returns the display name of the assignee, or "anyone" for an
+// unassigned todo, escaped for a message.
+func assigneeName(ctx context.Context, svc *service.Service, lang string, userID *int64) string {
+	if userID == nil {
+		return i18n.T(lang, "common.anyone")
+	}
+	user, err := svc.Users.GetByID(ctx, *userID)
+	if err != nil || user == nil {
+		return i18n.T(lang, "common.someone")
+	}
+	return markup.Escape(user.DisplayName())
+}
+
+// splitTags separates #tags from the other words, normalising and
+// deduplicating them.
+func splitTags(words []string) (tags, rest []string) {
+	for _, w := range words {
+		if !strings.HasPrefix(w, "#") {
+			rest = append(rest, w)
+			continue
+		}
+		tag, ok := models.NormalizeTag(w)
+		if !ok {
+			rest = append(rest, w)
+			continue
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, rest
+}
+
+// formatTags renders e.g. " `#school` `#math`" for tagged todos.
+func formatTags(tags []string) string {
+	var sb strings.Builder
+	for _, tag := range tags {
+		sb.WriteString(" `#" + tag + "`")
+	}
+	return sb.String()
+}
+
+// loadChatTodo resolves the todo ID in args to a todo of the chat's family.
+// Problems are reported to the chat in lang and yield a nil todo.
+func loadChatTodo(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger, lang string, message *tgbotapi.Message, args []string) (*models.Todo, error) {
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.id_missing", message.Command()))
+		telegram.Send(bot, logger, msg)
+		return nil, nil
+	}
+
+	todoID, err := strconv.ParseInt(strings.TrimPrefix(args[0], "#"), 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.id_invalid"))
+		telegram.Send(bot, logger, msg)
+		return nil, nil
+	}
+
+	todo, err := svc.Todos.GetByID(ctx, todoID)
+	if err != nil || todo == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.not_found", todoID))
+		telegram.Send(bot, logger, msg)
+		return nil, nil
+	}
+
+	// Validate that the todo belongs to this chat or its family
+	chatID, _ := workspaceChat(ctx, svc, message, "")
+	if same, _ := svc.SameFamily(ctx, todo.ChatID, chatID); !same {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.not_in_chat", todoID))
+		telegram.Send(bot, logger, msg)
+		return nil, nil
+	}
+
+	return todo, nil
+}
+
+// changeTodoStatus implements /done, /cancel and /reopen.
+func changeTodoStatus(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, args []string, to models.TodoStatus) error {
+	user, err := svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	lang := messageLang(ctx, svc, message)
+	todo, err := loadChatTodo(ctx, bot, svc, logger, lang, message, args)
+	if err != nil || todo == nil {
+		return err
+	}
+
+	from := todo.Status
+	next, err := svc.TransitionTodo(ctx, todo, user.ID, to)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, svc, message, user.ID) {
+		next, err = svc.TransitionTodo(ctx, todo, user.ID, to)
+	}
+
+	var text string
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		text = i18n.T(lang, "todo.change_forbidden")
+	case errors.Is(err, service.ErrTodoTransition) && todo.Status == to:
+		text = i18n.T(lang, "todo.already", todo.ID, todoStatusName(lang, to))
+	case errors.Is(err, service.ErrTodoTransition):
+		text = i18n.T(lang, "todo.not_pending", todo.ID, todoStatusName(lang, todo.Status), todo.ID)
+	case err != nil:
+		return fmt.Errorf("change todo status: %w", err)
+	case to == models.TodoStatusCompleted && next != nil:
+		text = i18n.T(lang, "todo.completed_next", todo.ID, markup.Escape(todo.Title),
+			next.ID, assigneeName(ctx, svc, lang, next.AssignedToID), i18n.Date(lang, *next.Deadline, "Mon, 02 Jan"))
+	case to == models.TodoStatusCompleted:
+		text = i18n.T(lang, "todo.completed", todo.ID, markup.Escape(todo.Title))
+	case to == models.TodoStatusCancelled && todo.IsRecurring():
+		text = i18n.T(lang, "chore.cancelled", todo.ID, markup.Escape(todo.Title))
+	case to == models.TodoStatusCancelled:
+		text = i18n.T(lang, "todo.cancelled", todo.ID, markup.Escape(todo.Title))
+	default:
+		text = i18n.T(lang, "todo.reopened", todo.ID, priorityEmoji(todo.Priority), todo.ID, markup.Escape(todo.Title))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, logger, msg)
+
+	if err == nil {
+		logger.WithFields(logrus.Fields{
+			"chat_id": message.Chat.ID,
+			"user_id": message.From.ID,
+			"todo_id": todo.ID,
+			"from":    from,
+			"to":      to,
+		}).Info("Todo status changed")
+	}
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // AddHandler – /add <text>
 // ---------------------------------------------------------------------------
@@ -45,51 +203,71 @@ func NewAddHandler(svc *service.Service, logger *logrus.Logger) *AddHandler {
 
 // Handle processes the /add command.
 func (h *AddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	// Media alone makes a todo named after it
+	media := mediaFile(message)
+	if len(args) == 0 && media != nil {
+		args = strings.Fields(mediaTitle(lang, media))
+	}
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a todo text.\nUsage: `/add Buy groceries`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.text_missing"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
+	tags, words := splitTags(args)
+	if len(words) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.text_only_tags"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
 
-	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	return createTodo(ctx, bot, h.svc, h.logger, message, lang, strings.Join(words, " "), tags, media)
+}
+
+// createTodo saves a todo by the message's author in the chat the message
+// works on, attaches media if given and confirms it.
+func createTodo(ctx context.Context, bot *tgbotapi.BotAPI, svc *service.Service, logger *logrus.Logger,
+	message *tgbotapi.Message, lang, title string, tags []string, media *telegramMedia) error {
+	user, err := svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	chatTitle := message.Chat.Title
-	if chatTitle == "" {
-		chatTitle = message.From.FirstName + "'s list"
-	}
-	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, chatTitle)
+	chatID, chatTitle := workspaceChat(ctx, svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := svc.EnsureFamily(ctx, chatID, chatTitle)
 	if err != nil {
 		return fmt.Errorf("ensure family: %w", err)
 	}
-	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+	_ = svc.EnsureFamilyMember(ctx, family.ID, user.ID)
 
-	title := strings.Join(args, " ")
 	todo := &models.Todo{
 		Title:       title,
 		Status:      models.TodoStatusPending,
 		Priority:    models.TodoPriorityMedium,
 		CreatedByID: user.ID,
-		ChatID:      message.Chat.ID,
+		ChatID:      chatID,
+		Tags:        tags,
 	}
 
-	todo, err = h.svc.Todos.Create(ctx, todo)
+	todo, err = svc.Todos.Create(ctx, todo)
 	if err != nil {
 		return fmt.Errorf("create todo: %w", err)
 	}
 
-	text := fmt.Sprintf("✅ *Todo added!*\n\n🟡 *#%d* — %s", todo.ID, todo.Title)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	suffix := formatTags(todo.Tags)
+	if media != nil && attachMedia(ctx, svc, logger, media, family.ID, chatID, models.AttachmentEntityTodo, todo.ID, user.ID) {
+		suffix += " 📎"
+	}
+
+	text := i18n.T(lang, "todo.added", todo.ID, markup.Escape(todo.Title), suffix)
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, logger, msg)
 
-	h.logger.WithFields(logrus.Fields{
+	logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
 		"user_id": message.From.ID,
 		"todo_id": todo.ID,
@@ -103,6 +281,10 @@ func (h *AddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, arg
 // ---------------------------------------------------------------------------
 
 // ListHandler handles the /list command to display pending todos for the chat.
+// The list can be narrowed down with #tags (todos carrying all of them), an
+// @user they are assigned to and "overdue", e.g. `/list #school @anna`, and
+// ordered with sort:created (newest first, the default), sort:deadline or
+// sort:priority. Long lists come in pages.
 type ListHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -117,48 +299,136 @@ func NewListHandler(svc *service.Service, logger *logrus.Logger) *ListHandler {
 func (h *ListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
 
+	out, count, err := h.output(ctx, message, args)
+	if err != nil {
+		return err
+	}
+	if err := sendPaged(bot, message.Chat.ID, out, "list", args); err != nil {
+		return err
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"count":   count,
+	}).Info("Listed todos")
+
+	return nil
+}
+
+// HandleCallback processes a press on a page button of /list.
+func (h *ListHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+	page, args, err := parsePageCallback(data)
+	if err != nil {
+		return err
+	}
+
+	message := pageCallbackMessage(query)
+	out, _, err := h.output(context.Background(), message, args)
+	if err != nil {
+		return err
+	}
+	return editPaged(bot, message, out, page, "list", args)
+}
+
+// output renders the todos /list shows for args, and how many there are.
+func (h *ListHandler) output(ctx context.Context, message *tgbotapi.Message, args []string) (pagedOutput, int, error) {
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
+
 	status := models.TodoStatusPending
 	filters := repository.TodoFilters{Status: &status}
 
-	todos, err := h.svc.Todos.GetByChatID(ctx, message.Chat.ID, filters)
+	sort, rest, problem := splitSort(lang, args, string(repository.TodoSortCreated),
+		string(repository.TodoSortCreated), string(repository.TodoSortDeadline), string(repository.TodoSortPriority))
+	filters.Sort = repository.TodoSort(sort)
+	tags, rest := splitTags(rest)
+	filters.Tags = tags
+	users, rest, mentionProblem := splitMentions(ctx, h.svc, lang, rest)
+	if problem == "" {
+		problem = mentionProblem
+	}
+	if problem == "" && len(users) > 1 {
+		problem = i18n.T(lang, "list.one_assignee")
+	}
+	for _, word := range rest {
+		if strings.EqualFold(word, "overdue") {
+			filters.OverdueOnly = true
+			continue
+		}
+		if problem == "" {
+			problem = i18n.T(lang, "list.unknown_filter", markup.Escape(word))
+		}
+	}
+	if problem != "" {
+		return pagedOutput{header: problem}, 0, nil
+	}
+
+	var filterNames []string
+	if filters.OverdueOnly {
+		filterNames = append(filterNames, i18n.T(lang, "list.filter_overdue"))
+	}
+	if len(users) == 1 {
+		filters.AssignedToID = &users[0].ID
+		filterNames = append(filterNames, i18n.T(lang, "list.filter_for", markup.Escape(users[0].DisplayName())))
+	}
+	if len(tags) > 0 {
+		filterNames = append(filterNames, strings.TrimSpace(formatTags(tags)))
+	}
+	if filters.Sort != repository.TodoSortCreated {
+		filterNames = append(filterNames, i18n.T(lang, "list.filter_by", i18n.T(lang, "sort."+sort)))
+	}
+
+	todos, err := h.svc.Todos.GetByChatID(ctx, chatID, filters)
+	if err != nil {
+		return pagedOutput{}, 0, fmt.Errorf("list todos: %w", err)
+	}
+	ids := make([]int64, len(todos))
+	for i, t := range todos {
+		ids[i] = t.ID
+	}
+	attachments, err := h.svc.LoadAttachments(ctx, models.AttachmentEntityTodo, ids)
 	if err != nil {
-		return fmt.Errorf("list todos: %w", err)
+		return pagedOutput{}, 0, fmt.Errorf("load attachments: %w", err)
 	}
 
-	if len(todos) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"📋 *No pending todos!*\n\nAdd one with `/add <text>`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+	heading := i18n.T(lang, "list.heading")
+	if len(filterNames) > 0 {
+		heading += " — " + strings.Join(filterNames, " ")
 	}
 
-	var sb strings.Builder
-	sb.WriteString("📋 *Pending Todos*\n\n")
+	if len(todos) == 0 {
+		if len(filterNames) > 0 {
+			return pagedOutput{header: heading + "\n\n" + i18n.T(lang, "list.no_match")}, 0, nil
+		}
+		return pagedOutput{header: i18n.T(lang, "list.empty")}, 0, nil
+	}
 
+	out := pagedOutput{
+		header: heading + "\n\n",
+		footer: "\n_" + i18n.N(lang, "list.count", len(todos)) + "_",
+		lang:   lang,
+	}
 	for i, t := range todos {
-		sb.WriteString(fmt.Sprintf("%d. %s *#%d* %s", i+1, priorityEmoji(t.Priority), t.ID, t.Title))
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%d. %s *#%d* %s", i+1, priorityEmoji(t.Priority), t.ID, markup.Escape(t.Title)))
 		if t.Deadline != nil {
 			sb.WriteString(fmt.Sprintf("  📅 _%s_", t.Deadline.Format("2006-01-02")))
 		}
+		sb.WriteString(formatTags(t.Tags))
+		sb.WriteString(checklistProgress(t))
+		sb.WriteString(attachmentIndicator(attachments[t.ID]))
+		if t.IsRecurring() {
+			sb.WriteString(" 🔁")
+		}
 		if t.IsOverdue() {
 			sb.WriteString(" ⚠️")
 		}
-		sb.WriteString("\n")
+		out.entries = append(out.entries, sb.String())
 	}
-
-	sb.WriteString(fmt.Sprintf("\n_%d pending items_", len(todos)))
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
-
-	h.logger.WithFields(logrus.Fields{
-		"chat_id": message.Chat.ID,
-		"count":   len(todos),
-	}).Info("Listed todos")
-
-	return nil
+	return out, len(todos), nil
 }
 
 // ---------------------------------------------------------------------------
@@ -166,8 +436,7 @@ func (h *ListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ar
 // ---------------------------------------------------------------------------
 
 // DoneHandler handles the /done command to mark a todo as completed.
-// It validates that the caller is the creator or assignee before allowing
-// the status change.
+// The creator, the assignee and family admins may complete a todo.
 type DoneHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -180,82 +449,149 @@ func NewDoneHandler(svc *service.Service, logger *logrus.Logger) *DoneHandler {
 
 // Handle processes the /done command.
 func (h *DoneHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	return changeTodoStatus(context.Background(), bot, h.svc, h.logger, message, args, models.TodoStatusCompleted)
+}
+
+// ---------------------------------------------------------------------------
+// CancelHandler – /cancel <id>
+// ---------------------------------------------------------------------------
+
+// CancelHandler handles the /cancel command for todos that will not be
+// done. Cancelled todos leave /list but keep their history and can be
+// reopened. Without an ID it stops the question the bot is asking instead.
+type CancelHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewCancelHandler creates a new CancelHandler.
+func NewCancelHandler(svc *service.Service, logger *logrus.Logger) *CancelHandler {
+	return &CancelHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /cancel command.
+func (h *CancelHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a todo ID.\nUsage: `/done 5`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+		cancelled, err := cancelDialog(ctx, bot, h.svc, h.logger, message)
+		if err != nil {
+			return fmt.Errorf("cancel dialog: %w", err)
+		}
+		if cancelled {
+			return nil
+		}
 	}
+	return changeTodoStatus(ctx, bot, h.svc, h.logger, message, args, models.TodoStatusCancelled)
+}
 
-	todoID, err := strconv.ParseInt(args[0], 10, 64)
-	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid ID. Please provide a numeric todo ID.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
-	}
+// ---------------------------------------------------------------------------
+// ReopenHandler – /reopen <id>
+// ---------------------------------------------------------------------------
 
-	ctx := context.Background()
+// ReopenHandler handles the /reopen command, which puts a completed or
+// cancelled todo back to pending.
+type ReopenHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
 
-	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
-	if err != nil {
-		return fmt.Errorf("ensure user: %w", err)
-	}
+// NewReopenHandler creates a new ReopenHandler.
+func NewReopenHandler(svc *service.Service, logger *logrus.Logger) *ReopenHandler {
+	return &ReopenHandler{svc: svc, logger: logger}
+}
 
-	todo, err := h.svc.Todos.GetByID(ctx, todoID)
+// Handle processes the /reopen command.
+func (h *ReopenHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	return changeTodoStatus(context.Background(), bot, h.svc, h.logger, message, args, models.TodoStatusPending)
+}
+
+// ---------------------------------------------------------------------------
+// ShowHandler – /show <id>
+// ---------------------------------------------------------------------------
+
+// ShowHandler handles the /show command, which prints a todo with its
+// details and the history of its status changes.
+type ShowHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewShowHandler creates a new ShowHandler.
+func NewShowHandler(svc *service.Service, logger *logrus.Logger) *ShowHandler {
+	return &ShowHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /show command.
+func (h *ShowHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	todo, err := loadChatTodo(ctx, bot, h.svc, h.logger, lang, message, args)
 	if err != nil || todo == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Todo *#%d* not found.", todoID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+		return err
 	}
 
-	// Validate that the todo belongs to this chat
-	if todo.ChatID != message.Chat.ID {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Todo *#%d* not found in this chat.", todoID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+	events, err := h.svc.TodoHistory(ctx, todo.ID)
+	if err != nil {
+		return fmt.Errorf("todo history: %w", err)
 	}
 
-	// Validate ownership: only creator or assignee can mark as done
-	isOwner := todo.CreatedByID == user.ID
-	isAssignee := todo.AssignedToID != nil && *todo.AssignedToID == user.ID
-	if !isOwner && !isAssignee {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ You can only complete todos you created or that are assigned to you.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s *#%d* %s\n", priorityEmoji(todo.Priority), todo.ID, markup.Escape(todo.Title)))
+	if todo.Description != "" {
+		sb.WriteString(fmt.Sprintf("\n_%s_\n", markup.Escape(todo.Description)))
+	}
+	sb.WriteString("\n" + i18n.T(lang, "show.status", todoStatusEmoji(todo.Status), todoStatusName(lang, todo.Status)))
+	sb.WriteString("\n" + i18n.T(lang, "show.priority", i18n.T(lang, "todo.priority."+string(todo.Priority))))
+	if len(todo.Tags) > 0 {
+		sb.WriteString("\n🏷" + formatTags(todo.Tags))
+	}
+	if todo.Deadline != nil {
+		sb.WriteString("\n" + i18n.T(lang, "show.deadline", todo.Deadline.Format("2006-01-02 15:04")))
+		if todo.IsOverdue() {
+			sb.WriteString(" ⚠️")
+		}
+	}
+	if creator, _ := h.svc.Users.GetByID(ctx, todo.CreatedByID); creator != nil {
+		sb.WriteString("\n" + i18n.T(lang, "show.created", markup.Escape(creator.DisplayName()), todo.CreatedAt.Format("2006-01-02 15:04")))
+	}
+	if todo.AssignedToID != nil {
+		if assignee, _ := h.svc.Users.GetByID(ctx, *todo.AssignedToID); assignee != nil {
+			sb.WriteString("\n" + i18n.T(lang, "show.assigned", markup.Escape(assignee.DisplayName())))
+		}
 	}
 
-	if todo.IsCompleted() {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("ℹ️ Todo *#%d* is already completed.", todoID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+	items, err := h.svc.Todos.GetChecklist(ctx, todo.ID)
+	if err != nil {
+		return fmt.Errorf("get checklist: %w", err)
+	}
+	if len(items) > 0 {
+		sb.WriteString("\n\n" + i18n.T(lang, "show.checklist", checklistProgress(todo)))
+		sb.WriteString(formatChecklist(todo.ID, items))
+		if todo.AutoComplete {
+			sb.WriteString("\n_" + i18n.T(lang, "show.autocomplete") + "_")
+		}
 	}
 
-	todo.Status = models.TodoStatusCompleted
-	if _, err = h.svc.Todos.Update(ctx, todo); err != nil {
-		return fmt.Errorf("complete todo: %w", err)
+	if len(events) > 0 {
+		sb.WriteString("\n\n" + i18n.T(lang, "show.history"))
+		for _, e := range events {
+			who := i18n.T(lang, "common.someone")
+			if e.User != nil {
+				who = markup.Escape(e.User.DisplayName())
+			}
+			sb.WriteString(fmt.Sprintf("\n• %s — %s: %s → %s",
+				e.CreatedAt.Format("2006-01-02 15:04"), who, todoStatusName(lang, e.FromStatus), todoStatusName(lang, e.ToStatus)))
+		}
 	}
 
-	text := fmt.Sprintf("🎉 Todo *#%d* completed!\n\n~%s~", todo.ID, todo.Title)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
-		"user_id": message.From.ID,
 		"todo_id": todo.ID,
-	}).Info("Todo completed")
+	}).Info("Showed todo")
 
 	return nil
 }
@@ -265,7 +601,7 @@ func (h *DoneHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, ar
 // ---------------------------------------------------------------------------
 
 // DeleteHandler handles the /delete command to remove a todo.
-// Only the creator of the todo is allowed to delete it.
+// Only the creator of the todo or a family admin is allowed to delete it.
 type DeleteHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -278,24 +614,23 @@ func NewDeleteHandler(svc *service.Service, logger *logrus.Logger) *DeleteHandle
 
 // Handle processes the /delete command.
 func (h *DeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a todo ID.\nUsage: `/delete 5`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.id_missing", "delete"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	todoID, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid ID. Please provide a numeric todo ID.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
 
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
@@ -304,27 +639,24 @@ func (h *DeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 
 	todo, err := h.svc.Todos.GetByID(ctx, todoID)
 	if err != nil || todo == nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Todo *#%d* not found.", todoID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.not_found", todoID))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	if todo.ChatID != message.Chat.ID {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Todo *#%d* not found in this chat.", todoID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	if same, _ := h.svc.SameFamily(ctx, todo.ChatID, chatID); !same {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.not_in_chat", todoID))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	// Only the creator can delete a todo
-	if todo.CreatedByID != user.ID {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ You can only delete todos you created.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	// Only the creator or a family admin can delete a todo
+	if err := authorize(ctx, bot, h.svc, message, user.ID, todo.CreatedByID); err != nil {
+		if !errors.Is(err, service.ErrForbidden) {
+			return fmt.Errorf("authorize: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "todo.delete_forbidden"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
@@ -332,10 +664,9 @@ func (h *DeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		return fmt.Errorf("delete todo: %w", err)
 	}
 
-	text := fmt.Sprintf("🗑 Todo *#%d* deleted: %s", todo.ID, todo.Title)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	text := i18n.T(lang, "todo.deleted", todo.ID, markup.Escape(todo.Title))
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
@@ -346,6 +677,158 @@ func (h *DeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 	return nil
 }
 
+// ---------------------------------------------------------------------------
+// DeadlineHandler – /deadline <id> [<YYYY-MM-DD> [HH:MM]|off]
+// ---------------------------------------------------------------------------
+
+// DeadlineHandler handles the /deadline command, which sets or removes the
+// deadline of a todo. Without a date it offers a date picker. A deadline
+// without a time is due at the end of the day.
+type DeadlineHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewDeadlineHandler creates a new DeadlineHandler.
+func NewDeadlineHandler(svc *service.Service, logger *logrus.Logger) *DeadlineHandler {
+	return &DeadlineHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /deadline command.
+func (h *DeadlineHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	todo, err := loadChatTodo(ctx, bot, h.svc, h.logger, lang, message, args)
+	if err != nil || todo == nil {
+		return err
+	}
+
+	switch {
+	case len(args) == 1:
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "deadline.pick", todo.ID, markup.Escape(todo.Title)))
+		msg.ReplyMarkup = deadlinePicker(lang, todo.ID).Keyboard(time.Now())
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	case strings.EqualFold(args[1], "off"):
+		return h.set(ctx, bot, message, lang, todo, nil)
+	}
+
+	var timeStr string
+	if len(args) > 2 {
+		timeStr = args[2]
+	}
+	deadline, allDay, err := parseEventStart(args[1], timeStr)
+	if err != nil || !calDateRegex.MatchString(args[1]) || (timeStr != "" && !calTimeRegex.MatchString(timeStr)) {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "deadline.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	if allDay {
+		deadline = endOfDay(deadline)
+	}
+	return h.set(ctx, bot, message, lang, todo, &deadline)
+}
+
+// HandleCallback processes a press on the deadline picker. The data has the
+// form "<todo id>:<picker data>", or "<todo id>:off" to remove the deadline.
+func (h *DeadlineHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
+	}
+	idPart, pick, _ := strings.Cut(data, ":")
+
+	ctx := context.Background()
+	message := pageCallbackMessage(query)
+	lang := messageLang(ctx, h.svc, message)
+
+	var deadline *time.Time
+	if pick != "off" {
+		todoID, err := strconv.ParseInt(idPart, 10, 64)
+		if err != nil {
+			return nil
+		}
+		next, picked, withTime := deadlinePicker(lang, todoID).Update(pick)
+		if next != nil {
+			telegram.Send(bot, h.logger, tgbotapi.NewEditMessageReplyMarkup(message.Chat.ID, message.MessageID, *next))
+			return nil
+		}
+		if picked.IsZero() {
+			return nil
+		}
+		if !withTime {
+			picked = endOfDay(picked)
+		}
+		deadline = &picked
+	}
+
+	todo, err := loadChatTodo(ctx, bot, h.svc, h.logger, lang, message, []string{idPart})
+	if err != nil || todo == nil {
+		return err
+	}
+	clearKeyboard(bot, h.logger, message)
+	return h.set(ctx, bot, message, lang, todo, deadline)
+}
+
+// set changes the todo's deadline on behalf of the message's author and
+// reports back.
+func (h *DeadlineHandler) set(ctx context.Context, bot *tgbotapi.BotAPI, message *tgbotapi.Message, lang string,
+	todo *models.Todo, deadline *time.Time) error {
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	err = h.svc.SetTodoDeadline(ctx, todo, user.ID, deadline)
+	if errors.Is(err, service.ErrForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		err = h.svc.SetTodoDeadline(ctx, todo, user.ID, deadline)
+	}
+
+	var text string
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		text = i18n.T(lang, "todo.change_forbidden")
+	case err != nil:
+		return fmt.Errorf("set deadline: %w", err)
+	case deadline == nil:
+		text = i18n.T(lang, "deadline.removed", todo.ID, markup.Escape(todo.Title))
+	default:
+		text = i18n.T(lang, "deadline.set", todo.ID, markup.Escape(todo.Title), eventWhen(lang, *deadline, false))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	if err == nil {
+		h.logger.WithFields(logrus.Fields{
+			"chat_id": message.Chat.ID,
+			"user_id": message.From.ID,
+			"todo_id": todo.ID,
+		}).Info("Todo deadline changed")
+	}
+	return nil
+}
+
+// deadlinePicker is the date picker for a todo's deadline.
+func deadlinePicker(lang string, todoID int64) telegram.DatePicker {
+	now := time.Now()
+	return telegram.DatePicker{
+		Prefix: fmt.Sprintf("deadline:%d:", todoID),
+		Lang:   lang,
+		Time:   true,
+		NoTime: i18n.T(lang, "deadline.end_of_day"),
+		From:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		Footer: tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(i18n.T(lang, "deadline.off_button"), fmt.Sprintf("deadline:%d:off", todoID)),
+		),
+	}
+}
+
+// endOfDay returns the last minute of the day.
+func endOfDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 0, 0, day.Location())
+}
+
 // ---------------------------------------------------------------------------
 // MyHandler – /my
 // ---------------------------------------------------------------------------
@@ -365,6 +848,8 @@ func NewMyHandler(svc *service.Service, logger *logrus.Logger) *MyHandler {
 // Handle processes the /my command.
 func (h *MyHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
+	lang := messageLang(ctx, h.svc, message)
 
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
@@ -376,7 +861,7 @@ func (h *MyHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args
 	status := models.TodoStatusPending
 	filters := repository.TodoFilters{Status: &status}
 
-	allTodos, err := h.svc.Todos.GetByChatID(ctx, message.Chat.ID, filters)
+	allTodos, err := h.svc.Todos.GetByChatID(ctx, chatID, filters)
 	if err != nil {
 		return fmt.Errorf("get todos: %w", err)
 	}
@@ -391,32 +876,44 @@ func (h *MyHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args
 	}
 
 	if len(myTodos) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"📌 *You have no pending todos!*\n\nCreate one with `/add <text>`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "my.empty"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
+	ids := make([]int64, len(myTodos))
+	for i, t := range myTodos {
+		ids[i] = t.ID
+	}
+	attachments, err := h.svc.LoadAttachments(ctx, models.AttachmentEntityTodo, ids)
+	if err != nil {
+		return fmt.Errorf("load attachments: %w", err)
+	}
+
 	var sb strings.Builder
-	sb.WriteString("📌 *Your Todos*\n\n")
+	sb.WriteString(i18n.T(lang, "my.heading") + "\n\n")
 
 	for i, t := range myTodos {
-		sb.WriteString(fmt.Sprintf("%d. %s *#%d* %s", i+1, priorityEmoji(t.Priority), t.ID, t.Title))
+		sb.WriteString(fmt.Sprintf("%d. %s *#%d* %s", i+1, priorityEmoji(t.Priority), t.ID, markup.Escape(t.Title)))
 		if t.Deadline != nil {
 			sb.WriteString(fmt.Sprintf("  📅 _%s_", t.Deadline.Format("2006-01-02")))
 		}
+		sb.WriteString(formatTags(t.Tags))
+		sb.WriteString(checklistProgress(t))
+		sb.WriteString(attachmentIndicator(attachments[t.ID]))
+		if t.IsRecurring() {
+			sb.WriteString(" 🔁")
+		}
 		if t.IsOverdue() {
 			sb.WriteString(" ⚠️")
 		}
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("\n_%d items assigned to you_", len(myTodos)))
+	sb.WriteString("\n_" + i18n.N(lang, "my.count", len(myTodos)) + "_")
 
-	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
@@ -2,15 +2,20 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
 
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
 )
 
 // ---------------------------------------------------------------------------
@@ -32,27 +37,22 @@ func NewWishAddHandler(svc *service.Service, logger *logrus.Logger) *WishAddHand
 
 // Handle processes the /wish command.
 func (h *WishAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a wish item.\n"+
-				"Usage: `/wish PlayStation 5`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wish.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	ctx := context.Background()
-
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	chatTitle := message.Chat.Title
-	if chatTitle == "" {
-		chatTitle = message.From.FirstName + "'s family"
-	}
-	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, chatTitle)
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
 	if err != nil {
 		return fmt.Errorf("ensure family: %w", err)
 	}
@@ -67,7 +67,7 @@ func (h *WishAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		list = &models.WishList{
 			FamilyID: family.ID,
 			UserID:   user.ID,
-			Name:     user.DisplayName() + "'s Wishes",
+			Name:     i18n.T(lang, "wishlist.owner", user.DisplayName()),
 		}
 		list, err = h.svc.WishList.CreateList(ctx, list)
 		if err != nil {
@@ -75,21 +75,42 @@ func (h *WishAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 		}
 	}
 
-	itemName := strings.Join(args, " ")
+	// A link anywhere in the arguments becomes the item URL; the page is
+	// used to fill in the name and price
+	var itemURL string
+	nameParts := make([]string, 0, len(args))
+	for _, arg := range args {
+		if itemURL == "" && (strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://")) {
+			itemURL = arg
+			continue
+		}
+		nameParts = append(nameParts, arg)
+	}
+
 	item := &models.WishItem{
 		WishListID: list.ID,
-		Name:       itemName,
+		Name:       strings.Join(nameParts, " "),
+		URL:        itemURL,
 	}
+	h.svc.EnrichWish(ctx, item)
 
 	item, err = h.svc.WishList.AddItem(ctx, item)
 	if err != nil {
 		return fmt.Errorf("add wish item: %w", err)
 	}
 
-	text := fmt.Sprintf("🎁 *Added to your wish list!*\n\n*#%d* — %s", item.ID, itemName)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "wish.added", item.ID, markup.Escape(item.Name)))
+	if item.Price != "" {
+		sb.WriteString(fmt.Sprintf("\n💰 %s", markup.Escape(item.Price)))
+	}
+	if item.URL != "" {
+		sb.WriteString(fmt.Sprintf("\n🔗 [%s](%s)", i18n.T(lang, "wish.link"), markup.Escape(item.URL)))
+		sb.WriteString("\n\n" + i18n.T(lang, "wish.fix_hint", item.ID))
+	}
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	msg.DisableWebPagePreview = true
+	telegram.Send(bot, h.logger, msg)
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
@@ -107,10 +128,14 @@ func (h *WishAddHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message,
 // WishListHandler handles the /wishlist command.
 //
 // Without arguments it shows all family wish lists. When a @username is
-// provided it shows that specific user's wish list.
+// provided it shows that specific user's wish list. Wishes are ranked by
+// priority, or ordered with sort:created or sort:name.
 //
-// Reservation status (the lock icon) is hidden from the list owner so that
-// surprises are not spoiled; other viewers see which items are reserved.
+// Reservation status is hidden from the list owner so that surprises are
+// not spoiled; other viewers see which items are reserved and by whom. An
+// owner who enabled /wishhint only learns that something was reserved. In
+// group chats the owners may be reading along, so reservations are only
+// shown in private chats.
 type WishListHandler struct {
 	svc    *service.Service
 	logger *logrus.Logger
@@ -125,18 +150,57 @@ func NewWishListHandler(svc *service.Service, logger *logrus.Logger) *WishListHa
 func (h *WishListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
 
-	currentUser, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	out, err := h.output(ctx, message, args)
 	if err != nil {
-		return fmt.Errorf("ensure user: %w", err)
+		return err
+	}
+	if err := sendPaged(bot, message.Chat.ID, out, "wishlist", args); err != nil {
+		return err
 	}
 
-	chatTitle := message.Chat.Title
-	if chatTitle == "" {
-		chatTitle = message.From.FirstName + "'s family"
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"args":    args,
+	}).Info("Listed wish lists")
+
+	return nil
+}
+
+// HandleCallback processes a press on a page button of /wishlist.
+func (h *WishListHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil {
+		return nil
 	}
-	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, chatTitle)
+	page, args, err := parsePageCallback(data)
 	if err != nil {
-		return fmt.Errorf("ensure family: %w", err)
+		return err
+	}
+
+	message := pageCallbackMessage(query)
+	out, err := h.output(context.Background(), message, args)
+	if err != nil {
+		return err
+	}
+	return editPaged(bot, message, out, page, "wishlist", args)
+}
+
+// output renders the wish lists /wishlist shows for args.
+func (h *WishListHandler) output(ctx context.Context, message *tgbotapi.Message, args []string) (pagedOutput, error) {
+	currentUser, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return pagedOutput{}, fmt.Errorf("ensure user: %w", err)
+	}
+
+	lang := messageLang(ctx, h.svc, message)
+	chatID, chatTitle := workspaceChat(ctx, h.svc, message, i18n.T(lang, "family.private_title", message.From.FirstName))
+	family, err := h.svc.EnsureFamily(ctx, chatID, chatTitle)
+	if err != nil {
+		return pagedOutput{}, fmt.Errorf("ensure family: %w", err)
+	}
+
+	sort, args, problem := splitSort(lang, args, "priority", "priority", "created", "name")
+	if problem != "" {
+		return pagedOutput{header: problem}, nil
 	}
 
 	// If a @username is specified, show that user's wish list
@@ -144,161 +208,208 @@ func (h *WishListHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message
 		username := strings.TrimPrefix(args[0], "@")
 		targetUser, lookupErr := h.svc.Users.GetByUsername(ctx, username)
 		if lookupErr != nil || targetUser == nil {
-			msg := tgbotapi.NewMessage(message.Chat.ID,
-				fmt.Sprintf("❌ User @%s not found.", username))
-			msg.ParseMode = tgbotapi.ModeMarkdown
-			bot.Send(msg)
-			return nil
+			return pagedOutput{header: i18n.T(lang, "user.not_found", markup.Escape(username))}, nil
 		}
-		return h.showUserWishList(bot, message.Chat.ID, currentUser, targetUser, family.ID)
+		return h.userWishList(ctx, lang, message.Chat.IsPrivate(), currentUser, targetUser, family.ID, sort)
 	}
 
 	// No @user argument — show all family wish lists
 	lists, err := h.svc.WishList.GetListsByFamily(ctx, family.ID)
 	if err != nil {
-		return fmt.Errorf("get wish lists: %w", err)
+		return pagedOutput{}, fmt.Errorf("get wish lists: %w", err)
 	}
 
 	if len(lists) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"🎁 *No wish lists yet!*\n\nAdd wishes with `/wish <item>`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+		return pagedOutput{header: i18n.T(lang, "wishlist.none")}, nil
 	}
 
-	var sb strings.Builder
-	sb.WriteString("🎁 *Family Wish Lists*\n\n")
+	out := pagedOutput{
+		header: i18n.T(lang, "wishlist.family_heading") + "\n\n",
+		footer: i18n.T(lang, "wishlist.family_footer"),
+		lang:   lang,
+	}
 
 	for _, list := range lists {
 		items, itemErr := h.svc.WishList.GetItems(ctx, list.ID)
 		if itemErr != nil {
 			continue
 		}
+		sortWishes(items, sort)
 
-		isOwnList := list.UserID == currentUser.ID
+		hideReservations(message.Chat.IsPrivate(), list, items, currentUser.ID)
 		ownerName := list.Name
 		if list.User != nil {
-			ownerName = list.User.DisplayName() + "'s Wishes"
+			ownerName = i18n.T(lang, "wishlist.owner", list.User.DisplayName())
 		}
 
-		sb.WriteString(fmt.Sprintf("*%s* (%d items)\n", ownerName, len(items)))
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("*%s* (%s)\n", markup.Escape(ownerName), i18n.N(lang, "common.items", len(items))))
 		for _, item := range items {
-			sb.WriteString(fmt.Sprintf("  *#%d* %s", item.ID, item.Name))
+			sb.WriteString(fmt.Sprintf("  *#%d* %s%s", item.ID, markup.Escape(item.Name), wishPriorityLabel(lang, item.Priority)))
 			if item.Price != "" {
-				sb.WriteString(fmt.Sprintf(" — _%s_", item.Price))
-			}
-			// Show reservation status only to non-owners
-			if !isOwnList && item.Reserved {
-				sb.WriteString(" 🔒")
+				sb.WriteString(fmt.Sprintf(" — _%s_", markup.Escape(item.Price)))
 			}
+			sb.WriteString(reservationLabel(lang, item, currentUser.ID))
 			sb.WriteString("\n")
+			sb.WriteString(pledgeProgress(lang, item, "    "))
 		}
 		if len(items) == 0 {
-			sb.WriteString("  _(empty)_\n")
+			sb.WriteString("  _(" + i18n.T(lang, "wishlist.empty_mark") + ")_\n")
+		}
+		if list.HasReserved != nil && *list.HasReserved {
+			sb.WriteString("  ✨ _" + i18n.T(lang, "wishlist.reserved_hint") + "_\n")
 		}
-		sb.WriteString("\n")
+		out.entries = append(out.entries, sb.String())
+	}
+	if !message.Chat.IsPrivate() {
+		out.footer += "\n" + i18n.T(lang, "wishlist.private_hint")
 	}
 
-	sb.WriteString("_View a specific list with_ `/wishlist @username`")
-
-	msg := tgbotapi.NewMessage(message.Chat.ID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	msg.DisableWebPagePreview = true
-	bot.Send(msg)
-
-	h.logger.WithFields(logrus.Fields{
-		"chat_id":    message.Chat.ID,
-		"list_count": len(lists),
-	}).Info("Listed family wish lists")
-
-	return nil
+	return out, nil
 }
 
-// showUserWishList renders a single user's wish list. Reservation indicators
-// are hidden when the viewer is the list owner.
-func (h *WishListHandler) showUserWishList(
-	bot *tgbotapi.BotAPI,
-	chatID int64,
+// userWishList renders a single user's wish list. Reservation indicators
+// are hidden when the viewer is the list owner or the chat is not private.
+func (h *WishListHandler) userWishList(
+	ctx context.Context,
+	lang string,
+	private bool,
 	viewer *models.User,
 	owner *models.User,
 	familyID int64,
-) error {
-	ctx := context.Background()
+	sort string,
+) (pagedOutput, error) {
 	isOwnList := viewer.ID == owner.ID
 
 	list, err := h.svc.WishList.GetListByUser(ctx, owner.ID, familyID)
 	if err != nil || list == nil {
-		var emptyText string
 		if isOwnList {
-			emptyText = "🎁 *You don't have a wish list yet.*\n\nCreate one with `/wish <item>`"
-		} else {
-			emptyText = fmt.Sprintf("🎁 *%s doesn't have a wish list yet.*", owner.DisplayName())
+			return pagedOutput{header: i18n.T(lang, "wishlist.own_none")}, nil
 		}
-		msg := tgbotapi.NewMessage(chatID, emptyText)
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+		return pagedOutput{header: i18n.T(lang, "wishlist.other_none", markup.Escape(owner.DisplayName()))}, nil
 	}
 
 	items, err := h.svc.WishList.GetItems(ctx, list.ID)
 	if err != nil {
-		return fmt.Errorf("get wish items: %w", err)
+		return pagedOutput{}, fmt.Errorf("get wish items: %w", err)
 	}
+	hideReservations(private, list, items, viewer.ID)
+	sortWishes(items, sort)
 
 	if len(items) == 0 {
-		var emptyMsg string
 		if isOwnList {
-			emptyMsg = "🎁 *Your wish list is empty!*\n\nAdd items with `/wish <item>`"
-		} else {
-			emptyMsg = fmt.Sprintf("🎁 *%s's wish list is empty.*", owner.DisplayName())
+			return pagedOutput{header: i18n.T(lang, "wishlist.own_empty")}, nil
 		}
-		msg := tgbotapi.NewMessage(chatID, emptyMsg)
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
-		return nil
+		return pagedOutput{header: i18n.T(lang, "wishlist.other_empty", markup.Escape(owner.DisplayName()))}, nil
 	}
 
-	var sb strings.Builder
+	out := pagedOutput{header: i18n.T(lang, "wishlist.other_heading", markup.Escape(owner.DisplayName())) + "\n\n", lang: lang}
 	if isOwnList {
-		sb.WriteString("🎁 *Your Wish List*\n\n")
-	} else {
-		sb.WriteString(fmt.Sprintf("🎁 *%s's Wish List*\n\n", owner.DisplayName()))
+		out.header = i18n.T(lang, "wishlist.own_heading") + "\n\n"
 	}
 
 	for i, item := range items {
-		sb.WriteString(fmt.Sprintf("%d. *#%d* %s", i+1, item.ID, item.Name))
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%d. *#%d* %s%s", i+1, item.ID, markup.Escape(item.Name), wishPriorityLabel(lang, item.Priority)))
 		if item.URL != "" {
-			sb.WriteString(fmt.Sprintf(" ([link](%s))", item.URL))
+			sb.WriteString(fmt.Sprintf(" ([%s](%s))", i18n.T(lang, "wish.link"), markup.Escape(item.URL)))
 		}
 		if item.Price != "" {
-			sb.WriteString(fmt.Sprintf(" — _%s_", item.Price))
+			sb.WriteString(fmt.Sprintf(" — _%s_", markup.Escape(item.Price)))
 		}
-		// Show reservation status only to non-owners
-		if !isOwnList && item.Reserved {
-			sb.WriteString(" 🔒")
+		sb.WriteString(reservationLabel(lang, item, viewer.ID))
+		if progress := pledgeProgress(lang, item, "    "); progress != "" {
+			sb.WriteString("\n" + strings.TrimSuffix(progress, "\n"))
 		}
-		sb.WriteString("\n")
+		out.entries = append(out.entries, sb.String())
 	}
 
-	sb.WriteString(fmt.Sprintf("\n_%d items_", len(items)))
+	out.footer = "\n_" + i18n.N(lang, "common.items", len(items)) + "_"
+	if list.HasReserved != nil && *list.HasReserved {
+		out.footer += "\n✨ _" + i18n.T(lang, "wishlist.own_reserved_hint") + "_"
+	}
 	if !isOwnList {
-		sb.WriteString("\n\n_Use_ `/reserve <id>` _to reserve a gift_")
+		out.footer += "\n\n" + i18n.T(lang, "wishlist.reserve_hint")
 	}
+	if !private && !isOwnList {
+		out.footer += "\n" + i18n.T(lang, "wishlist.private_hint")
+	}
+	return out, nil
+}
 
-	msg := tgbotapi.NewMessage(chatID, sb.String())
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	msg.DisableWebPagePreview = true
-	bot.Send(msg)
+// hideReservations strips what the viewer must not see from a wish list.
+// Outside private chats everyone in the chat sees the reply, the list's
+// owner possibly among them, so reservations are stripped for all viewers.
+func hideReservations(private bool, list *models.WishList, items []*models.WishItem, viewerID int64) {
+	if !private {
+		viewerID = list.UserID
+	}
+	service.HideReservations(list, items, viewerID)
+}
 
-	h.logger.WithFields(logrus.Fields{
-		"chat_id":     chatID,
-		"target_user": owner.ID,
-		"own_list":    isOwnList,
-		"count":       len(items),
-	}).Info("Listed user wish list")
+// sortWishes orders wish items for /wishlist: by priority as stored, by
+// when they were added, or by name.
+func sortWishes(items []*models.WishItem, sort string) {
+	switch sort {
+	case "created":
+		slices.SortStableFunc(items, func(a, b *models.WishItem) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+	case "name":
+		slices.SortStableFunc(items, func(a, b *models.WishItem) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+}
 
-	return nil
+// wishPriorityLabel marks ranked wishes, the top one as most wanted.
+func wishPriorityLabel(lang string, priority int) string {
+	switch {
+	case priority == 1:
+		return " 🔥 _" + i18n.T(lang, "wish.most_wanted") + "_"
+	case priority > 1:
+		return fmt.Sprintf(" ⭐%d", priority)
+	default:
+		return ""
+	}
+}
+
+// reservationLabel describes who reserved an item. Items of the viewer's own
+// list have already been stripped by service.HideReservations.
+func reservationLabel(lang string, item *models.WishItem, viewerID int64) string {
+	if !item.Reserved {
+		return ""
+	}
+	if item.Purchased {
+		return " 🎉 _" + i18n.T(lang, "wish.purchased") + "_"
+	}
+	if item.ReservedByID != nil && *item.ReservedByID == viewerID {
+		return " 🔒 _" + i18n.T(lang, "wish.by_you") + "_"
+	}
+	if item.ReservedBy != nil {
+		return " 🔒 _" + i18n.T(lang, "wish.by", markup.Escape(item.ReservedBy.DisplayName())) + "_"
+	}
+	return " 🔒"
+}
+
+// replyPrivately keeps reservation details away from the wish list owner.
+// In group chats the command message is removed (when the bot may delete
+// messages) and the reply goes to the sender's private chat; if the sender
+// never started the bot, the spoiler-free fallback is posted instead.
+func replyPrivately(bot *tgbotapi.BotAPI, logger *logrus.Logger, message *tgbotapi.Message, text, fallback string) {
+	if message.Chat.IsPrivate() {
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, logger, msg)
+		return
+	}
+
+	bot.Request(tgbotapi.NewDeleteMessage(message.Chat.ID, message.MessageID))
+
+	msg := markup.NewMessage(message.From.ID, text)
+	if _, err := bot.Send(msg); err != nil {
+		msg = markup.NewMessage(message.Chat.ID, fallback)
+		telegram.Send(bot, logger, msg)
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -320,50 +431,405 @@ func NewWishReserveHandler(svc *service.Service, logger *logrus.Logger) *WishRes
 
 // Handle processes the /reserve command.
 func (h *WishReserveHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
 	if len(args) == 0 {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Please provide a wish item ID.\n\n"+
-				"Usage: `/reserve 5`\n\n"+
-				"_View someone's wish list first with_ `/wishlist @username`")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "reserve.usage"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
 	itemID, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			"❌ Invalid ID. Please provide a numeric item ID.")
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	if err = h.svc.ReserveWish(ctx, itemID, user.ID); err != nil {
+		text := i18n.T(lang, "reserve.failed", itemID)
+		if errors.Is(err, service.ErrWishForbidden) {
+			text = i18n.T(lang, "reserve.own")
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	replyPrivately(bot, h.logger, message, i18n.T(lang, "reserve.done", itemID), i18n.T(lang, "reserve.done_public"))
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"item_id": itemID,
+	}).Info("Wish item reserved")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// WishHintHandler – /wishhint on|off
+// ---------------------------------------------------------------------------
+
+// WishHintHandler handles the /wishhint command. It lets wish list owners
+// opt in to a spoiler-free indicator that something on their list has been
+// reserved, without revealing which item or by whom.
+type WishHintHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewWishHintHandler creates a new WishHintHandler.
+func NewWishHintHandler(svc *service.Service, logger *logrus.Logger) *WishHintHandler {
+	return &WishHintHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /wishhint command.
+func (h *WishHintHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
 	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 || (args[0] != "on" && args[0] != "off") {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wishhint.usage"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+	enabled := args[0] == "on"
+
+	chatID, _ := workspaceChat(ctx, h.svc, message, "")
 
 	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
 	if err != nil {
 		return fmt.Errorf("ensure user: %w", err)
 	}
 
-	if err = h.svc.WishList.ReserveItem(ctx, itemID, user.ID); err != nil {
-		msg := tgbotapi.NewMessage(message.Chat.ID,
-			fmt.Sprintf("❌ Could not reserve item *#%d*.\nIt may not exist or is already reserved.", itemID))
-		msg.ParseMode = tgbotapi.ModeMarkdown
-		bot.Send(msg)
+	family, err := h.svc.Families.GetByChatID(ctx, chatID)
+	if err != nil || family == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wishlist.own_none"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	list, err := h.svc.WishList.GetListByUser(ctx, user.ID, family.ID)
+	if err != nil {
+		return fmt.Errorf("get wish list: %w", err)
+	}
+	if list == nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wishlist.own_none"))
+		telegram.Send(bot, h.logger, msg)
 		return nil
 	}
 
-	text := fmt.Sprintf("🔒 Item *#%d* reserved!\n\n_The owner won't see who reserved it._", itemID)
-	msg := tgbotapi.NewMessage(message.Chat.ID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
-	bot.Send(msg)
+	if err = h.svc.WishList.SetReservedHint(ctx, list.ID, enabled); err != nil {
+		return fmt.Errorf("set reserved hint: %w", err)
+	}
+
+	text := i18n.T(lang, "wishhint.on")
+	if !enabled {
+		text = i18n.T(lang, "wishhint.off")
+	}
+	msg := markup.NewMessage(message.Chat.ID, text)
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"enabled": enabled,
+	}).Info("Updated wish list reservation hint")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// WishUnreserveHandler – /unreserve <id>
+// ---------------------------------------------------------------------------
+
+// WishUnreserveHandler handles the /unreserve command. Only the member who
+// reserved an item can release it.
+type WishUnreserveHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewWishUnreserveHandler creates a new WishUnreserveHandler.
+func NewWishUnreserveHandler(svc *service.Service, logger *logrus.Logger) *WishUnreserveHandler {
+	return &WishUnreserveHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /unreserve command.
+func (h *WishUnreserveHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wish.id_missing", "unreserve"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	itemID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	if err = h.svc.UnreserveWish(ctx, itemID, user.ID); err != nil {
+		var text string
+		switch {
+		case errors.Is(err, service.ErrWishNotFound):
+			text = i18n.T(lang, "wish.not_found", itemID)
+		case errors.Is(err, service.ErrWishForbidden):
+			text = i18n.T(lang, "unreserve.not_yours", itemID)
+		case errors.Is(err, service.ErrWishHasPledges):
+			text = i18n.T(lang, "unreserve.pledged")
+		case errors.Is(err, service.ErrWishPurchased):
+			text = i18n.T(lang, "wish.purchased_already", itemID)
+		default:
+			return fmt.Errorf("unreserve wish item: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	replyPrivately(bot, h.logger, message, i18n.T(lang, "unreserve.done", itemID), i18n.T(lang, "unreserve.done_public"))
 
 	h.logger.WithFields(logrus.Fields{
 		"chat_id": message.Chat.ID,
 		"user_id": message.From.ID,
 		"item_id": itemID,
-	}).Info("Wish item reserved")
+	}).Info("Wish item unreserved")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// WishDeleteHandler – /delwish <id>
+// ---------------------------------------------------------------------------
+
+// WishDeleteHandler handles the /delwish command. Only the owner of the
+// wish list can delete its items.
+type WishDeleteHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewWishDeleteHandler creates a new WishDeleteHandler.
+func NewWishDeleteHandler(svc *service.Service, logger *logrus.Logger) *WishDeleteHandler {
+	return &WishDeleteHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /delwish command.
+func (h *WishDeleteHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if len(args) == 0 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "wish.id_missing", "delwish"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	itemID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	err = h.svc.DeleteWish(ctx, itemID, user.ID)
+	if errors.Is(err, service.ErrWishForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		err = h.svc.DeleteWish(ctx, itemID, user.ID)
+	}
+	if err != nil {
+		var text string
+		switch {
+		case errors.Is(err, service.ErrWishNotFound):
+			text = i18n.T(lang, "wish.not_found", itemID)
+		case errors.Is(err, service.ErrWishForbidden):
+			text = i18n.T(lang, "delwish.forbidden")
+		default:
+			return fmt.Errorf("delete wish item: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "delwish.done", itemID))
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"item_id": itemID,
+	}).Info("Wish item deleted")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// WishEditHandler – /editwish <id> key:value...
+// ---------------------------------------------------------------------------
+
+// wishEditKeys are the fields that /editwish accepts.
+var wishEditKeys = []string{"name", "price", "url", "notes", "priority"}
+
+// parseWishEdit parses "key:value" arguments into an update. A value runs
+// until the next recognized key, so names and notes may contain spaces. An
+// empty value clears the field. Errors are messages in lang.
+func parseWishEdit(lang string, args []string) (service.WishUpdate, error) {
+	values := make(map[string]string)
+	var current string
+
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, ":")
+		key = strings.ToLower(key)
+		if found && slices.Contains(wishEditKeys, key) {
+			current = key
+			values[current] = value
+			continue
+		}
+		if current == "" {
+			return service.WishUpdate{}, errors.New(i18n.T(lang, "editwish.unexpected", markup.Escape(arg)))
+		}
+		values[current] = strings.TrimSpace(values[current] + " " + arg)
+	}
+
+	var upd service.WishUpdate
+	for key, value := range values {
+		switch key {
+		case "name":
+			if value == "" {
+				return upd, errors.New(i18n.T(lang, "editwish.name_empty"))
+			}
+			upd.Name = &value
+		case "price":
+			upd.Price = &value
+		case "url":
+			if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+				return upd, errors.New(i18n.T(lang, "editwish.url_invalid"))
+			}
+			upd.URL = &value
+		case "notes":
+			upd.Notes = &value
+		case "priority":
+			p := 0
+			if value != "" {
+				v, err := strconv.Atoi(value)
+				if err != nil || v < 0 || v > 99 {
+					return upd, errors.New(i18n.T(lang, "editwish.priority_invalid"))
+				}
+				p = v
+			}
+			upd.Priority = &p
+		}
+	}
+
+	return upd, nil
+}
+
+// WishEditHandler handles the /editwish command. Only the owner of the wish
+// list can edit its items.
+type WishEditHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewWishEditHandler creates a new WishEditHandler.
+func NewWishEditHandler(svc *service.Service, logger *logrus.Logger) *WishEditHandler {
+	return &WishEditHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /editwish command.
+func (h *WishEditHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+	usage := i18n.T(lang, "editwish.usage")
+
+	if len(args) < 2 {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "editwish.missing")+"\n\n"+usage)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	itemID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID, i18n.T(lang, "buy.id_invalid"))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	upd, err := parseWishEdit(lang, args[1:])
+	if err != nil {
+		msg := markup.NewMessage(message.Chat.ID,
+			fmt.Sprintf("❌ %s\n\n%s", err, usage))
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	item, err := h.svc.UpdateWish(ctx, itemID, user.ID, upd)
+	if errors.Is(err, service.ErrWishForbidden) && syncChatAdmin(ctx, bot, h.svc, message, user.ID) {
+		item, err = h.svc.UpdateWish(ctx, itemID, user.ID, upd)
+	}
+	if err != nil {
+		var text string
+		switch {
+		case errors.Is(err, service.ErrWishNotFound):
+			text = i18n.T(lang, "wish.not_found", itemID)
+		case errors.Is(err, service.ErrWishForbidden):
+			text = i18n.T(lang, "editwish.forbidden")
+		default:
+			return fmt.Errorf("update wish item: %w", err)
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "editwish.done", item.ID, markup.Escape(item.Name), wishPriorityLabel(lang, item.Priority)))
+	if item.Price != "" {
+		sb.WriteString(fmt.Sprintf("\n💰 %s", markup.Escape(item.Price)))
+	}
+	if item.URL != "" {
+		sb.WriteString(fmt.Sprintf("\n🔗 [%s](%s)", i18n.T(lang, "wish.link"), markup.Escape(item.URL)))
+	}
+	if item.Notes != "" {
+		sb.WriteString(fmt.Sprintf("\n📝 %s", markup.Escape(item.Notes)))
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, sb.String())
+	msg.DisableWebPagePreview = true
+	telegram.Send(bot, h.logger, msg)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id": message.Chat.ID,
+		"user_id": message.From.ID,
+		"item_id": itemID,
+	}).Info("Wish item updated")
 
 	return nil
 }
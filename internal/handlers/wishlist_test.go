@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+func TestHideReservations(t *testing.T) {
+	const owner, viewer = 1, 2
+
+	tests := []struct {
+		name     string
+		private  bool
+		viewerID int64
+		hidden   bool
+	}{
+		{"owner in private chat", true, owner, true},
+		{"member in private chat", true, viewer, false},
+		{"owner in group", false, owner, true},
+		{"member in group", false, viewer, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservedBy := int64(3)
+			list := &models.WishList{UserID: owner}
+			item := &models.WishItem{
+				Reserved:     true,
+				ReservedByID: &reservedBy,
+				ReservedBy:   &models.User{ID: reservedBy},
+				Pledged:      10,
+				Pledges:      []models.WishPledge{{UserID: reservedBy, Amount: 10}},
+			}
+
+			hideReservations(tt.private, list, []*models.WishItem{item}, tt.viewerID)
+
+			if label := reservationLabel("en", item, tt.viewerID); (label == "") != tt.hidden {
+				t.Errorf("reservationLabel = %q, hidden want %v", label, tt.hidden)
+			}
+			if progress := pledgeProgress("en", item, ""); (progress == "") != tt.hidden {
+				t.Errorf("pledgeProgress = %q, hidden want %v", progress, tt.hidden)
+			}
+		})
+	}
+}
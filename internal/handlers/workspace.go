@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/service"
+	"github.com/Kerhoff/TodoboT/internal/telegram"
+)
+
+// workspaceChat returns the chat whose todos, lists and events a command
+// works on, and the title to name its family after. That is the chat the
+// message was sent in, except in a private chat where the sender picked
+// another family with /family: then it is that family's home chat, and the
+// title is empty so the family keeps its name. fallbackTitle names families
+// of chats without a title.
+func workspaceChat(ctx context.Context, svc *service.Service, message *tgbotapi.Message, fallbackTitle string) (int64, string) {
+	title := message.Chat.Title
+	if title == "" {
+		title = fallbackTitle
+	}
+	if !message.Chat.IsPrivate() {
+		return message.Chat.ID, title
+	}
+
+	user, err := svc.Users.GetByTelegramID(ctx, message.From.ID)
+	if err != nil || user == nil {
+		return message.Chat.ID, title
+	}
+	family, err := svc.SelectedFamily(ctx, user.ID)
+	if err != nil || family == nil || family.ChatID == message.Chat.ID {
+		return message.Chat.ID, title
+	}
+	return family.ChatID, ""
+}
+
+// ---------------------------------------------------------------------------
+// FamilyHandler – /family
+// ---------------------------------------------------------------------------
+
+// FamilyHandler handles the /family command. In a private chat it shows the
+// families the user belongs to as buttons; the one picked is what later
+// commands in the private chat work on, so the family shopping list can be
+// added to without posting in the group.
+type FamilyHandler struct {
+	svc    *service.Service
+	logger *logrus.Logger
+}
+
+// NewFamilyHandler creates a new FamilyHandler.
+func NewFamilyHandler(svc *service.Service, logger *logrus.Logger) *FamilyHandler {
+	return &FamilyHandler{svc: svc, logger: logger}
+}
+
+// Handle processes the /family command.
+func (h *FamilyHandler) Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error {
+	ctx := context.Background()
+	lang := messageLang(ctx, h.svc, message)
+
+	if !message.Chat.IsPrivate() {
+		text := i18n.T(lang, "family.none")
+		if family, _ := h.svc.Families.GetByChatID(ctx, message.Chat.ID); family != nil {
+			text = i18n.T(lang, "family.belongs", markup.Escape(family.Name))
+		}
+		msg := markup.NewMessage(message.Chat.ID, text)
+		telegram.Send(bot, h.logger, msg)
+		return nil
+	}
+
+	user, err := h.svc.EnsureUser(ctx, message.From.ID, message.From.UserName, message.From.FirstName, message.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	family, err := h.svc.EnsureFamily(ctx, message.Chat.ID, i18n.T(lang, "family.private_title", message.From.FirstName))
+	if err != nil {
+		return fmt.Errorf("ensure family: %w", err)
+	}
+	_ = h.svc.EnsureFamilyMember(ctx, family.ID, user.ID)
+
+	text, keyboard, err := h.switcher(ctx, lang, user, message.Chat.ID)
+	if err != nil {
+		return err
+	}
+
+	msg := markup.NewMessage(message.Chat.ID, text)
+	msg.ReplyMarkup = keyboard
+	telegram.Send(bot, h.logger, msg)
+
+	return nil
+}
+
+// HandleCallback processes a press on a family button.
+func (h *FamilyHandler) HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error {
+	if query.Message == nil || !query.Message.Chat.IsPrivate() {
+		return nil
+	}
+
+	familyID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid family callback %q", data)
+	}
+
+	ctx := context.Background()
+	chatID := query.Message.Chat.ID
+
+	user, err := h.svc.EnsureUser(ctx, query.From.ID, query.From.UserName, query.From.FirstName, query.From.LastName)
+	if err != nil {
+		return fmt.Errorf("ensure user: %w", err)
+	}
+
+	// The private chat's own family is the default and is not stored
+	if family, _ := h.svc.Families.GetByID(ctx, familyID); family != nil && family.ChatID == chatID {
+		familyID = 0
+	}
+
+	err = h.svc.SelectFamily(ctx, user.ID, familyID)
+	if err != nil && !errors.Is(err, service.ErrNotFamilyMember) {
+		return fmt.Errorf("select family: %w", err)
+	}
+
+	text, keyboard, err := h.switcher(ctx, messageLang(ctx, h.svc, pageCallbackMessage(query)), user, chatID)
+	if err != nil {
+		return err
+	}
+
+	edit := markup.NewEditMessageTextAndMarkup(chatID, query.Message.MessageID, text, keyboard)
+	telegram.Send(bot, h.logger, edit)
+
+	h.logger.WithFields(logrus.Fields{
+		"chat_id":   chatID,
+		"user_id":   query.From.ID,
+		"family_id": familyID,
+	}).Info("Switched family")
+
+	return nil
+}
+
+// switcher renders the family switcher for the user's private chat.
+func (h *FamilyHandler) switcher(ctx context.Context, lang string, user *models.User, chatID int64) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	families, err := h.svc.Families.GetByUser(ctx, user.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("get families: %w", err)
+	}
+	selected, err := h.svc.SelectedFamily(ctx, user.ID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, fmt.Errorf("get selected family: %w", err)
+	}
+
+	current := i18n.T(lang, "family.private_lists")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, f := range families {
+		label := f.Name
+		if f.ChatID == chatID {
+			label = "👤 " + f.Name
+		}
+		if (selected == nil && f.ChatID == chatID) || (selected != nil && selected.ID == f.ID) {
+			label = "✅ " + label
+			if selected != nil {
+				current = "*" + markup.Escape(f.Name) + "*"
+			}
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("family:%d", f.ID)),
+		))
+	}
+
+	text := i18n.T(lang, "family.switcher", current)
+	return text, tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
@@ -0,0 +1,546 @@
+package i18n
+
+// en is the English catalog. It is the default: every key must be here.
+var en = map[string]string{
+	// Router
+	"router.error":   "❌ An error occurred while processing your command. Please try again.",
+	"router.unknown": "❓ Unknown command. Use /help to see available commands.",
+
+	// /start and /help
+	"start.text": `🎯 *Welcome to TodoboT!*
+
+Your family assistant for tasks, events, shopping, wishes, and reminders.
+
+*Quick Start:*
+• /add Buy groceries - Add a todo
+• /event Birthday 2025-03-15 - Add event
+• /buy Milk x 2 - Add to shopping list
+• /wish New headphones - Add to wish list
+• /remind 2h Take medicine - Set reminder
+
+Type /help for the full command list!`,
+
+	"help.title":             "📚 *TodoboT Help*",
+	"help.section.todos":     "*Todos:*",
+	"help.section.chores":    "*Chores:*",
+	"help.section.calendar":  "*Calendar:*",
+	"help.section.shopping":  "*Shopping List:*",
+	"help.section.wishes":    "*Wish Lists:*",
+	"help.section.occasions": "*Occasions:*",
+	"help.section.reminders": "*Reminders:*",
+	"help.section.language":  "*Language:*",
+	"help.section.capture":   "*Plain messages:*",
+	"help.section.search":    "*Search:*",
+	"help.section.family":    "*Family:*",
+	"help.footer":            "_Family admins and group admins can edit and delete anything._\n\n_Time formats: 10m, 2h, 1d, 15:30, 2025-01-15 15:30_",
+
+	// /help lines of each registered command
+	"help.add":         "• /add <text> [#tag ...] - Add a new todo\n• /add [text] - In reply to a photo, voice note or document: add a todo with it attached",
+	"help.list":        "• /list [#tag ...] [@user] [overdue] [sort:deadline|priority|created] - Show pending todos, optionally filtered",
+	"help.done":        "• /done <id> - Complete a todo",
+	"help.cancel":      "• /cancel <id> - Cancel a todo\n• /cancel - Stop answering my questions",
+	"help.reopen":      "• /reopen <id> - Reopen a completed or cancelled todo",
+	"help.show":        "• /show <id> - Show a todo and its history",
+	"help.sub":         "• /sub <id> <text> - Add a checklist item to a todo\n• /sub <id> auto on|off - Complete the todo when its checklist is done",
+	"help.check":       "• /check <id>.<n> - Tick checklist item n off",
+	"help.delete":      "• /delete <id> - Delete a todo",
+	"help.deadline":    "• /deadline <id> [date [time]|off] - Set a todo's deadline, or pick it from a calendar",
+	"help.my":          "• /my - Show your assigned todos",
+	"help.chore":       "• /chore <daily|weekly|monthly> <title> [@user ...] - Add a recurring todo; mentioned members take turns",
+	"help.rotation":    "• /rotation <id> @user ... - Change who takes turns on a chore",
+	"help.chores":      "• /chores - Who has which chore this week",
+	"help.event":       "• /event <title> <YYYY-MM-DD> [HH:MM] - Add event\n• /event - Add event step by step",
+	"help.events":      "• /events - Show upcoming events",
+	"help.delevent":    "• /delevent <id> - Delete an event",
+	"help.buy":         "• /buy <item> [x qty] [#category] - Add to shopping list\n• /buy [item] - In reply to a photo, voice note or document: add an item with it attached",
+	"help.buylist":     "• /buylist [sort:name] - Show shopping list",
+	"help.bought":      "• /bought <id> [price] - Mark item as bought",
+	"help.buyclear":    "• /buyclear - Move bought items to history",
+	"help.spent":       "• /spent [months] - Show family spending",
+	"help.receipt":     "• /receipt [id] - Attach a receipt photo (send as photo caption)",
+	"help.wish":        "• /wish <item|link> - Add to your wish list",
+	"help.wishlist":    "• /wishlist [@user] [sort:created|name] - View wish lists",
+	"help.reserve":     "• /reserve <id> - Reserve a wish item",
+	"help.unreserve":   "• /unreserve <id> - Release your reservation",
+	"help.editwish":    "• /editwish <id> price:… url:… notes:… priority:… - Edit your wish",
+	"help.delwish":     "• /delwish <id> - Delete your wish",
+	"help.pledge":      "• /pledge <id> <amount> - Chip in for a group gift",
+	"help.unpledge":    "• /unpledge <id> - Withdraw your pledge",
+	"help.purchased":   "• /purchased <id> [amount] - Organizer: mark gift bought and settle up",
+	"help.wishhint":    "• /wishhint on|off - Hint when something on your list is reserved",
+	"help.wishfor":     "• /wishfor <occasion id|off> - Link your wish list to an occasion",
+	"help.birthday":    "• /birthday <date> [@user] [days:N] - Save a birthday",
+	"help.occasion":    "• /occasion <date> <title> [days:N] - Add a yearly holiday",
+	"help.occasions":   "• /occasions - Show upcoming occasions",
+	"help.deloccasion": "• /deloccasion <id> - Delete an occasion",
+	"help.remind":      "• /remind <time> <text> - Set reminder\n• /remind - Set a reminder step by step",
+	"help.reminders":   "• /reminders - Show your reminders",
+	"help.delremind":   "• /delremind <id> - Delete reminder",
+	"help.lang":        "• /lang [en|ru|auto] - Pick the language I talk in (in groups: for the whole family)",
+	"help.capture":     "• /capture on|off - Offer to save \"we need milk and bread\" or \"remind me tomorrow 8am to call grandma\" written without a command",
+	"help.search":      "• /search <words> - Find todos, events, shopping items, wishes and reminders\n• @TodoboT <words> - In any chat: find your families' todos, shopping items and wishes and share them",
+	"help.family":      "• /family - Pick which family your private chat with me works on",
+	"help.promote":     "• /promote @user - Make a member a family admin",
+	"help.demote":      "• /demote @user - Make an admin a regular member",
+	"help.link":        "• /link - Get a code to share this family with another chat",
+	"help.join":        "• /join <code> - Attach this chat to another family",
+
+	// Command menus
+	"command.start":       "Start using the bot",
+	"command.help":        "Show all commands",
+	"command.add":         "Add a todo",
+	"command.list":        "Show pending todos",
+	"command.done":        "Complete a todo",
+	"command.cancel":      "Cancel a todo or stop answering questions",
+	"command.reopen":      "Reopen a todo",
+	"command.show":        "Show a todo and its history",
+	"command.sub":         "Add a checklist item to a todo",
+	"command.check":       "Tick a checklist item off",
+	"command.delete":      "Delete a todo",
+	"command.deadline":    "Set a todo's deadline",
+	"command.my":          "Show your assigned todos",
+	"command.chore":       "Add a recurring chore",
+	"command.rotation":    "Change who takes turns on a chore",
+	"command.chores":      "Who has which chore this week",
+	"command.event":       "Add an event",
+	"command.events":      "Show upcoming events",
+	"command.delevent":    "Delete an event",
+	"command.buy":         "Add to the shopping list",
+	"command.buylist":     "Show the shopping list",
+	"command.bought":      "Mark an item as bought",
+	"command.buyclear":    "Move bought items to history",
+	"command.spent":       "Show family spending",
+	"command.receipt":     "Attach a receipt photo",
+	"command.wish":        "Add to your wish list",
+	"command.wishlist":    "View wish lists",
+	"command.reserve":     "Reserve a wish",
+	"command.unreserve":   "Release your reservation",
+	"command.editwish":    "Edit your wish",
+	"command.delwish":     "Delete your wish",
+	"command.pledge":      "Chip in for a group gift",
+	"command.unpledge":    "Withdraw your pledge",
+	"command.purchased":   "Mark a group gift bought and settle up",
+	"command.wishhint":    "Get hints when your wishes are reserved",
+	"command.wishfor":     "Link your wish list to an occasion",
+	"command.birthday":    "Save a birthday",
+	"command.occasion":    "Add a yearly holiday",
+	"command.occasions":   "Show upcoming occasions",
+	"command.deloccasion": "Delete an occasion",
+	"command.remind":      "Set a reminder",
+	"command.reminders":   "Show your reminders",
+	"command.delremind":   "Delete a reminder",
+	"command.search":      "Find todos, events, shopping items and wishes",
+	"command.lang":        "Pick the language I talk in",
+	"command.capture":     "Save things written without a command",
+	"command.family":      "Pick the family this chat works on",
+	"command.promote":     "Make a member a family admin",
+	"command.demote":      "Make an admin a regular member",
+	"command.link":        "Get a code to share this family",
+	"command.join":        "Attach this chat to another family",
+
+	// /lang
+	"lang.pick":        "🌐 I talk in *%s* here. Pick a language:",
+	"lang.set":         "🌐 From now on I talk in *%s* here.",
+	"lang.auto":        "🌐 I follow everyone's Telegram language again (*%s* for you).",
+	"lang.auto_button": "🔄 Automatic",
+	"lang.unknown":     "❌ I don't speak `%s` yet. Choose one of: %s, or `auto`.",
+	"lang.forbidden":   "❌ Only family admins can change the language of this chat.",
+
+	// Common words
+	"common.anyone":        "anyone",
+	"common.someone":       "someone",
+	"family.private_title": "%s's list",
+	"sort.created":         "newest",
+	"sort.deadline":        "deadline",
+	"sort.priority":        "priority",
+	"sort.name":            "name",
+	"sort.unknown":         "❌ Unknown sort order `%s`. Use one of: %s.",
+
+	// Todos
+	"todo.status.pending":   "pending",
+	"todo.status.completed": "completed",
+	"todo.status.cancelled": "cancelled",
+	"todo.priority.low":     "low",
+	"todo.priority.medium":  "medium",
+	"todo.priority.high":    "high",
+	"todo.id_missing":       "❌ Please provide a todo ID.\nUsage: `/%s 5`",
+	"todo.id_invalid":       "❌ Invalid ID. Please provide a numeric todo ID.",
+	"todo.not_found":        "❌ Todo *#%d* not found.",
+	"todo.not_in_chat":      "❌ Todo *#%d* not found in this chat.",
+	"todo.change_forbidden": "❌ You can only change todos you created or that are assigned to you.",
+	"todo.already":          "ℹ️ Todo *#%d* is already %s.",
+	"todo.not_pending":      "❌ Todo *#%d* is %s. Only pending todos can be completed or cancelled; use `/reopen %d` first.",
+	"todo.completed_next":   "🎉 Todo *#%d* completed!\n\n~%s~\n\n🔁 Next up: *#%d* for %s, due %s",
+	"todo.completed":        "🎉 Todo *#%d* completed!\n\n~%s~",
+	"chore.cancelled":       "🚫 Chore *#%d* cancelled. It will not come back.\n\n~%s~",
+	"todo.cancelled":        "🚫 Todo *#%d* cancelled.\n\n~%s~",
+	"todo.reopened":         "🔄 Todo *#%d* reopened.\n\n%s *#%d* %s",
+	"todo.text_missing":     "❌ Please provide a todo text.\nUsage: `/add Buy groceries #shopping`",
+	"todo.text_only_tags":   "❌ Please provide a todo text besides the tags.\nUsage: `/add Buy groceries #shopping`",
+	"todo.added":            "✅ *Todo added!*\n\n🟡 *#%d* — %s%s",
+	"todo.delete_forbidden": "❌ You can only delete todos you created.",
+	"todo.deleted":          "🗑 Todo *#%d* deleted: %s",
+	"list.one_assignee":     "❌ Please mention only one assignee.",
+	"list.unknown_filter":   "❌ Unknown filter \"%s\".\nUsage: `/list #tag @user overdue sort:deadline`",
+	"list.filter_overdue":   "overdue",
+	"list.filter_for":       "for %s",
+	"list.filter_by":        "by %s",
+	"list.heading":          "📋 *Pending Todos*",
+	"list.no_match":         "Nothing matches.",
+	"list.empty":            "📋 *No pending todos!*\n\nAdd one with `/add <text>`",
+	"show.status":           "%s Status: %s",
+	"show.priority":         "⚡ Priority: %s",
+	"show.deadline":         "📅 Deadline: %s",
+	"show.created":          "👤 Created by %s on %s",
+	"show.assigned":         "🙋 Assigned to %s",
+	"show.checklist":        "📝 *Checklist*%s",
+	"show.autocomplete":     "Completes itself once everything is ticked off.",
+	"show.history":          "📜 *History*",
+	"my.empty":              "📌 *You have no pending todos!*\n\nCreate one with `/add <text>`",
+	"my.heading":            "📌 *Your Todos*",
+
+	// Checklists
+	"checklist.none":           "📝 *#%d* %s has no checklist yet.\n\nAdd items with `/sub %d <text>`",
+	"checklist.show":           "📝 *#%d* %s%s\n%s\n\nTick items off with `/check %d.<n>`",
+	"checklist.closed":         "❌ Todo *#%d* is %s. Reopen it with `/reopen %d` to add items.",
+	"checklist.added":          "📝 Added to *#%d* %s%s\n\n⬜ `%d.%d` %s",
+	"checklist.auto_on":        "✅ *#%d* will be completed once its whole checklist is ticked off.",
+	"checklist.auto_off":       "👌 *#%d* stays open until someone runs `/done %d`.",
+	"checklist.check_usage":    "❌ Please provide the todo ID and the item number.\nUsage: `/check 12.3`",
+	"checklist.no_item":        "❌ Todo *#%d* has no item %d. See `/sub %d`.",
+	"checklist.completed_next": "🎉 All done — todo completed!\n🔁 Next up: *#%d* for %s, due %s",
+	"checklist.completed":      "🎉 All done — todo completed!",
+	"checklist.all_ticked":     "✅ Everything is ticked off. Complete it with `/done %d`.",
+
+	// Chores
+	"user.not_found":           "❌ User @%s not found. They need to talk to me once first.",
+	"recurrence.daily":         "daily",
+	"recurrence.weekly":        "weekly",
+	"recurrence.monthly":       "monthly",
+	"chore.usage":              "❌ Please provide an interval and a title.\n\nUsage: `/chore weekly Take out the trash @anna @ben`\nIntervals: daily, weekly, monthly. Mentioned members take turns in that order.",
+	"chore.rotation_members":   "❌ Everyone in the rotation has to be a member of this family.",
+	"chore.added":              "🧹 *Chore added!*\n\n%s *#%d* — %s\n🔁 %s: %s\n👉 First up: %s, due %s",
+	"chore.not_chore":          "❌ Todo *#%d* is not a chore. Add chores with `/chore`.",
+	"chore.rotation_show":      "🔁 *#%d* %s rotates: %s\n\nChange it with `/rotation %d @anna @ben`",
+	"chore.rotation_forbidden": "❌ Only the chore's creator or a family admin can change its rotation.",
+	"chore.rotation_set":       "🔁 *#%d* %s now rotates: %s",
+	"chores.empty":             "🧹 *No chores yet!*\n\nAdd one with `/chore weekly Take out the trash @anna @ben`",
+	"chores.heading":           "🧹 *Chores this week*",
+	"chores.none_this_week":    "Nothing due this week.",
+	"chores.later":             "Later",
+	"chores.footer":            "_Mark a chore done with_ `/done <id>` _to pass it on._",
+
+	// Shopping list
+	"buy.usage":            "❌ Please provide an item name.\n\n*Usage:*\n`/buy Milk x2`\n`/buy Whole wheat bread`\n`/buy Cheese #dairy`",
+	"buy.list_name":        "Shopping List",
+	"buy.added":            "🛒 *Added to shopping list!*\n\n⬜ *#%d* — %s%s",
+	"buy.no_list":          "🛒 *No shopping list yet!*\n\nStart one with `/buy <item>`",
+	"buy.id_invalid":       "❌ Invalid ID. Please provide a numeric item ID.",
+	"buylist.empty":        "🛒 *Shopping list is empty!*\n\nAdd items with `/buy <item>`",
+	"buylist.heading":      "🛒 *Shopping List*",
+	"buylist.bought_by":    "by %s",
+	"buylist.count":        "%d remaining, %d bought",
+	"buylist.clear_hint":   "_Use_ `/buyclear` _to move bought items to history_",
+	"bought.usage":         "❌ Please provide an item ID.\nUsage: `/bought 3` or `/bought 3 4.99`",
+	"bought.price_invalid": "❌ Invalid price. Example: `/bought 3 4.99`",
+	"bought.failed":        "❌ Could not mark item *#%d* as bought. It may not exist.",
+	"bought.done":          "✅ Item *#%d* marked as bought!",
+	"buyclear.no_list":     "❌ No shopping list found for this chat.",
+	"buyclear.nothing":     "ℹ️ There are no bought items to clear.",
+	"buyclear.done":        "🧹 All bought items have been cleared from the shopping list!\n\n🧾 *Trip #%d* — %s, total %.2f\n\n_Send a photo with the caption_ `/receipt` _to attach the receipt, see spending with_ `/spent`",
+	"spent.usage":          "❌ Please provide a number of months between 1 and 24.\nUsage: `/spent 3`",
+	"spent.none":           "💰 *No purchases since %s.*\n\nRecord prices with `/bought <id> <price>`",
+	"spent.heading":        "💰 *Spending since %s*",
+	"spent.total":          "*Total:* %.2f (%s)",
+	"spent.per_month":      "📆 *Per month*",
+	"spent.per_member":     "👤 *Per member*",
+	"spent.per_category":   "🏷 *Per category*",
+
+	// Calendar
+	"event.all_day":          "%s (all day)",
+	"event.at":               "%s at %s",
+	"event.usage":            "❌ Please provide a title and date.\n\n*Usage:*\n`/event Meeting 2025-01-15 14:00`\n`/event Birthday party 2025-03-20`",
+	"event.no_date":          "❌ Could not find a date in your command.\nPlease use the format `YYYY-MM-DD`.\nExample: `/event Meeting 2025-01-15 14:00`",
+	"event.no_title":         "❌ Please provide an event title before the date.",
+	"event.bad_date":         "❌ Invalid date/time format.\nDate: `YYYY-MM-DD`, Time: `HH:MM`",
+	"event.created":          "📅 *Event created!*\n\n*#%d* — %s\n📆 %s",
+	"event.ask_title":        "📅 *New event*\nWhat's the title?\n\nSend /cancel to stop.",
+	"event.ask_when":         "📆 When is *%s*?\nPick a day and time, or reply with `YYYY-MM-DD` or `YYYY-MM-DD HH:MM`.",
+	"event.bad_when":         "❌ I didn't get that date. Reply with `YYYY-MM-DD` or `YYYY-MM-DD HH:MM`, or pick a day above.",
+	"event.ask_location":     "📍 Where is it? Reply with the place, or skip.",
+	"event.all_day_button":   "🗓 All day",
+	"events.empty":           "📅 *No upcoming events!*\n\nAdd one with `/event <title> <date> [time]`",
+	"events.heading":         "📅 *Upcoming Events*",
+	"events.occasion":        "occasion #%d",
+	"delevent.usage":         "❌ Please provide an event ID.\nUsage: `/delevent 3`",
+	"event.id_invalid":       "❌ Invalid ID. Please provide a numeric event ID.",
+	"event.not_found":        "❌ Event *#%d* not found.",
+	"event.not_in_chat":      "❌ Event *#%d* not found in this chat.",
+	"event.delete_forbidden": "❌ You can only delete events you created.",
+	"event.deleted":          "🗑 Event *#%d* deleted: %s",
+
+	// Occasion reminders
+	"occasion.birthday_of":  "%s's birthday",
+	"occasion.today":        "is today!",
+	"occasion.tomorrow":     "is tomorrow",
+	"occasion.their":        "their",
+	"occasion.whose":        "%s's",
+	"occasion.all_taken":    "🎁 Everything on %s wish list is taken.",
+	"occasion.still_free":   "🎁 Still free on %s wish list:\n%s",
+	"occasion.reserve_hint": "Reserve with `/reserve <id>` or chip in with `/pledge <id> <amount>`.",
+	"reminder.heading":      "Reminder",
+
+	// Occasions
+	"birthday.usage":            "❌ Please provide a birthday date.\n\n*Usage:*\n`/birthday 1990-05-14`\n`/birthday 14.05 @anna days:14`\n\n_Reminders go out 7 days ahead unless `days:N` is given._",
+	"birthday.saved":            "🎂 *Birthday saved!*\n\n*#%d* %s\n📆 %s\n\n_Everyone else gets a reminder %s ahead._",
+	"occasion.usage":            "❌ Please provide a date and a title.\n\n*Usage:*\n`/occasion 12-25 Christmas`\n`/occasion 08.03 Women's Day days:3`",
+	"occasion.added":            "🎉 *Occasion added!*\n\n*#%d* %s\n📆 %s\n\nLink your wish list to it with `/wishfor %d`.",
+	"occasions.empty":           "🎉 *No occasions yet!*\n\nAdd a birthday with `/birthday <date>` or a holiday with `/occasion <date> <title>`",
+	"occasions.heading":         "🎉 *Upcoming Occasions*",
+	"occasions.footer":          "_Link your wish list with_ `/wishfor <id>`",
+	"deloccasion.usage":         "❌ Please provide an occasion ID.\nUsage: `/deloccasion 3`",
+	"occasion.id_invalid":       "❌ Invalid ID. Please provide a numeric occasion ID.",
+	"occasion.not_in_chat":      "❌ Occasion *#%d* not found in this chat.",
+	"occasion.delete_forbidden": "❌ You can only delete occasions you added or your own birthday.",
+	"occasion.deleted":          "🗑 Occasion *#%d* deleted.",
+	"wishfor.usage":             "❌ Please provide an occasion ID.\n\nUsage: `/wishfor 3` or `/wishfor off`\nSee `/occasions` for IDs.",
+	"wishfor.unlinked":          "🎁 Your wish list is no longer linked to an occasion.",
+	"wishfor.linked":            "🎁 Your wish list is now for *%s* (%s).",
+
+	// Wish lists
+	"wish.usage":                 "❌ Please provide a wish item.\nUsage: `/wish PlayStation 5`\nor `/wish https://shop.example/item` _to fill in name and price from the page_",
+	"wish.added":                 "🎁 *Added to your wish list!*\n\n*#%d* — %s",
+	"wish.link":                  "link",
+	"wish.fix_hint":              "_Fix details with_ `/editwish %d name:… price:…`",
+	"wish.most_wanted":           "most wanted",
+	"wish.purchased":             "purchased",
+	"wish.by_you":                "by you",
+	"wish.by":                    "by %s",
+	"wish.id_missing":            "❌ Please provide a wish item ID.\n\nUsage: `/%s 5`",
+	"wish.not_found":             "❌ Wish item *#%d* not found.",
+	"wish.purchased_already":     "❌ Item *#%d* has already been purchased.",
+	"wishlist.owner":             "%s's Wishes",
+	"wishlist.none":              "🎁 *No wish lists yet!*\n\nAdd wishes with `/wish <item>`",
+	"wishlist.family_heading":    "🎁 *Family Wish Lists*",
+	"wishlist.family_footer":     "_View a specific list with_ `/wishlist @username`",
+	"wishlist.empty_mark":        "empty",
+	"wishlist.reserved_hint":     "Something here has been reserved",
+	"wishlist.own_reserved_hint": "Something on your list has been reserved",
+	"wishlist.own_none":          "🎁 *You don't have a wish list yet.*\n\nCreate one with `/wish <item>`",
+	"wishlist.other_none":        "🎁 *%s doesn't have a wish list yet.*",
+	"wishlist.own_empty":         "🎁 *Your wish list is empty!*\n\nAdd items with `/wish <item>`",
+	"wishlist.other_empty":       "🎁 *%s's wish list is empty.*",
+	"wishlist.other_heading":     "🎁 *%s's Wish List*",
+	"wishlist.own_heading":       "🎁 *Your Wish List*",
+	"wishlist.private_hint":      "_Reservations are only shown in a private chat with me._",
+	"wishlist.reserve_hint":      "_Use_ `/reserve <id>` _to reserve a gift_",
+	"reserve.usage":              "❌ Please provide a wish item ID.\n\nUsage: `/reserve 5`\n\n_View someone's wish list first with_ `/wishlist @username`",
+	"reserve.failed":             "❌ Could not reserve item *#%d*.\nIt may not exist or is already reserved.",
+	"reserve.own":                "❌ You can't reserve an item on your own wish list.",
+	"reserve.done":               "🔒 Item *#%d* reserved!\n\n_The owner won't see who reserved it._",
+	"reserve.done_public":        "🔒 Reserved! _The owner won't see who reserved it._",
+	"wishhint.usage":             "❌ Please choose on or off.\n\nUsage: `/wishhint on`\n\n_When enabled, your wish list tells you that something has been reserved without revealing what or by whom._",
+	"wishhint.on":                "✨ Reservation hint enabled. `/wishlist` will tell you when something has been reserved.",
+	"wishhint.off":               "🙈 Reservation hint disabled. You won't see any reservation info.",
+	"unreserve.not_yours":        "❌ You haven't reserved item *#%d*.",
+	"unreserve.pledged":          "❌ Others have pledged toward this gift, so you can't step back as organizer.",
+	"unreserve.done":             "🔓 Your reservation of item *#%d* was released.",
+	"unreserve.done_public":      "🔓 Reservation released.",
+	"delwish.forbidden":          "❌ You can only delete items from your own wish list.",
+	"delwish.done":               "🗑 Wish item *#%d* deleted.",
+	"editwish.usage":             "*Usage:*\n`/editwish 5 price:49.99`\n`/editwish 5 url:https://shop.example/item notes:blue, size M`\n`/editwish 5 priority:1` — mark as most wanted\n\n_Fields: name, price, url, notes, priority. An empty value clears a field._",
+	"editwish.missing":           "❌ Please provide a wish item ID and the fields to change.",
+	"editwish.unexpected":        "unexpected \"%s\", use key:value",
+	"editwish.name_empty":        "name cannot be empty",
+	"editwish.url_invalid":       "url must start with http:// or https://",
+	"editwish.priority_invalid":  "priority must be a number from 0 to 99",
+	"editwish.forbidden":         "❌ You can only edit items on your own wish list.",
+	"editwish.done":              "✏️ *Wish updated!*\n\n*#%d* %s%s",
+
+	// Group gifts
+	"pledge.pledged":           "%.2f pledged",
+	"pledge.usage":             "❌ Please provide a wish item ID and an amount.\n\nUsage: `/pledge 5 30`\n\n_Pledging again changes your amount, `/unpledge 5` withdraws it._",
+	"pledge.amount_invalid":    "❌ Invalid amount. Use a positive number like `30` or `12.50`.",
+	"pledge.own":               "❌ You can't pledge toward your own wish.",
+	"pledge.done":              "💰 You pledged *%.2f* toward *#%d* %s",
+	"pledge.organizer":         "_You are organizing this gift. Once bought, run_ `/purchased %d [amount]`",
+	"pledge.organized_by":      "Organized by %s",
+	"pledge.done_public":       "💰 Pledge saved! _The owner won't see it._",
+	"unpledge.not_yours":       "❌ You haven't pledged toward item *#%d*.",
+	"unpledge.done":            "↩️ Your pledge for item *#%d* was withdrawn.",
+	"unpledge.done_public":     "↩️ Pledge withdrawn.",
+	"purchased.usage":          "❌ Please provide a wish item ID.\n\nUsage: `/purchased 5` or `/purchased 5 89.90`\n\n_Without an amount, the pledged total is used._",
+	"purchased.amount_invalid": "❌ Invalid amount. Use a number like `89.90`.",
+	"purchased.forbidden":      "❌ Only the organizer who reserved the gift can mark it as purchased.",
+	"purchased.heading":        "🎉 *Gift purchased:* #%d %s",
+	"purchased.paid_by":        "Paid by %s: *%.2f*",
+	"purchased.pledged":        "pledged %.2f",
+	"purchased.nothing":        "_Nobody else pledged, so there is nothing to settle._",
+	"purchased.settle":         "*Settle up:*",
+	"purchased.owes":           "%s owes %s *%.2f*",
+	"purchased.done_public":    "🎉 Gift purchased! _Contributors have been told what they owe._",
+	"purchased.share":          "🎁 %s bought *%s*.\nYour share: *%.2f* — please settle up with them.",
+
+	// Reminders
+	"remind.overdue":          "%s (overdue)",
+	"remind.in_hours":         "in %dh %dm (%s)",
+	"remind.in_minutes":       "in %dm (%s)",
+	"remind.in_moment":        "in less than a minute (%s)",
+	"remind.usage":            "❌ Please provide a time and reminder text.\n\n*Usage:*\n`/remind 10m Take out trash`\n`/remind 2h Call dentist`\n`/remind 1d Pay bills`\n`/remind 15:30 Pick up kids`\n`/remind 2025-12-31 15:30 New Year party`",
+	"remind.bad_time":         "❌ Could not parse time.\n\nSupported formats: `10m`, `2h`, `1d`, `15:30`, `2025-12-31 15:30`",
+	"remind.no_text":          "❌ Please provide a reminder text after the time.",
+	"remind.set":              "⏰ *Reminder set!*\n\n*#%d* — %s\n📅 %s",
+	"remind.ask_text":         "⏰ *New reminder*\nWhat should I remind you of?\n\nSend /cancel to stop.",
+	"remind.ask_when":         "⏰ When should I remind you of *%s*?\nPick a day and time, or reply with `10m`, `2h`, `15:30` or `2025-12-31 15:30`.",
+	"remind.bad_when":         "❌ I didn't get that time, or it has passed. Reply with `10m`, `2h`, `15:30` or `2025-12-31 15:30`, or pick one above.",
+	"reminders.empty":         "⏰ *No active reminders!*\n\nCreate one with `/remind <time> <text>`",
+	"reminders.heading":       "⏰ *Your Reminders*",
+	"reminders.footer":        "_Delete with_ `/delremind <id>`",
+	"delremind.usage":         "❌ Please provide a reminder ID.\nUsage: `/delremind 3`",
+	"remind.id_invalid":       "❌ Invalid ID. Please provide a numeric reminder ID.",
+	"remind.not_found":        "❌ Reminder *#%d* not found.",
+	"remind.delete_forbidden": "❌ You can only delete your own reminders.",
+	"remind.deleted":          "🗑 Reminder *#%d* deleted: %s",
+
+	// Receipts
+	"receipt.usage":          "❌ Please send a receipt photo with this command as the caption, or reply to one.\n\n*Usage:*\n`/receipt` — attach to the last shopping trip\n`/receipt 12` — attach to bought item #12\n`/receipt trip 3` — attach to shopping trip #3",
+	"receipt.no_list":        "❌ No shopping list found for this chat.",
+	"receipt.no_trip":        "ℹ️ There is no completed shopping trip yet. Finish one with `/buyclear` or attach the receipt to an item with `/receipt <item id>`.",
+	"receipt.trip_label":     "shopping trip #%d",
+	"receipt.item_label":     "item #%d",
+	"receipt.trip_missing":   "❌ Please provide a trip ID: `/receipt trip 3`",
+	"receipt.trip_invalid":   "❌ Invalid trip ID. Please provide a number.",
+	"receipt.trip_not_found": "❌ Shopping trip #%d not found in this chat.",
+	"receipt.item_invalid":   "❌ Invalid item ID. Please provide a number.",
+	"receipt.item_not_found": "❌ Item #%d not found in this chat.",
+	"receipt.not_bought":     "❌ Item #%d has not been bought yet. Mark it with `/bought %d` first.",
+	"receipt.attached":       "🧾 Receipt attached to %s.",
+
+	// Pages
+	"page.of":   "Page %d of %d",
+	"page.prev": "◀️ Prev",
+	"page.next": "Next ▶️",
+
+	// Search
+	"search.group.todo":     "📋 *Todos*",
+	"search.group.event":    "📅 *Events*",
+	"search.group.buying":   "🛒 *Shopping list*",
+	"search.group.wish":     "🎁 *Wishes*",
+	"search.group.reminder": "⏰ *Reminders*",
+	"search.usage":          "❌ Please tell me what to look for.\nUsage: `/search dentist`",
+	"search.empty_query":    "❌ Please search for words or numbers.",
+	"search.nothing":        "🔎 Nothing found for `%s`.",
+	"search.heading":        "🔎 *Search:* `%s`\n_%d–%d of %d_",
+
+	// Families
+	"family.none":          "👨‍👩‍👧 This chat has no family yet. Add a todo or an item to start one.",
+	"family.belongs":       "👨‍👩‍👧 This chat belongs to *%s*.\n\nSend /family in a private chat with me to work on it from there.",
+	"family.private_lists": "your private lists",
+	"family.switcher":      "👨‍👩‍👧 *Your families*\n\nCommands here work on %s. Pick another family below.",
+
+	// Family roles and links
+	"role.lookup_failed": "❌ Could not look up that user.",
+	"role.usage":         "❌ Please mention a member or reply to their message.\n\nUsage: `/%s @username`",
+	"role.no_family":     "❌ This chat has no family yet.",
+	"role.forbidden":     "❌ Only family admins can change roles.",
+	"role.not_member":    "❌ %s is not a member of this family.",
+	"role.last_admin":    "❌ The family needs at least one admin. Promote someone else first.",
+	"role.admin":         "⭐ %s is now a family admin.",
+	"role.member":        "👤 %s is now a regular member.",
+	"link.forbidden":     "❌ Only family admins can link chats.",
+	"link.code":          "🔗 Link code for *%s*: `%s`\n\nSend `/join %s` in the other chat, e.g. your private chat with me. The code works once and expires %s.",
+	"join.usage":         "❌ Please provide a link code.\n\nUsage: `/join <code>`\nGet a code with /link in the family chat.",
+	"join.forbidden":     "❌ Only admins of this chat's family can link it to another family.",
+	"join.invalid":       "❌ That link code is invalid, expired or already used. Ask for a new one with /link.",
+	"join.done":          "🔗 This chat is now part of *%s*. Todos, the shopping list, the calendar and reminders are shared.",
+	"join.already":       "🔗 This chat already belongs to *%s*.",
+
+	// Membership
+	"member.bot_added": "👋 Hi *%s*! I keep your family's todos, shopping list, calendar and wish lists.\n\nGroup admins are family admins. Use /help to get started.",
+	"member.joined":    "👋 Welcome to *%s*, %s! Use /help to see what I can do.",
+	"member.left":      "👋 %s left the family.",
+	"member.new_admin": "%s is the new family admin",
+
+	// Conversations
+	"dialog.cancelled":     "🚫 Cancelled.",
+	"dialog.expired":       "⌛ This question has expired. Please start over.",
+	"dialog.skip_button":   "Skip ➡",
+	"dialog.cancel_button": "✖ Cancel",
+
+	// Deadlines
+	"deadline.pick":       "📅 When is *#%d* %s due?",
+	"deadline.usage":      "❌ Please provide a date.\n\n*Usage:*\n`/deadline 5` - pick a date\n`/deadline 5 2025-03-15 18:00`\n`/deadline 5 off` - remove the deadline",
+	"deadline.set":        "📅 *#%d* %s is due %s.",
+	"deadline.removed":    "📅 *#%d* %s no longer has a deadline.",
+	"deadline.end_of_day": "🌙 End of day",
+	"deadline.off_button": "🗑 No deadline",
+
+	// Snoozing reminders
+	"snooze.15m":       "💤 15 min",
+	"snooze.1h":        "💤 1 h",
+	"snooze.3h":        "💤 3 h",
+	"snooze.1d":        "💤 1 day",
+	"snooze.pick":      "📅 Snooze until…",
+	"snooze.back":      "« Back",
+	"snooze.forbidden": "❌ You can only snooze your own reminders.",
+	"snooze.done":      "💤 Reminder *#%d* snoozed.\n📅 %s",
+
+	// Inline mode
+	"inline.open_bot":   "Nothing found — open TodoboT",
+	"inline.todo_card":  "📋 *%s*\n%s\n👨‍👩‍👧 %s",
+	"inline.buy_card":   "🛒 *%s*\n%s\n👨‍👩‍👧 %s",
+	"inline.wish_card":  "🎁 *%s*\n%s\n👨‍👩‍👧 %s",
+	"inline.wish_of":    "On %s's wish list",
+	"inline.buy_needed": "Still to buy",
+	"inline.buy_bought": "Bought",
+	"inline.add_button": "🛒 Add to my shopping list",
+	"inline.added":      "✅ %s added it",
+
+	// Capturing plain messages
+	"capture.status_on":      "📝 I offer to save things to buy, do and be reminded of that are written here without a command.",
+	"capture.status_off":     "📝 I only react to commands here.",
+	"capture.usage":          "Turn it on or off with `/capture on` or `/capture off`. In groups I only see messages without a command when I am an admin or my privacy mode is off.",
+	"capture.on":             "✅ From now on I offer to save things written here like \"we need milk and bread\" or \"remind me tomorrow 8am to call grandma\". Nothing is saved until you confirm.",
+	"capture.off":            "✅ I no longer read messages without a command here.",
+	"capture.forbidden":      "❌ Only family admins can turn this on or off.",
+	"capture.buy_offer":      "🛒 Add to the shopping list: %s?",
+	"capture.todo_offer":     "📋 Add a todo: *%s*?",
+	"capture.remind_offer":   "⏰ Remind you of *%s* %s?",
+	"capture.confirm_button": "✅ Yes",
+	"capture.dismiss_button": "✖️ No",
+	"capture.gone":           "❌ The message is gone, so there is nothing to save.",
+	"capture.past":           "❌ That time has already passed.",
+	"capture.buy_added":      "🛒 *Added to shopping list!*\n\n%s",
+
+	// Attached media
+	"media.photo":    "Photo",
+	"media.voice":    "Voice note",
+	"media.document": "Document",
+}
+
+// enPlurals holds the English messages that depend on a count: one, other.
+var enPlurals = map[string][]string{
+	// Todos
+	"list.count": {"%d pending item", "%d pending items"},
+	"my.count":   {"%d item assigned to you", "%d items assigned to you"},
+
+	// Shopping list
+	"common.items": {"%d item", "%d items"},
+
+	// Calendar
+	"events.count": {"%d upcoming event", "%d upcoming events"},
+
+	// Occasion reminders
+	"occasion.in_days": {"in %d day", "in %d days"},
+
+	// Occasions
+	"birthday.remind_days": {"%d day", "%d days"},
+
+	// Reminders
+	"reminders.count": {"%d active reminder", "%d active reminders"},
+
+	// Membership
+	"member.left_todos":     {"%d of their todos is unassigned now — see /list", "%d of their todos are unassigned now — see /list"},
+	"member.left_wishes":    {"%d wish reservation was released or passed on", "%d wish reservations were released or passed on"},
+	"member.left_reminders": {"%d of their reminders was stopped", "%d of their reminders were stopped"},
+}
@@ -0,0 +1,171 @@
+// Package i18n holds the bot's message catalogs.
+//
+// Every language has a catalog mapping message keys to templates in the
+// markup format. Templates are fmt format strings. Messages that depend on
+// a count have a list of forms instead, in the order of the language's
+// plural rule, e.g. {"%d item", "%d items"} in English and
+// {"%d товар", "%d товара", "%d товаров"} in Russian.
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Default is the language used when nobody picked one and Telegram does not
+// tell the user's language, and for keys missing from another catalog.
+const Default = "en"
+
+// language describes a supported language.
+type language struct {
+	// name is the language's own name, as shown by /lang
+	name    string
+	catalog map[string]string
+	plurals map[string][]string
+	// plural returns the index of the form to use for n
+	plural func(n int) int
+	// dates translates the English weekday and month names time.Format
+	// writes, nil for English
+	dates *strings.Replacer
+}
+
+var languages = map[string]*language{
+	"en": {name: "English", catalog: en, plurals: enPlurals, plural: englishPlural},
+	"ru": {name: "Русский", catalog: ru, plurals: ruPlurals, plural: russianPlural, dates: russianDates},
+}
+
+// order lists the language codes as offered to users.
+var order = []string{"en", "ru"}
+
+// Languages returns the codes of the supported languages, the default first.
+func Languages() []string {
+	return slices.Clone(order)
+}
+
+// Name returns the language's own name, e.g. "Русский" for "ru".
+func Name(lang string) string {
+	if l, ok := languages[lang]; ok {
+		return l.name
+	}
+	return lang
+}
+
+// Match returns the supported language for a language code as sent by
+// Telegram ("ru", "en-US", ...), or "" if it is not supported.
+func Match(code string) string {
+	code = strings.ToLower(code)
+	if base, _, found := strings.Cut(code, "-"); found {
+		code = base
+	}
+	if _, ok := languages[code]; ok {
+		return code
+	}
+	return ""
+}
+
+// lookup returns the template for key in lang, falling back to the default
+// language and then to the key itself.
+func lookup(lang, key string) string {
+	if l, ok := languages[lang]; ok {
+		if s, ok := l.catalog[key]; ok {
+			return s
+		}
+	}
+	if s, ok := languages[Default].catalog[key]; ok {
+		return s
+	}
+	return key
+}
+
+// T returns the message for key in lang, formatted with args.
+func T(lang, key string, args ...any) string {
+	s := lookup(lang, key)
+	if len(args) == 0 {
+		return s
+	}
+	return fmt.Sprintf(s, args...)
+}
+
+// N returns the form of the message for key that fits the count n,
+// formatted with n followed by args.
+func N(lang, key string, n int, args ...any) string {
+	l, ok := languages[lang]
+	if !ok || len(l.plurals[key]) == 0 {
+		l = languages[Default]
+	}
+	forms := l.plurals[key]
+	if len(forms) == 0 {
+		return key
+	}
+	form := forms[len(forms)-1]
+	if i := l.plural(n); i < len(forms) {
+		form = forms[i]
+	}
+	return fmt.Sprintf(form, append([]any{n}, args...)...)
+}
+
+// Date formats t with a time layout like "Mon, 02 Jan 2006", with the names
+// of weekdays and months in lang.
+func Date(lang string, t time.Time, layout string) string {
+	s := t.Format(layout)
+	if l, ok := languages[lang]; ok && l.dates != nil {
+		s = l.dates.Replace(s)
+	}
+	return s
+}
+
+// Missing returns the keys of the default catalog that lang's catalog
+// lacks, or that have a different number of forms than the language's
+// plural rule needs, sorted. Such messages are shown in the default
+// language.
+func Missing(lang string) []string {
+	l, ok := languages[lang]
+	if !ok {
+		return nil
+	}
+	forms := 0
+	for n := range 200 {
+		forms = max(forms, l.plural(n)+1)
+	}
+
+	var missing []string
+	def := languages[Default]
+	for key := range def.catalog {
+		if _, ok := l.catalog[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	for key := range def.plurals {
+		if len(l.plurals[key]) != forms {
+			missing = append(missing, key)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// englishPlural picks between "one" and "other".
+func englishPlural(n int) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+// russianPlural picks between "one" (1, 21, 31, ...), "few" (2-4, 22-24,
+// ...) and "many" (0, 5-20, 25-30, ...).
+func russianPlural(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,49 @@
+package i18n
+
+import "testing"
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, lang := range Languages() {
+		if missing := Missing(lang); len(missing) > 0 {
+			t.Errorf("%s lacks %d messages: %v", lang, len(missing), missing)
+		}
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	for _, lang := range Languages() {
+		l := languages[lang]
+		forms := 0
+		for n := range 200 {
+			forms = max(forms, l.plural(n)+1)
+		}
+
+		for key, list := range l.plurals {
+			if len(list) != forms {
+				t.Errorf("%s: %s has %d forms, want %d", lang, key, len(list), forms)
+			}
+		}
+		for key := range languages[Default].plurals {
+			if _, ok := l.plurals[key]; !ok {
+				t.Errorf("%s: plural %s is missing", lang, key)
+			}
+		}
+	}
+}
+
+func TestNoStaleMessages(t *testing.T) {
+	def := languages[Default]
+	for _, lang := range Languages() {
+		l := languages[lang]
+		for key := range l.catalog {
+			if _, ok := def.catalog[key]; !ok {
+				t.Errorf("%s: %s is not in the %s catalog", lang, key, Default)
+			}
+		}
+		for key := range l.plurals {
+			if _, ok := def.plurals[key]; !ok {
+				t.Errorf("%s: plural %s is not in the %s catalog", lang, key, Default)
+			}
+		}
+	}
+}
@@ -0,0 +1,563 @@
+package i18n
+
+import "strings"
+
+// ru is the Russian catalog.
+var ru = map[string]string{
+	// Router
+	"router.error":   "❌ При выполнении команды произошла ошибка. Попробуйте ещё раз.",
+	"router.unknown": "❓ Неизвестная команда. Список команд — /help.",
+
+	// /start and /help
+	"start.text": `🎯 *Добро пожаловать в TodoboT!*
+
+Семейный помощник для задач, событий, покупок, желаний и напоминаний.
+
+*Быстрый старт:*
+• /add Купить продукты - Добавить задачу
+• /event День рождения 2025-03-15 - Добавить событие
+• /buy Молоко x 2 - Добавить в список покупок
+• /wish Новые наушники - Добавить в список желаний
+• /remind 2h Выпить лекарство - Поставить напоминание
+
+Полный список команд — /help!`,
+
+	"help.title":             "📚 *Справка TodoboT*",
+	"help.section.todos":     "*Задачи:*",
+	"help.section.chores":    "*Дела по дому:*",
+	"help.section.calendar":  "*Календарь:*",
+	"help.section.shopping":  "*Список покупок:*",
+	"help.section.wishes":    "*Списки желаний:*",
+	"help.section.occasions": "*Праздники:*",
+	"help.section.reminders": "*Напоминания:*",
+	"help.section.language":  "*Язык:*",
+	"help.section.capture":   "*Обычные сообщения:*",
+	"help.section.search":    "*Поиск:*",
+	"help.section.family":    "*Семья:*",
+	"help.footer":            "_Администраторы семьи и группы могут изменять и удалять всё._\n\n_Форматы времени: 10m, 2h, 1d, 15:30, 2025-01-15 15:30_",
+
+	// /help lines of each registered command
+	"help.add":         "• /add <текст> [#тег ...] - Добавить задачу\n• /add [текст] - В ответ на фото, голосовое или документ: добавить задачу с вложением",
+	"help.list":        "• /list [#тег ...] [@user] [overdue] [sort:deadline|priority|created] - Открытые задачи, с фильтрами",
+	"help.done":        "• /done <id> - Выполнить задачу",
+	"help.cancel":      "• /cancel <id> - Отменить задачу\n• /cancel - Прекратить отвечать на мои вопросы",
+	"help.reopen":      "• /reopen <id> - Вернуть выполненную или отменённую задачу",
+	"help.show":        "• /show <id> - Задача и её история",
+	"help.sub":         "• /sub <id> <текст> - Добавить пункт в чек-лист задачи\n• /sub <id> auto on|off - Выполнять задачу, когда отмечен весь чек-лист",
+	"help.check":       "• /check <id>.<n> - Отметить пункт n",
+	"help.delete":      "• /delete <id> - Удалить задачу",
+	"help.deadline":    "• /deadline <id> [дата [время]|off] - Задать срок задачи или выбрать его в календаре",
+	"help.my":          "• /my - Ваши задачи",
+	"help.chore":       "• /chore <daily|weekly|monthly> <название> [@user ...] - Повторяющаяся задача; упомянутые участники выполняют по очереди",
+	"help.rotation":    "• /rotation <id> @user ... - Изменить очередь",
+	"help.chores":      "• /chores - Кто что делает на этой неделе",
+	"help.event":       "• /event <название> <ГГГГ-ММ-ДД> [ЧЧ:ММ] - Добавить событие\n• /event - Добавить событие по шагам",
+	"help.events":      "• /events - Ближайшие события",
+	"help.delevent":    "• /delevent <id> - Удалить событие",
+	"help.buy":         "• /buy <товар> [x кол-во] [#категория] - Добавить в список покупок\n• /buy [товар] - В ответ на фото, голосовое или документ: добавить покупку с вложением",
+	"help.buylist":     "• /buylist [sort:name] - Список покупок",
+	"help.bought":      "• /bought <id> [цена] - Отметить как купленное",
+	"help.buyclear":    "• /buyclear - Перенести купленное в историю",
+	"help.spent":       "• /spent [месяцы] - Расходы семьи",
+	"help.receipt":     "• /receipt [id] - Прикрепить чек (отправьте как подпись к фото)",
+	"help.wish":        "• /wish <желание|ссылка> - Добавить в свой список желаний",
+	"help.wishlist":    "• /wishlist [@user] [sort:created|name] - Списки желаний",
+	"help.reserve":     "• /reserve <id> - Забронировать подарок",
+	"help.unreserve":   "• /unreserve <id> - Снять бронь",
+	"help.editwish":    "• /editwish <id> price:… url:… notes:… priority:… - Изменить желание",
+	"help.delwish":     "• /delwish <id> - Удалить желание",
+	"help.pledge":      "• /pledge <id> <сумма> - Скинуться на общий подарок",
+	"help.unpledge":    "• /unpledge <id> - Отозвать взнос",
+	"help.purchased":   "• /purchased <id> [сумма] - Организатор: подарок куплен, рассчитаться",
+	"help.wishhint":    "• /wishhint on|off - Подсказка, когда что-то из вашего списка забронировали",
+	"help.wishfor":     "• /wishfor <id праздника|off> - Привязать список желаний к празднику",
+	"help.birthday":    "• /birthday <дата> [@user] [days:N] - Сохранить день рождения",
+	"help.occasion":    "• /occasion <дата> <название> [days:N] - Добавить ежегодный праздник",
+	"help.occasions":   "• /occasions - Ближайшие праздники",
+	"help.deloccasion": "• /deloccasion <id> - Удалить праздник",
+	"help.remind":      "• /remind <время> <текст> - Поставить напоминание\n• /remind - Поставить напоминание по шагам",
+	"help.reminders":   "• /reminders - Ваши напоминания",
+	"help.delremind":   "• /delremind <id> - Удалить напоминание",
+	"help.lang":        "• /lang [en|ru|auto] - Выбрать язык бота (в группе — для всей семьи)",
+	"help.capture":     "• /capture on|off - Предлагать сохранить «нужно молоко и хлеб» или «напомни завтра в 8 утра позвонить бабушке», написанные без команды",
+	"help.search":      "• /search <слова> - Найти задачи, события, покупки, желания и напоминания\n• @TodoboT <слова> - В любом чате: найти задачи, покупки и желания ваших семей и поделиться ими",
+	"help.family":      "• /family - Выбрать, с какой семьёй работает личный чат со мной",
+	"help.promote":     "• /promote @user - Сделать участника администратором семьи",
+	"help.demote":      "• /demote @user - Сделать администратора обычным участником",
+	"help.link":        "• /link - Получить код, чтобы подключить семью к другому чату",
+	"help.join":        "• /join <код> - Подключить этот чат к другой семье",
+
+	// Command menus
+	"command.start":       "Начать работу с ботом",
+	"command.help":        "Все команды",
+	"command.add":         "Добавить задачу",
+	"command.list":        "Открытые задачи",
+	"command.done":        "Выполнить задачу",
+	"command.cancel":      "Отменить задачу или вопросы бота",
+	"command.reopen":      "Вернуть задачу",
+	"command.show":        "Задача и её история",
+	"command.sub":         "Добавить пункт в чек-лист",
+	"command.check":       "Отметить пункт чек-листа",
+	"command.delete":      "Удалить задачу",
+	"command.deadline":    "Задать срок задачи",
+	"command.my":          "Ваши задачи",
+	"command.chore":       "Добавить дело по дому",
+	"command.rotation":    "Изменить очередь",
+	"command.chores":      "Кто что делает на этой неделе",
+	"command.event":       "Добавить событие",
+	"command.events":      "Ближайшие события",
+	"command.delevent":    "Удалить событие",
+	"command.buy":         "Добавить в список покупок",
+	"command.buylist":     "Список покупок",
+	"command.bought":      "Отметить как купленное",
+	"command.buyclear":    "Перенести купленное в историю",
+	"command.spent":       "Расходы семьи",
+	"command.receipt":     "Прикрепить чек",
+	"command.wish":        "Добавить в список желаний",
+	"command.wishlist":    "Списки желаний",
+	"command.reserve":     "Забронировать подарок",
+	"command.unreserve":   "Снять бронь",
+	"command.editwish":    "Изменить желание",
+	"command.delwish":     "Удалить желание",
+	"command.pledge":      "Скинуться на общий подарок",
+	"command.unpledge":    "Отозвать взнос",
+	"command.purchased":   "Подарок куплен, рассчитаться",
+	"command.wishhint":    "Подсказки о бронировании желаний",
+	"command.wishfor":     "Привязать список желаний к празднику",
+	"command.birthday":    "Сохранить день рождения",
+	"command.occasion":    "Добавить ежегодный праздник",
+	"command.occasions":   "Ближайшие праздники",
+	"command.deloccasion": "Удалить праздник",
+	"command.remind":      "Поставить напоминание",
+	"command.reminders":   "Ваши напоминания",
+	"command.delremind":   "Удалить напоминание",
+	"command.search":      "Найти задачи, события, покупки и желания",
+	"command.lang":        "Выбрать язык бота",
+	"command.capture":     "Сохранять написанное без команды",
+	"command.family":      "Выбрать семью для личного чата",
+	"command.promote":     "Сделать участника администратором",
+	"command.demote":      "Сделать администратора участником",
+	"command.link":        "Получить код для подключения семьи",
+	"command.join":        "Подключить чат к другой семье",
+
+	// /lang
+	"lang.pick":        "🌐 Здесь я говорю на языке: *%s*. Выберите язык:",
+	"lang.set":         "🌐 Теперь я говорю здесь на языке: *%s*.",
+	"lang.auto":        "🌐 Снова говорю на языке Telegram каждого участника (для вас — *%s*).",
+	"lang.auto_button": "🔄 Автоматически",
+	"lang.unknown":     "❌ Я пока не говорю на `%s`. Выберите: %s или `auto`.",
+	"lang.forbidden":   "❌ Менять язык этого чата могут только администраторы семьи.",
+
+	// Common words
+	"common.anyone":        "кто угодно",
+	"common.someone":       "кто-то",
+	"family.private_title": "Список: %s",
+	"sort.created":         "новизне",
+	"sort.deadline":        "сроку",
+	"sort.priority":        "приоритету",
+	"sort.name":            "названию",
+	"sort.unknown":         "❌ Неизвестная сортировка `%s`. Доступно: %s.",
+
+	// Todos
+	"todo.status.pending":   "открыта",
+	"todo.status.completed": "выполнена",
+	"todo.status.cancelled": "отменена",
+	"todo.priority.low":     "низкий",
+	"todo.priority.medium":  "средний",
+	"todo.priority.high":    "высокий",
+	"todo.id_missing":       "❌ Укажите номер задачи.\nПример: `/%s 5`",
+	"todo.id_invalid":       "❌ Неверный номер. Укажите номер задачи цифрами.",
+	"todo.not_found":        "❌ Задача *#%d* не найдена.",
+	"todo.not_in_chat":      "❌ Задача *#%d* не найдена в этом чате.",
+	"todo.change_forbidden": "❌ Можно менять только свои задачи или назначенные вам.",
+	"todo.already":          "ℹ️ Задача *#%d* уже %s.",
+	"todo.not_pending":      "❌ Задача *#%d* %s. Выполнить или отменить можно только открытую задачу; сначала `/reopen %d`.",
+	"todo.completed_next":   "🎉 Задача *#%d* выполнена!\n\n~%s~\n\n🔁 Следующая: *#%d*, исполнитель: %s, срок %s",
+	"todo.completed":        "🎉 Задача *#%d* выполнена!\n\n~%s~",
+	"chore.cancelled":       "🚫 Дело *#%d* отменено и больше не повторится.\n\n~%s~",
+	"todo.cancelled":        "🚫 Задача *#%d* отменена.\n\n~%s~",
+	"todo.reopened":         "🔄 Задача *#%d* снова открыта.\n\n%s *#%d* %s",
+	"todo.text_missing":     "❌ Напишите текст задачи.\nПример: `/add Купить продукты #магазин`",
+	"todo.text_only_tags":   "❌ Кроме тегов нужен текст задачи.\nПример: `/add Купить продукты #магазин`",
+	"todo.added":            "✅ *Задача добавлена!*\n\n🟡 *#%d* — %s%s",
+	"todo.delete_forbidden": "❌ Удалять можно только созданные вами задачи.",
+	"todo.deleted":          "🗑 Задача *#%d* удалена: %s",
+	"list.one_assignee":     "❌ Упомяните только одного исполнителя.",
+	"list.unknown_filter":   "❌ Неизвестный фильтр «%s».\nПример: `/list #тег @user overdue sort:deadline`",
+	"list.filter_overdue":   "просроченные",
+	"list.filter_for":       "для %s",
+	"list.filter_by":        "по %s",
+	"list.heading":          "📋 *Открытые задачи*",
+	"list.no_match":         "Ничего не найдено.",
+	"list.empty":            "📋 *Открытых задач нет!*\n\nДобавьте задачу: `/add <текст>`",
+	"show.status":           "%s Статус: %s",
+	"show.priority":         "⚡ Приоритет: %s",
+	"show.deadline":         "📅 Срок: %s",
+	"show.created":          "👤 Создал(а) %s, %s",
+	"show.assigned":         "🙋 Исполнитель: %s",
+	"show.checklist":        "📝 *Чек-лист*%s",
+	"show.autocomplete":     "Выполнится сама, когда всё будет отмечено.",
+	"show.history":          "📜 *История*",
+	"my.empty":              "📌 *У вас нет открытых задач!*\n\nСоздайте задачу: `/add <текст>`",
+	"my.heading":            "📌 *Ваши задачи*",
+
+	// Checklists
+	"checklist.none":           "📝 У *#%d* %s пока нет чек-листа.\n\nДобавьте пункты: `/sub %d <текст>`",
+	"checklist.show":           "📝 *#%d* %s%s\n%s\n\nОтмечайте пункты: `/check %d.<n>`",
+	"checklist.closed":         "❌ Задача *#%d* %s. Чтобы добавить пункты, откройте её снова: `/reopen %d`.",
+	"checklist.added":          "📝 Добавлено в *#%d* %s%s\n\n⬜ `%d.%d` %s",
+	"checklist.auto_on":        "✅ *#%d* выполнится, когда будет отмечен весь чек-лист.",
+	"checklist.auto_off":       "👌 *#%d* останется открытой, пока кто-нибудь не выполнит `/done %d`.",
+	"checklist.check_usage":    "❌ Укажите номер задачи и номер пункта.\nПример: `/check 12.3`",
+	"checklist.no_item":        "❌ У задачи *#%d* нет пункта %d. Смотрите `/sub %d`.",
+	"checklist.completed_next": "🎉 Всё готово — задача выполнена!\n🔁 Следующая: *#%d*, исполнитель: %s, срок %s",
+	"checklist.completed":      "🎉 Всё готово — задача выполнена!",
+	"checklist.all_ticked":     "✅ Всё отмечено. Завершите задачу: `/done %d`.",
+
+	// Chores
+	"user.not_found":           "❌ Пользователь @%s не найден. Ему нужно хотя бы раз написать мне.",
+	"recurrence.daily":         "ежедневно",
+	"recurrence.weekly":        "еженедельно",
+	"recurrence.monthly":       "ежемесячно",
+	"chore.usage":              "❌ Укажите периодичность и название.\n\nПример: `/chore weekly Вынести мусор @anna @ben`\nПериодичность: daily, weekly, monthly. Упомянутые участники выполняют по очереди в этом порядке.",
+	"chore.rotation_members":   "❌ Все в очереди должны быть участниками этой семьи.",
+	"chore.added":              "🧹 *Дело добавлено!*\n\n%s *#%d* — %s\n🔁 %s: %s\n👉 Первым: %s, срок %s",
+	"chore.not_chore":          "❌ Задача *#%d* не повторяющееся дело. Дела добавляются командой `/chore`.",
+	"chore.rotation_show":      "🔁 *#%d* %s — очередь: %s\n\nИзменить: `/rotation %d @anna @ben`",
+	"chore.rotation_forbidden": "❌ Очередь может менять только автор дела или администратор семьи.",
+	"chore.rotation_set":       "🔁 *#%d* %s — новая очередь: %s",
+	"chores.empty":             "🧹 *Дел пока нет!*\n\nДобавьте: `/chore weekly Вынести мусор @anna @ben`",
+	"chores.heading":           "🧹 *Дела на этой неделе*",
+	"chores.none_this_week":    "На этой неделе ничего.",
+	"chores.later":             "Позже",
+	"chores.footer":            "_Отметьте дело командой_ `/done <id>`_, чтобы передать его дальше._",
+
+	// Shopping list
+	"buy.usage":            "❌ Укажите, что купить.\n\n*Примеры:*\n`/buy Молоко x2`\n`/buy Хлеб цельнозерновой`\n`/buy Сыр #молочное`",
+	"buy.list_name":        "Список покупок",
+	"buy.added":            "🛒 *Добавлено в список покупок!*\n\n⬜ *#%d* — %s%s",
+	"buy.no_list":          "🛒 *Списка покупок пока нет!*\n\nНачните его: `/buy <товар>`",
+	"buy.id_invalid":       "❌ Неверный номер. Укажите номер товара цифрами.",
+	"buylist.empty":        "🛒 *Список покупок пуст!*\n\nДобавьте товары: `/buy <товар>`",
+	"buylist.heading":      "🛒 *Список покупок*",
+	"buylist.bought_by":    "купил(а) %s",
+	"buylist.count":        "осталось: %d, куплено: %d",
+	"buylist.clear_hint":   "_Команда_ `/buyclear` _перенесёт купленное в историю_",
+	"bought.usage":         "❌ Укажите номер товара.\nПример: `/bought 3` или `/bought 3 4.99`",
+	"bought.price_invalid": "❌ Неверная цена. Пример: `/bought 3 4.99`",
+	"bought.failed":        "❌ Не удалось отметить товар *#%d* как купленный. Возможно, его нет.",
+	"bought.done":          "✅ Товар *#%d* куплен!",
+	"buyclear.no_list":     "❌ В этом чате нет списка покупок.",
+	"buyclear.nothing":     "ℹ️ Купленных товаров нет.",
+	"buyclear.done":        "🧹 Купленное убрано из списка покупок!\n\n🧾 *Поход #%d* — %s, итого %.2f\n\n_Отправьте фото с подписью_ `/receipt`_, чтобы прикрепить чек; расходы —_ `/spent`",
+	"spent.usage":          "❌ Укажите число месяцев от 1 до 24.\nПример: `/spent 3`",
+	"spent.none":           "💰 *С %s покупок не было.*\n\nЦены записываются так: `/bought <id> <цена>`",
+	"spent.heading":        "💰 *Расходы с %s*",
+	"spent.total":          "*Итого:* %.2f (%s)",
+	"spent.per_month":      "📆 *По месяцам*",
+	"spent.per_member":     "👤 *По участникам*",
+	"spent.per_category":   "🏷 *По категориям*",
+
+	// Calendar
+	"event.all_day":          "%s (весь день)",
+	"event.at":               "%s в %s",
+	"event.usage":            "❌ Укажите название и дату.\n\n*Примеры:*\n`/event Встреча 2025-01-15 14:00`\n`/event День рождения 2025-03-20`",
+	"event.no_date":          "❌ В команде нет даты.\nИспользуйте формат `ГГГГ-ММ-ДД`.\nПример: `/event Встреча 2025-01-15 14:00`",
+	"event.no_title":         "❌ Укажите название события перед датой.",
+	"event.bad_date":         "❌ Неверный формат даты или времени.\nДата: `ГГГГ-ММ-ДД`, время: `ЧЧ:ММ`",
+	"event.created":          "📅 *Событие создано!*\n\n*#%d* — %s\n📆 %s",
+	"event.ask_title":        "📅 *Новое событие*\nКак оно называется?\n\nОтправьте /cancel, чтобы отменить.",
+	"event.ask_when":         "📆 Когда *%s*?\nВыберите день и время или ответьте в формате `ГГГГ-ММ-ДД` или `ГГГГ-ММ-ДД ЧЧ:ММ`.",
+	"event.bad_when":         "❌ Не удалось разобрать дату. Ответьте в формате `ГГГГ-ММ-ДД` или `ГГГГ-ММ-ДД ЧЧ:ММ` или выберите день выше.",
+	"event.ask_location":     "📍 Где? Ответьте, указав место, или пропустите.",
+	"event.all_day_button":   "🗓 Весь день",
+	"events.empty":           "📅 *Ближайших событий нет!*\n\nДобавьте: `/event <название> <дата> [время]`",
+	"events.heading":         "📅 *Ближайшие события*",
+	"events.occasion":        "праздник #%d",
+	"delevent.usage":         "❌ Укажите номер события.\nПример: `/delevent 3`",
+	"event.id_invalid":       "❌ Неверный номер. Укажите номер события цифрами.",
+	"event.not_found":        "❌ Событие *#%d* не найдено.",
+	"event.not_in_chat":      "❌ Событие *#%d* не найдено в этом чате.",
+	"event.delete_forbidden": "❌ Удалять можно только созданные вами события.",
+	"event.deleted":          "🗑 Событие *#%d* удалено: %s",
+
+	// Occasion reminders
+	"occasion.birthday_of":  "День рождения: %s",
+	"occasion.today":        "уже сегодня!",
+	"occasion.tomorrow":     "завтра",
+	"occasion.their":        "именинника",
+	"occasion.whose":        "%s",
+	"occasion.all_taken":    "🎁 Из списка желаний (%s) всё уже разобрали.",
+	"occasion.still_free":   "🎁 Ещё свободно в списке желаний (%s):\n%s",
+	"occasion.reserve_hint": "Забронируйте подарок: `/reserve <id>` или скиньтесь: `/pledge <id> <сумма>`.",
+	"reminder.heading":      "Напоминание",
+
+	// Occasions
+	"birthday.usage":            "❌ Укажите дату рождения.\n\n*Примеры:*\n`/birthday 1990-05-14`\n`/birthday 14.05 @anna days:14`\n\n_Напоминание приходит за 7 дней, если не указано `days:N`._",
+	"birthday.saved":            "🎂 *День рождения сохранён!*\n\n*#%d* %s\n📆 %s\n\n_Остальным придёт напоминание за %s._",
+	"occasion.usage":            "❌ Укажите дату и название.\n\n*Примеры:*\n`/occasion 01-01 Новый год`\n`/occasion 08.03 8 Марта days:3`",
+	"occasion.added":            "🎉 *Праздник добавлен!*\n\n*#%d* %s\n📆 %s\n\nПривяжите к нему свой список желаний: `/wishfor %d`.",
+	"occasions.empty":           "🎉 *Праздников пока нет!*\n\nДобавьте день рождения: `/birthday <дата>` или праздник: `/occasion <дата> <название>`",
+	"occasions.heading":         "🎉 *Ближайшие праздники*",
+	"occasions.footer":          "_Привязать список желаний:_ `/wishfor <id>`",
+	"deloccasion.usage":         "❌ Укажите номер праздника.\nПример: `/deloccasion 3`",
+	"occasion.id_invalid":       "❌ Неверный номер. Укажите номер праздника цифрами.",
+	"occasion.not_in_chat":      "❌ Праздник *#%d* не найден в этом чате.",
+	"occasion.delete_forbidden": "❌ Удалять можно только добавленные вами праздники или свой день рождения.",
+	"occasion.deleted":          "🗑 Праздник *#%d* удалён.",
+	"wishfor.usage":             "❌ Укажите номер праздника.\n\nПример: `/wishfor 3` или `/wishfor off`\nНомера — в `/occasions`.",
+	"wishfor.unlinked":          "🎁 Ваш список желаний больше не привязан к празднику.",
+	"wishfor.linked":            "🎁 Ваш список желаний теперь к празднику *%s* (%s).",
+
+	// Wish lists
+	"wish.usage":                 "❌ Укажите желание.\nПример: `/wish PlayStation 5`\nили `/wish https://shop.example/item` _— название и цена подставятся со страницы_",
+	"wish.added":                 "🎁 *Добавлено в ваш список желаний!*\n\n*#%d* — %s",
+	"wish.link":                  "ссылка",
+	"wish.fix_hint":              "_Поправить данные:_ `/editwish %d name:… price:…`",
+	"wish.most_wanted":           "очень хочу",
+	"wish.purchased":             "куплено",
+	"wish.by_you":                "вами",
+	"wish.by":                    "%s",
+	"wish.id_missing":            "❌ Укажите номер желания.\n\nПример: `/%s 5`",
+	"wish.not_found":             "❌ Желание *#%d* не найдено.",
+	"wish.purchased_already":     "❌ Подарок *#%d* уже куплен.",
+	"wishlist.owner":             "Желания: %s",
+	"wishlist.none":              "🎁 *Списков желаний пока нет!*\n\nДобавьте желание: `/wish <что хочется>`",
+	"wishlist.family_heading":    "🎁 *Списки желаний семьи*",
+	"wishlist.family_footer":     "_Список одного человека:_ `/wishlist @username`",
+	"wishlist.empty_mark":        "пусто",
+	"wishlist.reserved_hint":     "Что-то отсюда уже забронировали",
+	"wishlist.own_reserved_hint": "Что-то из вашего списка уже забронировали",
+	"wishlist.own_none":          "🎁 *У вас пока нет списка желаний.*\n\nСоздайте его: `/wish <что хочется>`",
+	"wishlist.other_none":        "🎁 *У %s пока нет списка желаний.*",
+	"wishlist.own_empty":         "🎁 *Ваш список желаний пуст!*\n\nДобавьте: `/wish <что хочется>`",
+	"wishlist.other_empty":       "🎁 *Список желаний (%s) пуст.*",
+	"wishlist.other_heading":     "🎁 *Список желаний: %s*",
+	"wishlist.own_heading":       "🎁 *Ваш список желаний*",
+	"wishlist.private_hint":      "_Брони видны только в личном чате со мной._",
+	"wishlist.reserve_hint":      "_Забронировать подарок:_ `/reserve <id>`",
+	"reserve.usage":              "❌ Укажите номер желания.\n\nПример: `/reserve 5`\n\n_Сначала посмотрите список:_ `/wishlist @username`",
+	"reserve.failed":             "❌ Не удалось забронировать *#%d*.\nВозможно, его нет или его уже забронировали.",
+	"reserve.own":                "❌ Нельзя бронировать желания из своего списка.",
+	"reserve.done":               "🔒 *#%d* забронировано!\n\n_Владелец не узнает, кто это сделал._",
+	"reserve.done_public":        "🔒 Забронировано! _Владелец не узнает, кто это сделал._",
+	"wishhint.usage":             "❌ Выберите on или off.\n\nПример: `/wishhint on`\n\n_Если включено, список желаний сообщит, что что-то забронировали, не раскрывая что и кто._",
+	"wishhint.on":                "✨ Подсказка включена. `/wishlist` сообщит, когда что-то забронируют.",
+	"wishhint.off":               "🙈 Подсказка выключена. Вы не увидите ничего о бронированиях.",
+	"unreserve.not_yours":        "❌ Вы не бронировали *#%d*.",
+	"unreserve.pledged":          "❌ На этот подарок уже скидываются другие, поэтому организатор не может отказаться.",
+	"unreserve.done":             "🔓 Бронь *#%d* снята.",
+	"unreserve.done_public":      "🔓 Бронь снята.",
+	"delwish.forbidden":          "❌ Удалять можно только желания из своего списка.",
+	"delwish.done":               "🗑 Желание *#%d* удалено.",
+	"editwish.usage":             "*Примеры:*\n`/editwish 5 price:49.99`\n`/editwish 5 url:https://shop.example/item notes:синий, размер M`\n`/editwish 5 priority:1` — отметить как самое желанное\n\n_Поля: name, price, url, notes, priority. Пустое значение очищает поле._",
+	"editwish.missing":           "❌ Укажите номер желания и поля, которые нужно изменить.",
+	"editwish.unexpected":        "непонятно \"%s\", пишите поле:значение",
+	"editwish.name_empty":        "название не может быть пустым",
+	"editwish.url_invalid":       "ссылка должна начинаться с http:// или https://",
+	"editwish.priority_invalid":  "приоритет — число от 0 до 99",
+	"editwish.forbidden":         "❌ Изменять можно только желания из своего списка.",
+	"editwish.done":              "✏️ *Желание обновлено!*\n\n*#%d* %s%s",
+
+	// Group gifts
+	"pledge.pledged":           "собрано %.2f",
+	"pledge.usage":             "❌ Укажите номер желания и сумму.\n\nПример: `/pledge 5 30`\n\n_Повторный вызов меняет сумму, `/unpledge 5` отменяет взнос._",
+	"pledge.amount_invalid":    "❌ Неверная сумма. Укажите положительное число, например `30` или `12.50`.",
+	"pledge.own":               "❌ Нельзя скидываться на своё желание.",
+	"pledge.done":              "💰 Вы вносите *%.2f* на *#%d* %s",
+	"pledge.organizer":         "_Вы организатор подарка. Когда купите, выполните_ `/purchased %d [сумма]`",
+	"pledge.organized_by":      "Организатор: %s",
+	"pledge.done_public":       "💰 Взнос сохранён! _Владелец его не увидит._",
+	"unpledge.not_yours":       "❌ Вы не скидывались на *#%d*.",
+	"unpledge.done":            "↩️ Ваш взнос на *#%d* отменён.",
+	"unpledge.done_public":     "↩️ Взнос отменён.",
+	"purchased.usage":          "❌ Укажите номер желания.\n\nПример: `/purchased 5` или `/purchased 5 89.90`\n\n_Без суммы берётся сумма взносов._",
+	"purchased.amount_invalid": "❌ Неверная сумма. Укажите число, например `89.90`.",
+	"purchased.forbidden":      "❌ Отметить подарок купленным может только организатор, который его забронировал.",
+	"purchased.heading":        "🎉 *Подарок куплен:* #%d %s",
+	"purchased.paid_by":        "Заплатил(а) %s: *%.2f*",
+	"purchased.pledged":        "собрано %.2f",
+	"purchased.nothing":        "_Больше никто не скидывался, рассчитываться не нужно._",
+	"purchased.settle":         "*Расчёт:*",
+	"purchased.owes":           "%s должен(а) %s *%.2f*",
+	"purchased.done_public":    "🎉 Подарок куплен! _Участникам сообщили, сколько они должны._",
+	"purchased.share":          "🎁 %s купил(а) *%s*.\nВаша доля: *%.2f* — не забудьте рассчитаться.",
+
+	// Reminders
+	"remind.overdue":          "%s (просрочено)",
+	"remind.in_hours":         "через %d ч %d мин (%s)",
+	"remind.in_minutes":       "через %d мин (%s)",
+	"remind.in_moment":        "меньше чем через минуту (%s)",
+	"remind.usage":            "❌ Укажите время и текст напоминания.\n\n*Примеры:*\n`/remind 10m Вынести мусор`\n`/remind 2h Позвонить стоматологу`\n`/remind 1d Оплатить счета`\n`/remind 15:30 Забрать детей`\n`/remind 2025-12-31 15:30 Новогодняя вечеринка`",
+	"remind.bad_time":         "❌ Не удалось разобрать время.\n\nФорматы: `10m`, `2h`, `1d`, `15:30`, `2025-12-31 15:30`",
+	"remind.no_text":          "❌ Укажите текст напоминания после времени.",
+	"remind.set":              "⏰ *Напоминание создано!*\n\n*#%d* — %s\n📅 %s",
+	"remind.ask_text":         "⏰ *Новое напоминание*\nО чём напомнить?\n\nОтправьте /cancel, чтобы отменить.",
+	"remind.ask_when":         "⏰ Когда напомнить про *%s*?\nВыберите день и время или ответьте в формате `10m`, `2h`, `15:30` или `2025-12-31 15:30`.",
+	"remind.bad_when":         "❌ Не удалось разобрать время, или оно уже прошло. Ответьте в формате `10m`, `2h`, `15:30` или `2025-12-31 15:30` или выберите выше.",
+	"reminders.empty":         "⏰ *Активных напоминаний нет!*\n\nСоздайте: `/remind <время> <текст>`",
+	"reminders.heading":       "⏰ *Ваши напоминания*",
+	"reminders.footer":        "_Удалить:_ `/delremind <id>`",
+	"delremind.usage":         "❌ Укажите номер напоминания.\nПример: `/delremind 3`",
+	"remind.id_invalid":       "❌ Неверный номер. Укажите номер напоминания цифрами.",
+	"remind.not_found":        "❌ Напоминание *#%d* не найдено.",
+	"remind.delete_forbidden": "❌ Удалять можно только свои напоминания.",
+	"remind.deleted":          "🗑 Напоминание *#%d* удалено: %s",
+
+	// Receipts
+	"receipt.usage":          "❌ Отправьте фото чека с этой командой в подписи или ответьте ею на фото.\n\n*Примеры:*\n`/receipt` — к последнему походу в магазин\n`/receipt 12` — к купленному товару #12\n`/receipt trip 3` — к походу в магазин #3",
+	"receipt.no_list":        "❌ В этом чате нет списка покупок.",
+	"receipt.no_trip":        "ℹ️ Завершённых походов в магазин пока нет. Завершите поход командой `/buyclear` или прикрепите чек к товару: `/receipt <номер>`.",
+	"receipt.trip_label":     "походу в магазин #%d",
+	"receipt.item_label":     "товару #%d",
+	"receipt.trip_missing":   "❌ Укажите номер похода: `/receipt trip 3`",
+	"receipt.trip_invalid":   "❌ Неверный номер похода. Укажите число.",
+	"receipt.trip_not_found": "❌ Поход в магазин #%d не найден в этом чате.",
+	"receipt.item_invalid":   "❌ Неверный номер товара. Укажите число.",
+	"receipt.item_not_found": "❌ Товар #%d не найден в этом чате.",
+	"receipt.not_bought":     "❌ Товар #%d ещё не куплен. Сначала отметьте: `/bought %d`.",
+	"receipt.attached":       "🧾 Чек прикреплён к %s.",
+
+	// Pages
+	"page.of":   "Страница %d из %d",
+	"page.prev": "◀️ Назад",
+	"page.next": "Далее ▶️",
+
+	// Search
+	"search.group.todo":     "📋 *Задачи*",
+	"search.group.event":    "📅 *События*",
+	"search.group.buying":   "🛒 *Список покупок*",
+	"search.group.wish":     "🎁 *Желания*",
+	"search.group.reminder": "⏰ *Напоминания*",
+	"search.usage":          "❌ Что искать?\nПример: `/search стоматолог`",
+	"search.empty_query":    "❌ Ищите слова или числа.",
+	"search.nothing":        "🔎 По запросу `%s` ничего не найдено.",
+	"search.heading":        "🔎 *Поиск:* `%s`\n_%d–%d из %d_",
+
+	// Families
+	"family.none":          "👨‍👩‍👧 У этого чата пока нет семьи. Добавьте задачу или покупку, чтобы её создать.",
+	"family.belongs":       "👨‍👩‍👧 Этот чат принадлежит семье *%s*.\n\nОтправьте /family мне в личку, чтобы работать с ней оттуда.",
+	"family.private_lists": "ваши личные списки",
+	"family.switcher":      "👨‍👩‍👧 *Ваши семьи*\n\nКоманды здесь работают с: %s. Выберите другую семью ниже.",
+
+	// Family roles and links
+	"role.lookup_failed": "❌ Не удалось найти этого пользователя.",
+	"role.usage":         "❌ Упомяните участника или ответьте на его сообщение.\n\nПример: `/%s @username`",
+	"role.no_family":     "❌ У этого чата пока нет семьи.",
+	"role.forbidden":     "❌ Менять роли могут только администраторы семьи.",
+	"role.not_member":    "❌ %s не состоит в этой семье.",
+	"role.last_admin":    "❌ В семье должен быть хотя бы один администратор. Сначала назначьте кого-то другого.",
+	"role.admin":         "⭐ %s теперь администратор семьи.",
+	"role.member":        "👤 %s теперь обычный участник.",
+	"link.forbidden":     "❌ Связывать чаты могут только администраторы семьи.",
+	"link.code":          "🔗 Код для семьи *%s*: `%s`\n\nОтправьте `/join %s` в другом чате, например в личке со мной. Код одноразовый и действует до %s.",
+	"join.usage":         "❌ Укажите код.\n\nПример: `/join <код>`\nКод можно получить командой /link в чате семьи.",
+	"join.forbidden":     "❌ Привязать этот чат к другой семье могут только администраторы его семьи.",
+	"join.invalid":       "❌ Код неверный, просрочен или уже использован. Попросите новый через /link.",
+	"join.done":          "🔗 Этот чат теперь в семье *%s*. Задачи, список покупок, календарь и напоминания общие.",
+	"join.already":       "🔗 Этот чат уже в семье *%s*.",
+
+	// Membership
+	"member.bot_added": "👋 Привет, *%s*! Я веду задачи семьи, список покупок, календарь и списки желаний.\n\nАдминистраторы группы — администраторы семьи. Начните с /help.",
+	"member.joined":    "👋 Добро пожаловать в *%s*, %s! Что я умею — в /help.",
+	"member.left":      "👋 %s покинул(а) семью.",
+	"member.new_admin": "%s — новый администратор семьи",
+
+	// Conversations
+	"dialog.cancelled":     "🚫 Отменено.",
+	"dialog.expired":       "⌛ Время на ответ вышло. Начните заново.",
+	"dialog.skip_button":   "Пропустить ➡",
+	"dialog.cancel_button": "✖ Отмена",
+
+	// Deadlines
+	"deadline.pick":       "📅 Когда срок у *#%d* %s?",
+	"deadline.usage":      "❌ Укажите дату.\n\n*Примеры:*\n`/deadline 5` - выбрать дату\n`/deadline 5 2025-03-15 18:00`\n`/deadline 5 off` - убрать срок",
+	"deadline.set":        "📅 Срок *#%d* %s: %s.",
+	"deadline.removed":    "📅 У *#%d* %s больше нет срока.",
+	"deadline.end_of_day": "🌙 До конца дня",
+	"deadline.off_button": "🗑 Без срока",
+
+	// Snoozing reminders
+	"snooze.15m":       "💤 15 мин",
+	"snooze.1h":        "💤 1 ч",
+	"snooze.3h":        "💤 3 ч",
+	"snooze.1d":        "💤 1 день",
+	"snooze.pick":      "📅 Отложить до…",
+	"snooze.back":      "« Назад",
+	"snooze.forbidden": "❌ Откладывать можно только свои напоминания.",
+	"snooze.done":      "💤 Напоминание *#%d* отложено.\n📅 %s",
+
+	// Inline mode
+	"inline.open_bot":   "Ничего не найдено — открыть TodoboT",
+	"inline.todo_card":  "📋 *%s*\n%s\n👨‍👩‍👧 %s",
+	"inline.buy_card":   "🛒 *%s*\n%s\n👨‍👩‍👧 %s",
+	"inline.wish_card":  "🎁 *%s*\n%s\n👨‍👩‍👧 %s",
+	"inline.wish_of":    "В списке желаний: %s",
+	"inline.buy_needed": "Нужно купить",
+	"inline.buy_bought": "Куплено",
+	"inline.add_button": "🛒 Добавить в мой список покупок",
+	"inline.added":      "✅ %s добавил(а) в список",
+
+	// Capturing plain messages
+	"capture.status_on":      "📝 Я предлагаю сохранить покупки, задачи и напоминания, написанные здесь без команды.",
+	"capture.status_off":     "📝 Здесь я отвечаю только на команды.",
+	"capture.usage":          "Включить или выключить: `/capture on` или `/capture off`. В группах я вижу сообщения без команд, только если я администратор или у меня выключен режим приватности.",
+	"capture.on":             "✅ Теперь я предлагаю сохранить то, что здесь пишут, например «нужно молоко и хлеб» или «напомни завтра в 8 утра позвонить бабушке». Без подтверждения ничего не сохраняется.",
+	"capture.off":            "✅ Я больше не читаю здесь сообщения без команд.",
+	"capture.forbidden":      "❌ Включать и выключать это могут только администраторы семьи.",
+	"capture.buy_offer":      "🛒 Добавить в список покупок: %s?",
+	"capture.todo_offer":     "📋 Добавить задачу: *%s*?",
+	"capture.remind_offer":   "⏰ Напомнить: *%s* — %s?",
+	"capture.confirm_button": "✅ Да",
+	"capture.dismiss_button": "✖️ Нет",
+	"capture.gone":           "❌ Сообщение удалено, сохранять нечего.",
+	"capture.past":           "❌ Это время уже прошло.",
+	"capture.buy_added":      "🛒 *Добавлено в список покупок!*\n\n%s",
+
+	// Attached media
+	"media.photo":    "Фото",
+	"media.voice":    "Голосовое сообщение",
+	"media.document": "Документ",
+}
+
+// ruPlurals holds the Russian messages that depend on a count: one (1, 21,
+// ...), few (2-4, 22-24, ...) and many (0, 5-20, ...).
+var ruPlurals = map[string][]string{
+	// Todos
+	"list.count": {"%d открытая задача", "%d открытые задачи", "%d открытых задач"},
+	"my.count":   {"%d ваша задача", "%d ваши задачи", "%d ваших задач"},
+
+	// Shopping list
+	"common.items": {"%d товар", "%d товара", "%d товаров"},
+
+	// Calendar
+	"events.count": {"%d событие впереди", "%d события впереди", "%d событий впереди"},
+
+	// Occasion reminders
+	"occasion.in_days": {"через %d день", "через %d дня", "через %d дней"},
+
+	// Occasions
+	"birthday.remind_days": {"%d день", "%d дня", "%d дней"},
+
+	// Reminders
+	"reminders.count": {"%d активное напоминание", "%d активных напоминания", "%d активных напоминаний"},
+
+	// Membership
+	"member.left_todos":     {"%d задача теперь без исполнителя — см. /list", "%d задачи теперь без исполнителя — см. /list", "%d задач теперь без исполнителя — см. /list"},
+	"member.left_wishes":    {"%d бронь желания снята или передана", "%d брони желаний сняты или переданы", "%d броней желаний снято или передано"},
+	"member.left_reminders": {"%d напоминание остановлено", "%d напоминания остановлены", "%d напоминаний остановлено"},
+}
+
+// russianDates translates the weekday and month names of time.Format. Full
+// names come first so that "March" is not read as "Mar" + "ch"; months are
+// in the genitive case, as they follow the day.
+var russianDates = strings.NewReplacer(
+	"Monday", "понедельник", "Tuesday", "вторник", "Wednesday", "среда",
+	"Thursday", "четверг", "Friday", "пятница", "Saturday", "суббота", "Sunday", "воскресенье",
+	"January", "января", "February", "февраля", "March", "марта", "April", "апреля",
+	"May", "мая", "June", "июня", "July", "июля", "August", "августа",
+	"September", "сентября", "October", "октября", "November", "ноября", "December", "декабря",
+	"Mon", "пн", "Tue", "вт", "Wed", "ср", "Thu", "чт", "Fri", "пт", "Sat", "сб", "Sun", "вс",
+	"Jan", "янв", "Feb", "фев", "Mar", "мар", "Apr", "апр", "Jun", "июн",
+	"Jul", "июл", "Aug", "авг", "Sep", "сен", "Oct", "окт", "Nov", "ноя", "Dec", "дек",
+)
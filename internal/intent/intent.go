@@ -0,0 +1,222 @@
+// Package intent recognises things to buy, do or be reminded of in plain
+// chat messages, such as "we need milk and bread" or "remind me tomorrow
+// 8am to call grandma".
+//
+// Recognition is rule based: every supported language has keyword patterns
+// for each kind of intent and words for days and times. Nothing is sent
+// anywhere, so it works offline and only ever finds what the rules say.
+package intent
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Kind is what a message asks for.
+type Kind string
+
+// Kinds of intents.
+const (
+	Buying Kind = "buying"
+	Remind Kind = "remind"
+	Todo   Kind = "todo"
+)
+
+// MaxLength is the longest message, in characters, that is looked at.
+// Longer ones are conversation rather than notes.
+const MaxLength = 200
+
+// defaultHour is when reminders for a day without a time go off.
+const defaultHour = 9
+
+// Intent is what a message asks for.
+type Intent struct {
+	Kind Kind
+	// Items are the things to buy.
+	Items []string
+	// Text is the todo's title or what to remind of.
+	Text string
+	// At is when to remind.
+	At time.Time
+}
+
+// Languages returns the codes of the languages with rules, sorted.
+func Languages() []string {
+	var codes []string
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// Parse returns what the message text asks for, read with the rules of
+// lang, or nil if they recognise nothing in it. now is when the message was
+// written; reminder times are relative to it.
+func Parse(lang, text string, now time.Time) *Intent {
+	r, ok := languages[lang]
+	if !ok {
+		return nil
+	}
+	text = trim(strings.Join(strings.Fields(text), " "))
+	if text == "" || utf8.RuneCountInString(text) > MaxLength {
+		return nil
+	}
+
+	for _, p := range r.patterns {
+		m := p.re.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+
+		switch p.kind {
+		case Buying:
+			if items := r.items(m[1]); len(items) > 0 {
+				return &Intent{Kind: Buying, Items: items}
+			}
+		case Todo:
+			if title := trim(m[1]); title != "" {
+				return &Intent{Kind: Todo, Text: title}
+			}
+		case Remind:
+			at, rest, ok := r.when(m[1], now)
+			if !ok {
+				continue
+			}
+			rest = trim(strings.Join(strings.Fields(rest), " "))
+			rest = trim(r.connector.ReplaceAllString(rest, ""))
+			if rest != "" {
+				return &Intent{Kind: Remind, Text: rest, At: at}
+			}
+		}
+	}
+	return nil
+}
+
+// items splits a list of things to buy.
+func (r *rules) items(list string) []string {
+	var items []string
+	for _, item := range r.separator.Split(list, -1) {
+		if item = trim(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// when finds the time to remind at in text, relative to now. It returns
+// the time, text without it and whether there was one.
+func (r *rules) when(text string, now time.Time) (time.Time, string, bool) {
+	if g, rest := find(r.relative, text); g != nil {
+		count := 1
+		if g[1] != "" {
+			var ok bool
+			if count, ok = r.counts[g[1]]; !ok {
+				count, _ = strconv.Atoi(g[1])
+			}
+		}
+		unit := r.unit(g[2])
+		if count <= 0 || unit == 0 {
+			return time.Time{}, text, false
+		}
+		return now.Add(time.Duration(count) * unit), rest, true
+	}
+
+	dayGroups, rest := find(r.days, text)
+	var clockGroups []string
+	for _, re := range r.clocks {
+		if clockGroups, rest = find(re, rest); clockGroups != nil {
+			break
+		}
+	}
+	if dayGroups == nil && clockGroups == nil {
+		return time.Time{}, text, false
+	}
+
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	hour, minute := defaultHour, 0
+	var d day
+	if dayGroups != nil {
+		d = r.dayWords[dayGroups[1]]
+		if d.weekly {
+			date = date.AddDate(0, 0, (int(d.weekday)-int(date.Weekday())+7)%7)
+		} else {
+			date = date.AddDate(0, 0, d.offset)
+		}
+		if d.hour != 0 {
+			hour = d.hour
+		}
+		if h, ok := r.partsOfDay[dayGroups[2]]; ok {
+			hour = h
+		}
+	}
+
+	if clockGroups != nil {
+		evening := hour >= 12
+		hour, _ = strconv.Atoi(clockGroups[1])
+		minute, _ = strconv.Atoi(clockGroups[2])
+		if meridiem := strings.ReplaceAll(clockGroups[3], ".", ""); meridiem != "" {
+			if hour == 0 || hour > 12 {
+				return time.Time{}, text, false
+			}
+			hour %= 12
+			if r.pm[meridiem] {
+				hour += 12
+			}
+		} else if evening && dayGroups != nil && hour < 12 {
+			// "tonight at 8"
+			hour += 12
+		}
+		if hour > 23 || minute > 59 {
+			return time.Time{}, text, false
+		}
+	}
+
+	at := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location())
+	if !at.After(now) {
+		switch {
+		case dayGroups == nil:
+			at = at.AddDate(0, 0, 1)
+		case d.weekly:
+			at = at.AddDate(0, 0, 7)
+		default:
+			return time.Time{}, text, false
+		}
+	}
+	return at, rest, true
+}
+
+// unit returns the length of the time unit named by word.
+func (r *rules) unit(word string) time.Duration {
+	for prefix, d := range r.units {
+		if strings.HasPrefix(word, prefix) {
+			return d
+		}
+	}
+	return 0
+}
+
+// find returns the groups of the first match of re in text, lower-cased,
+// and text without the match. The groups are nil if there is none.
+func find(re *regexp.Regexp, text string) ([]string, string) {
+	loc := re.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return nil, text
+	}
+	groups := make([]string, len(loc)/2)
+	for i := range groups {
+		if loc[2*i] >= 0 {
+			groups[i] = strings.ToLower(text[loc[2*i]:loc[2*i+1]])
+		}
+	}
+	return groups, text[:loc[0]] + " " + text[loc[1]:]
+}
+
+// trim removes surrounding spaces and closing punctuation.
+func trim(s string) string {
+	return strings.TrimRight(strings.TrimSpace(s), " .!?…")
+}
@@ -0,0 +1,144 @@
+package intent
+
+import (
+	"regexp"
+	"time"
+)
+
+// rules are the words of a language that intents are recognised by.
+type rules struct {
+	// patterns are tried in order. The first group of a match holds the
+	// things to buy, the todo, or what to remind of together with when.
+	patterns []pattern
+	// separator splits lists of things to buy.
+	separator *regexp.Regexp
+	// connector starts what to remind of, e.g. "to" in "remind me
+	// tomorrow to call grandma".
+	connector *regexp.Regexp
+
+	// relative is a time from now, e.g. "in 2 hours": group 1 is the
+	// count, which may be missing or a word in counts, and group 2 the
+	// unit, starting with a prefix in units.
+	relative *regexp.Regexp
+	counts   map[string]int
+	units    map[string]time.Duration
+
+	// days names a day: group 1 is a word in dayWords and group 2 an
+	// optional part of the day in partsOfDay.
+	days       *regexp.Regexp
+	dayWords   map[string]day
+	partsOfDay map[string]int
+
+	// clocks are times of day, tried in order: group 1 is the hour,
+	// group 2 the minute and group 3 a meridiem word in pm; the last two
+	// may be empty.
+	clocks []*regexp.Regexp
+	pm     map[string]bool
+}
+
+// pattern recognises one way of saying an intent.
+type pattern struct {
+	kind Kind
+	re   *regexp.Regexp
+}
+
+// day is a day named relative to today or by its weekday.
+type day struct {
+	offset  int
+	weekday time.Weekday
+	weekly  bool
+	// hour is when to remind on the day if no time is given, or 0 for
+	// defaultHour.
+	hour int
+}
+
+// languages holds the rules of each supported language.
+var languages = map[string]*rules{
+	"en": {
+		patterns: []pattern{
+			{Remind, regexp.MustCompile(`(?i)^(?:please\s+)?remind\s+(?:me|us|everyone|all)\s+(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:(?:we|i)\s+)?(?:still\s+)?(?:need|have|must|should)\s+to\s+(?:buy|get)\s+(?:some\s+|more\s+)?(.+)$`)},
+			{Todo, regexp.MustCompile(`(?i)^(?:(?:we|i)\s+)?(?:still\s+)?(?:need|have|must|should)\s+to\s+(.+)$`)},
+			{Todo, regexp.MustCompile(`(?i)^(?:don['’]?t|do\s+not)\s+forget\s+(?:to\s+)?(.+)$`)},
+			{Todo, regexp.MustCompile(`(?i)^(?:todo|to-do|to\s+do)\s*:\s*(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:(?:we|i)\s+)?(?:still\s+)?need\s+(?:some\s+|more\s+)?(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:please\s+)?buy\s+(?:some\s+|more\s+)?(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:(?:we['’]re|we\s+are|i['’]m|i\s+am)\s+)?(?:all\s+)?out\s+of\s+(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:we|i)\s+ran\s+out\s+of\s+(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^add\s+(.+?)\s+to\s+(?:the\s+|our\s+|my\s+)?shopping\s+list$`)},
+		},
+		separator: regexp.MustCompile(`(?i)\s*,\s*(?:and\s+)?|\s+and\s+|\s*[&+;]\s*`),
+		connector: regexp.MustCompile(`(?i)^(?:to|that|about)\s+`),
+
+		relative: regexp.MustCompile(`(?i)(?:^|\s)in\s+(?:(\d+|an?|one|two|three|a\s+couple\s+of)\s+)?(minutes?|mins?|hours?|hrs?|days?|weeks?)(?:\s|$)`),
+		counts:   map[string]int{"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "a couple of": 2},
+		units:    map[string]time.Duration{"min": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour},
+
+		days: regexp.MustCompile(`(?i)(?:^|\s)(?:on\s+)?(the\s+day\s+after\s+tomorrow|day\s+after\s+tomorrow|today|tonight|tomorrow|monday|tuesday|wednesday|thursday|friday|saturday|sunday)(?:\s+(morning|afternoon|evening|night))?(?:\s|$)`),
+		dayWords: map[string]day{
+			"today":                  {},
+			"tonight":                {hour: 20},
+			"tomorrow":               {offset: 1},
+			"day after tomorrow":     {offset: 2},
+			"the day after tomorrow": {offset: 2},
+			"monday":                 {weekday: time.Monday, weekly: true},
+			"tuesday":                {weekday: time.Tuesday, weekly: true},
+			"wednesday":              {weekday: time.Wednesday, weekly: true},
+			"thursday":               {weekday: time.Thursday, weekly: true},
+			"friday":                 {weekday: time.Friday, weekly: true},
+			"saturday":               {weekday: time.Saturday, weekly: true},
+			"sunday":                 {weekday: time.Sunday, weekly: true},
+		},
+		partsOfDay: map[string]int{"morning": 9, "afternoon": 14, "evening": 19, "night": 21},
+
+		clocks: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)(?:^|\s)(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*([ap]\.?m\.?)(?:\s|$)`),
+			regexp.MustCompile(`(?i)(?:^|\s)(?:at\s+)?(\d{1,2}):(\d{2})()(?:\s|$)`),
+			regexp.MustCompile(`(?i)(?:^|\s)at\s+(\d{1,2})()()(?:\s|$)`),
+		},
+		pm: map[string]bool{"pm": true},
+	},
+
+	"ru": {
+		patterns: []pattern{
+			{Remind, regexp.MustCompile(`(?i)^(?:пожалуйста\s+)?напомни(?:те)?\s+(?:(?:мне|нам|всем)\s+)?(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:(?:нам|мне)\s+)?(?:ещё\s+|еще\s+)?(?:надо|нужно)\s+(?:ещё\s+|еще\s+)?купить\s+(.+)$`)},
+			{Todo, regexp.MustCompile(`(?i)^(?:(?:нам|мне)\s+)?(?:ещё\s+|еще\s+)?(?:надо|нужно)\s+(\S+(?:ть|ти|чь|ться|тись|чься)(?:\s.*)?)$`)},
+			{Todo, regexp.MustCompile(`(?i)^не\s+(?:забыть|забудь(?:те)?)\s+(.+)$`)},
+			{Todo, regexp.MustCompile(`(?i)^(?:задача|сделать)\s*:\s*(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:(?:нам|мне)\s+)?(?:ещё\s+|еще\s+)?(?:надо|нужно|нужен|нужна|нужны)\s+(?:ещё\s+|еще\s+)?(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:пожалуйста\s+)?купи(?:те|ть)?\s+(?:пожалуйста\s+)?(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(?:у\s+нас\s+)?(?:за)?кончил(?:ся|ась|ось|ись)\s+(.+)$`)},
+			{Buying, regexp.MustCompile(`(?i)^(.+?)\s+(?:за)?кончил(?:ся|ась|ось|ись)$`)},
+			{Buying, regexp.MustCompile(`(?i)^добавь(?:те)?\s+(.+?)\s+в\s+(?:список\s+покупок|покупки)$`)},
+		},
+		separator: regexp.MustCompile(`(?i)\s*,\s*(?:и\s+)?|\s+и\s+|\s*[&+;]\s*`),
+		connector: regexp.MustCompile(`(?i)^(?:что|чтобы|про|о|об)\s+`),
+
+		relative: regexp.MustCompile(`(?i)(?:^|\s)через\s+(?:(\d+|одну|один|два|две|три|пару)\s+)?(минуту|минуты|минут|мин|часа|часов|час|день|дня|дней|неделю|недели|недель)(?:\s|$)`),
+		counts:   map[string]int{"одну": 1, "один": 1, "два": 2, "две": 2, "три": 3, "пару": 2},
+		units:    map[string]time.Duration{"мин": time.Minute, "час": time.Hour, "д": 24 * time.Hour, "недел": 7 * 24 * time.Hour},
+
+		days: regexp.MustCompile(`(?i)(?:^|\s)(?:во?\s+)?(сегодня|завтра|послезавтра|понедельник|вторник|среду|четверг|пятницу|субботу|воскресенье)(?:\s+(утром|днём|днем|вечером|ночью))?(?:\s|$)`),
+		dayWords: map[string]day{
+			"сегодня":     {},
+			"завтра":      {offset: 1},
+			"послезавтра": {offset: 2},
+			"понедельник": {weekday: time.Monday, weekly: true},
+			"вторник":     {weekday: time.Tuesday, weekly: true},
+			"среду":       {weekday: time.Wednesday, weekly: true},
+			"четверг":     {weekday: time.Thursday, weekly: true},
+			"пятницу":     {weekday: time.Friday, weekly: true},
+			"субботу":     {weekday: time.Saturday, weekly: true},
+			"воскресенье": {weekday: time.Sunday, weekly: true},
+		},
+		partsOfDay: map[string]int{"утром": 9, "днём": 14, "днем": 14, "вечером": 19, "ночью": 22},
+
+		clocks: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)(?:^|\s)(?:в\s+)?(\d{1,2})(?:[:.](\d{2}))?(?:\s+час(?:а|ов)?)?\s+(утра|дня|вечера|ночи)(?:\s|$)`),
+			regexp.MustCompile(`(?i)(?:^|\s)(?:в\s+)?(\d{1,2})[:.](\d{2})()(?:\s|$)`),
+			regexp.MustCompile(`(?i)(?:^|\s)в\s+(\d{1,2})(?:\s+час(?:а|ов)?)?()()(?:\s|$)`),
+		},
+		pm: map[string]bool{"дня": true, "вечера": true},
+	},
+}
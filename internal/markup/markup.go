@@ -0,0 +1,209 @@
+// Package markup renders bot messages so that user content can never break
+// their formatting.
+//
+// Messages are written in the style of Telegram's legacy Markdown: *bold*,
+// _italic_, ~strikethrough~, `code` and [text](url). Everything that comes
+// from users — titles, names, notes — is passed through Escape first. Before
+// sending, the text is converted to Telegram HTML (or MarkdownV2), escaping
+// whatever the target parse mode treats specially. Unlike Telegram's own
+// Markdown parser the conversion never fails: markers without a partner are
+// kept as plain characters.
+package markup
+
+import (
+	"html"
+	"strings"
+)
+
+// specials are the characters Escape protects.
+const specials = "\\*_~`[]()"
+
+// Escape makes s plain text in a message template, so that e.g. a todo
+// titled "my_file*name" is shown as written.
+func Escape(s string) string {
+	if !strings.ContainsAny(s, specials) {
+		return s
+	}
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(specials, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// node is a piece of a parsed template: plain text or a formatted span.
+type node struct {
+	// kind is 0 for text, or the marker of the span: '*', '_', '~', '`'
+	// or '[' for links.
+	kind     byte
+	text     string
+	url      string
+	children []node
+}
+
+// parse splits a template into text and formatted spans.
+func parse(s string) []node {
+	var nodes []node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, node{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+			}
+			text.WriteByte(s[i])
+		case '*', '_', '~':
+			end := closing(s, i+1, c)
+			if end <= i+1 {
+				text.WriteByte(c)
+				continue
+			}
+			flush()
+			nodes = append(nodes, node{kind: c, children: parse(s[i+1 : end])})
+			i = end
+		case '`':
+			end := closing(s, i+1, c)
+			if end <= i+1 {
+				text.WriteByte(c)
+				continue
+			}
+			flush()
+			nodes = append(nodes, node{kind: c, text: unescape(s[i+1 : end])})
+			i = end
+		case '[':
+			textEnd := closing(s, i+1, ']')
+			if textEnd <= i+1 || textEnd+1 >= len(s) || s[textEnd+1] != '(' {
+				text.WriteByte(c)
+				continue
+			}
+			urlEnd := closing(s, textEnd+2, ')')
+			if urlEnd < 0 {
+				text.WriteByte(c)
+				continue
+			}
+			flush()
+			nodes = append(nodes, node{
+				kind:     c,
+				url:      unescape(s[textEnd+2 : urlEnd]),
+				children: parse(s[i+1 : textEnd]),
+			})
+			i = urlEnd
+		default:
+			text.WriteByte(c)
+		}
+	}
+	flush()
+	return nodes
+}
+
+// closing returns the index of the first unescaped c in s from start, or -1.
+func closing(s string, start int, c byte) int {
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case c:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescape removes the backslashes Escape added.
+func unescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// HTML converts a message template to Telegram HTML.
+func HTML(template string) string {
+	var sb strings.Builder
+	writeHTML(&sb, parse(template))
+	return sb.String()
+}
+
+var htmlTags = map[byte]string{'*': "b", '_': "i", '~': "s"}
+
+func writeHTML(sb *strings.Builder, nodes []node) {
+	for _, n := range nodes {
+		switch n.kind {
+		case 0:
+			sb.WriteString(html.EscapeString(n.text))
+		case '`':
+			sb.WriteString("<code>" + html.EscapeString(n.text) + "</code>")
+		case '[':
+			sb.WriteString(`<a href="` + html.EscapeString(n.url) + `">`)
+			writeHTML(sb, n.children)
+			sb.WriteString("</a>")
+		default:
+			tag := htmlTags[n.kind]
+			sb.WriteString("<" + tag + ">")
+			writeHTML(sb, n.children)
+			sb.WriteString("</" + tag + ">")
+		}
+	}
+}
+
+// MarkdownV2 converts a message template to Telegram MarkdownV2.
+func MarkdownV2(template string) string {
+	var sb strings.Builder
+	writeMarkdownV2(&sb, parse(template))
+	return sb.String()
+}
+
+// markdownV2Specials must be escaped in MarkdownV2 text.
+const markdownV2Specials = "_*[]()~`>#+-=|{}.!\\"
+
+func escapeMarkdownV2(s, specials string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(specials, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func writeMarkdownV2(sb *strings.Builder, nodes []node) {
+	for i, n := range nodes {
+		switch n.kind {
+		case 0:
+			sb.WriteString(escapeMarkdownV2(n.text, markdownV2Specials))
+		case '`':
+			sb.WriteString("`" + escapeMarkdownV2(n.text, "`\\") + "`")
+		case '[':
+			sb.WriteString("[")
+			writeMarkdownV2(sb, n.children)
+			sb.WriteString("](" + escapeMarkdownV2(n.url, ")\\") + ")")
+		default:
+			sb.WriteByte(n.kind)
+			writeMarkdownV2(sb, n.children)
+			sb.WriteByte(n.kind)
+		}
+		// "__" would start underline; Telegram ignores the \r in between
+		if n.kind == '_' && i+1 < len(nodes) && nodes[i+1].kind == '_' {
+			sb.WriteByte('\r')
+		}
+	}
+}
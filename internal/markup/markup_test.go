@@ -0,0 +1,138 @@
+package markup
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"plain", "plain"},
+		{"*bold* _italic_ ~gone~", "<b>bold</b> <i>italic</i> <s>gone</s>"},
+		{"`a<b`", "<code>a&lt;b</code>"},
+		{"[link](https://example.com/?a=1&b=2)", `<a href="https://example.com/?a=1&amp;b=2">link</a>`},
+		{"*" + Escape("my_file*name") + "*", "<b>my_file*name</b>"},
+		{"unpaired * and _", "unpaired * and _"},
+		{"<script>&", "&lt;script&gt;&amp;"},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.template); got != tt.want {
+			t.Errorf("HTML(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func FuzzToHTML(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"*bold* _italic_ ~strike~ `code`",
+		"[text](https://example.com/?a=1&b=<2>)",
+		"*_nested ~deep~_*",
+		"\\*escaped\\* \\",
+		"[unclosed](url",
+		"<b>&amp;</b>",
+		"*[link *bold*](u)*",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// Templates: whatever the markers, the output is well-formed
+		if err := checkHTML(HTML(s)); err != nil {
+			t.Errorf("HTML(%q): %v", s, err)
+		}
+
+		// User content: escaped text comes out exactly as written
+		if !utf8.ValidString(s) {
+			return
+		}
+		out := HTML(Escape(s))
+		if err := checkHTML(out); err != nil {
+			t.Errorf("HTML(Escape(%q)): %v", s, err)
+		}
+		if strings.Contains(out, "<") {
+			t.Errorf("HTML(Escape(%q)) = %q has tags", s, out)
+		}
+		if got := html.UnescapeString(out); got != s {
+			t.Errorf("HTML(Escape(%q)) shows %q", s, got)
+		}
+	})
+}
+
+// htmlEntities are the entities html.EscapeString writes.
+var htmlEntities = []string{"&lt;", "&gt;", "&amp;", "&#34;", "&#39;"}
+
+// checkHTML returns an error unless s is Telegram HTML made only of the
+// tags HTML writes, properly nested, with no bare '<', '>' or '&' in text
+// or attributes.
+func checkHTML(s string) error {
+	var open []string
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '<':
+			end := strings.IndexByte(s[i:], '>')
+			if end < 0 {
+				return fmt.Errorf("unterminated tag at offset %d", i)
+			}
+			tag := s[i+1 : i+end]
+			switch {
+			case strings.HasPrefix(tag, "/"):
+				if len(open) == 0 || open[len(open)-1] != tag[1:] {
+					return fmt.Errorf("unbalanced closing tag at offset %d", i)
+				}
+				open = open[:len(open)-1]
+			case tag == "b" || tag == "i" || tag == "s" || tag == "code":
+				open = append(open, tag)
+			case strings.HasPrefix(tag, `a href="`) && strings.HasSuffix(tag, `"`):
+				href := tag[len(`a href="`) : len(tag)-1]
+				if strings.ContainsAny(href, `"<>`) || !entitiesOnly(href) {
+					return fmt.Errorf("unescaped link at offset %d", i)
+				}
+				open = append(open, "a")
+			default:
+				return fmt.Errorf("unexpected tag at offset %d", i)
+			}
+			i += end + 1
+		case '>':
+			return fmt.Errorf("bare > at offset %d", i)
+		case '&':
+			if !entityAt(s[i:]) {
+				return fmt.Errorf("bare & at offset %d", i)
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("unclosed %s at the end", open[len(open)-1])
+	}
+	return nil
+}
+
+// entitiesOnly reports whether every '&' in s starts an entity.
+func entitiesOnly(s string) bool {
+	for i := range len(s) {
+		if s[i] == '&' && !entityAt(s[i:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// entityAt reports whether s starts with an entity html.EscapeString writes.
+func entityAt(s string) bool {
+	for _, entity := range htmlEntities {
+		if strings.HasPrefix(s, entity) {
+			return true
+		}
+	}
+	return false
+}
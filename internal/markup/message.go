@@ -0,0 +1,34 @@
+package markup
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// NewMessage creates a message to the chat from a template, sent as HTML.
+func NewMessage(chatID int64, template string) tgbotapi.MessageConfig {
+	msg := tgbotapi.NewMessage(chatID, HTML(template))
+	msg.ParseMode = tgbotapi.ModeHTML
+	return msg
+}
+
+// NewEditMessageText creates an edit replacing the text of a message with a
+// template, sent as HTML.
+func NewEditMessageText(chatID int64, messageID int, template string) tgbotapi.EditMessageTextConfig {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, HTML(template))
+	edit.ParseMode = tgbotapi.ModeHTML
+	return edit
+}
+
+// NewEditMessageTextAndMarkup is NewEditMessageText that also replaces the
+// message's inline keyboard.
+func NewEditMessageTextAndMarkup(chatID int64, messageID int, template string, markup tgbotapi.InlineKeyboardMarkup) tgbotapi.EditMessageTextConfig {
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, messageID, HTML(template), markup)
+	edit.ParseMode = tgbotapi.ModeHTML
+	return edit
+}
+
+// NewInlineQueryResultArticle creates an inline query result that sends a
+// template as HTML when chosen.
+func NewInlineQueryResultArticle(id, title, template string) tgbotapi.InlineQueryResultArticle {
+	return tgbotapi.NewInlineQueryResultArticleHTML(id, title, HTML(template))
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"mime"
+	"strings"
+	"time"
+)
+
+// AttachmentEntity identifies the kind of record a file is attached to
+type AttachmentEntity string
+
+const (
+	AttachmentEntityBuyingItem   AttachmentEntity = "buying_item"
+	AttachmentEntityShoppingTrip AttachmentEntity = "shopping_trip"
+	AttachmentEntityTodo         AttachmentEntity = "todo"
+)
+
+// Attachment represents a file (e.g. a receipt photo or a voice note) linked
+// to a record. Its content is in the blob store under StorageKey or, if
+// that is empty, only on the Telegram servers under TelegramFileID
+type Attachment struct {
+	ID             int64            `json:"id" db:"id"`
+	FamilyID       int64            `json:"family_id" db:"family_id"`
+	ChatID         int64            `json:"chat_id" db:"chat_id"`
+	EntityType     AttachmentEntity `json:"entity_type" db:"entity_type"`
+	EntityID       int64            `json:"entity_id" db:"entity_id"`
+	StorageKey     string           `json:"-" db:"storage_key"`
+	FileName       string           `json:"file_name" db:"file_name"`
+	ContentType    string           `json:"content_type" db:"content_type"`
+	SizeBytes      int64            `json:"size_bytes" db:"size_bytes"`
+	TelegramFileID string           `json:"-" db:"telegram_file_id"`
+	UploadedByID   *int64           `json:"uploaded_by_id" db:"uploaded_by_id"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+// SafeContentType reports whether files of the content type can be shown
+// in a browser without running anything: images other than SVG, audio and
+// PDF. Other files, HTML and SVG among them, are stored and served as
+// application/octet-stream.
+func SafeContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "image/svg+xml":
+		return false
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "audio/"):
+		return true
+	}
+	return mediaType == "application/pdf"
+}
@@ -15,16 +15,53 @@ type BuyingList struct {
 	CreatedBy *User     `json:"created_by,omitempty"`
 }
 
-// BuyingItem represents an item in a shopping list
+// BuyingItem represents an item in a shopping list. Bought items are kept
+// as purchase history: clearing the list archives them instead of deleting.
 type BuyingItem struct {
-	ID           int64     `json:"id" db:"id"`
-	BuyingListID int64     `json:"buying_list_id" db:"buying_list_id"`
-	Name         string    `json:"name" db:"name"`
-	Quantity     string    `json:"quantity" db:"quantity"`
-	Bought       bool      `json:"bought" db:"bought"`
-	BoughtByID   *int64    `json:"bought_by_id" db:"bought_by_id"`
-	AddedByID    int64     `json:"added_by_id" db:"added_by_id"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	BoughtBy     *User     `json:"bought_by,omitempty"`
-	AddedBy      *User     `json:"added_by,omitempty"`
+	ID           int64        `json:"id" db:"id"`
+	BuyingListID int64        `json:"buying_list_id" db:"buying_list_id"`
+	Name         string       `json:"name" db:"name"`
+	Quantity     string       `json:"quantity" db:"quantity"`
+	Category     string       `json:"category" db:"category"`
+	Bought       bool         `json:"bought" db:"bought"`
+	BoughtByID   *int64       `json:"bought_by_id" db:"bought_by_id"`
+	BoughtAt     *time.Time   `json:"bought_at" db:"bought_at"`
+	Price        *float64     `json:"price" db:"price"`
+	Archived     bool         `json:"archived" db:"archived"`
+	TripID       *int64       `json:"trip_id" db:"trip_id"`
+	AddedByID    int64        `json:"added_by_id" db:"added_by_id"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	BoughtBy     *User        `json:"bought_by,omitempty"`
+	AddedBy      *User        `json:"added_by,omitempty"`
+	Attachments  []Attachment `json:"attachments,omitempty"`
+}
+
+// ShoppingTrip groups the bought items that were archived together by one
+// clear of the shopping list
+type ShoppingTrip struct {
+	ID            int64        `json:"id" db:"id"`
+	BuyingListID  int64        `json:"buying_list_id" db:"buying_list_id"`
+	CompletedByID *int64       `json:"completed_by_id" db:"completed_by_id"`
+	ItemCount     int          `json:"item_count" db:"item_count"`
+	Total         float64      `json:"total" db:"total"`
+	CompletedAt   time.Time    `json:"completed_at" db:"completed_at"`
+	Attachments   []Attachment `json:"attachments,omitempty"`
+}
+
+// SpendingBucket is one aggregated row of a spending report
+type SpendingBucket struct {
+	Key   string  `json:"key"`
+	Total float64 `json:"total"`
+	Items int     `json:"items"`
+}
+
+// SpendingStats summarises purchases made since a given point in time,
+// grouped per month, per family member and per category
+type SpendingStats struct {
+	Since      time.Time        `json:"since"`
+	Total      float64          `json:"total"`
+	Items      int              `json:"items"`
+	ByMonth    []SpendingBucket `json:"by_month"`
+	ByMember   []SpendingBucket `json:"by_member"`
+	ByCategory []SpendingBucket `json:"by_category"`
 }
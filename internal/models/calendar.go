@@ -2,7 +2,8 @@ package models
 
 import "time"
 
-// CalendarEvent represents a family calendar event
+// CalendarEvent represents a family calendar event. Events generated from
+// occasions are not stored; they carry OccasionID instead of an ID.
 type CalendarEvent struct {
 	ID          int64      `json:"id" db:"id"`
 	FamilyID    int64      `json:"family_id" db:"family_id"`
@@ -18,6 +19,7 @@ type CalendarEvent struct {
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	CreatedBy   *User      `json:"created_by,omitempty"`
+	OccasionID  *int64     `json:"occasion_id,omitempty"`
 }
 
 // IsUpcoming returns true if the event hasn't started yet
@@ -12,6 +12,13 @@ type Family struct {
 	Members   []User    `json:"members,omitempty"`
 }
 
+// Family member roles. Admins may change and delete anything in the family
+// and manage roles; members may only change what they own.
+const (
+	FamilyRoleAdmin  = "admin"
+	FamilyRoleMember = "member"
+)
+
 // FamilyMember represents the join table between families and users
 type FamilyMember struct {
 	ID       int64  `json:"id" db:"id"`
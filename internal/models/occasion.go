@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// OccasionKind distinguishes personal occasions from shared ones
+type OccasionKind string
+
+const (
+	OccasionBirthday OccasionKind = "birthday"
+	OccasionHoliday  OccasionKind = "holiday"
+)
+
+// Occasion is a yearly date, such as a member's birthday or a holiday, that
+// wish lists can be linked to. Year is only known for birthdays given with
+// a birth year.
+type Occasion struct {
+	ID               int64        `json:"id" db:"id"`
+	FamilyID         int64        `json:"family_id" db:"family_id"`
+	ChatID           int64        `json:"chat_id" db:"chat_id"`
+	Kind             OccasionKind `json:"kind" db:"kind"`
+	Title            string       `json:"title" db:"title"`
+	CelebrantID      *int64       `json:"celebrant_id" db:"celebrant_id"`
+	Month            int          `json:"month" db:"month"`
+	Day              int          `json:"day" db:"day"`
+	Year             *int         `json:"year,omitempty" db:"year"`
+	RemindDaysBefore int          `json:"remind_days_before" db:"remind_days_before"`
+	LastRemindedFor  *time.Time   `json:"last_reminded_for,omitempty" db:"last_reminded_for"`
+	CreatedByID      int64        `json:"created_by_id" db:"created_by_id"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+	Celebrant        *User        `json:"celebrant,omitempty"`
+}
+
+// DateIn returns the occasion's date in the given year. February 29 falls
+// on February 28 in non-leap years.
+func (o *Occasion) DateIn(year int, loc *time.Location) time.Time {
+	d := time.Date(year, time.Month(o.Month), o.Day, 0, 0, 0, 0, loc)
+	if d.Month() != time.Month(o.Month) {
+		d = time.Date(year, time.Month(o.Month)+1, 0, 0, 0, 0, 0, loc)
+	}
+	return d
+}
+
+// NextDate returns the next date, today included, on which the occasion
+// falls.
+func (o *Occasion) NextDate(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	d := o.DateIn(now.Year(), now.Location())
+	if d.Before(today) {
+		d = o.DateIn(now.Year()+1, now.Location())
+	}
+	return d
+}
+
+// AgeOn returns how old the celebrant turns on the given date, or 0 when the
+// birth year is unknown.
+func (o *Occasion) AgeOn(date time.Time) int {
+	if o.Kind != OccasionBirthday || o.Year == nil {
+		return 0
+	}
+	return date.Year() - *o.Year
+}
@@ -0,0 +1,21 @@
+package models
+
+// SearchResultType names the kind of item a search result points to
+type SearchResultType string
+
+const (
+	SearchResultTodo       SearchResultType = "todo"
+	SearchResultEvent      SearchResultType = "event"
+	SearchResultBuyingItem SearchResultType = "buying_item"
+	SearchResultWishItem   SearchResultType = "wish_item"
+	SearchResultReminder   SearchResultType = "reminder"
+)
+
+// SearchResult is an item of the family that matched a full-text search.
+// Todos also match through their comments.
+type SearchResult struct {
+	Type  SearchResultType `json:"type"`
+	ID    int64            `json:"id"`
+	Title string           `json:"title"`
+	Rank  float64          `json:"rank"`
+}
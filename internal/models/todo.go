@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
 
 // TodoStatus represents the status of a todo item
 type TodoStatus string
@@ -20,23 +25,65 @@ const (
 	TodoPriorityHigh   TodoPriority = "high"
 )
 
+// TodoRecurrence defines how often a recurring todo (a chore) comes back
+type TodoRecurrence string
+
+const (
+	TodoRecurrenceNone    TodoRecurrence = "none"
+	TodoRecurrenceDaily   TodoRecurrence = "daily"
+	TodoRecurrenceWeekly  TodoRecurrence = "weekly"
+	TodoRecurrenceMonthly TodoRecurrence = "monthly"
+)
+
 // Todo represents a todo item
 type Todo struct {
-	ID           int64         `json:"id" db:"id"`
-	Title        string        `json:"title" db:"title"`
-	Description  string        `json:"description" db:"description"`
-	Status       TodoStatus    `json:"status" db:"status"`
-	Priority     TodoPriority  `json:"priority" db:"priority"`
-	Deadline     *time.Time    `json:"deadline" db:"deadline"`
-	CreatedByID  int64         `json:"created_by_id" db:"created_by_id"`
-	AssignedToID *int64        `json:"assigned_to_id" db:"assigned_to_id"`
-	ChatID       int64         `json:"chat_id" db:"chat_id"`
-	MessageID    *int64        `json:"message_id" db:"message_id"`
-	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
-	CreatedBy    *User         `json:"created_by,omitempty"`
-	AssignedTo   *User         `json:"assigned_to,omitempty"`
-	Comments     []Comment     `json:"comments,omitempty"`
+	ID             int64          `json:"id" db:"id"`
+	Title          string         `json:"title" db:"title"`
+	Description    string         `json:"description" db:"description"`
+	Status         TodoStatus     `json:"status" db:"status"`
+	Priority       TodoPriority   `json:"priority" db:"priority"`
+	Deadline       *time.Time     `json:"deadline" db:"deadline"`
+	CreatedByID    int64          `json:"created_by_id" db:"created_by_id"`
+	AssignedToID   *int64         `json:"assigned_to_id" db:"assigned_to_id"`
+	ChatID         int64          `json:"chat_id" db:"chat_id"`
+	MessageID      *int64         `json:"message_id" db:"message_id"`
+	Recurrence     TodoRecurrence `json:"recurrence" db:"recurrence"`
+	Rotation       []int64        `json:"rotation,omitempty" db:"rotation"`
+	PreviousID     *int64         `json:"previous_id" db:"previous_id"`
+	AutoComplete   bool           `json:"auto_complete" db:"auto_complete"`
+	ChecklistDone  int            `json:"checklist_done"`
+	ChecklistTotal int            `json:"checklist_total"`
+	Tags           []string       `json:"tags"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	CreatedBy      *User          `json:"created_by,omitempty"`
+	AssignedTo     *User          `json:"assigned_to,omitempty"`
+	Comments       []Comment      `json:"comments,omitempty"`
+	Attachments    []Attachment   `json:"attachments,omitempty"`
+}
+
+// ChecklistItem is a subtask of a todo. Position numbers the items of a
+// todo from 1.
+type ChecklistItem struct {
+	ID        int64      `json:"id" db:"id"`
+	TodoID    int64      `json:"todo_id" db:"todo_id"`
+	Position  int        `json:"position" db:"position"`
+	Text      string     `json:"text" db:"text"`
+	Done      bool       `json:"done" db:"done"`
+	DoneByID  *int64     `json:"done_by_id" db:"done_by_id"`
+	DoneAt    *time.Time `json:"done_at" db:"done_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TodoEvent records a status transition of a todo
+type TodoEvent struct {
+	ID         int64      `json:"id" db:"id"`
+	TodoID     int64      `json:"todo_id" db:"todo_id"`
+	UserID     *int64     `json:"user_id" db:"user_id"`
+	FromStatus TodoStatus `json:"from_status" db:"from_status"`
+	ToStatus   TodoStatus `json:"to_status" db:"to_status"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	User       *User      `json:"user,omitempty"`
 }
 
 // IsCompleted returns true if the todo is completed
@@ -44,6 +91,11 @@ func (t *Todo) IsCompleted() bool {
 	return t.Status == TodoStatusCompleted
 }
 
+// IsCancelled returns true if the todo is cancelled
+func (t *Todo) IsCancelled() bool {
+	return t.Status == TodoStatusCancelled
+}
+
 // IsPending returns true if the todo is pending
 func (t *Todo) IsPending() bool {
 	return t.Status == TodoStatusPending
@@ -56,8 +108,75 @@ func (t *Todo) IsAssigned() bool {
 
 // IsOverdue returns true if the todo has a deadline and it's passed
 func (t *Todo) IsOverdue() bool {
-	if t.Deadline == nil || t.IsCompleted() {
+	if t.Deadline == nil || !t.IsPending() {
 		return false
 	}
 	return time.Now().After(*t.Deadline)
-}
\ No newline at end of file
+}
+
+// IsRecurring returns true if the todo comes back after it is completed
+func (t *Todo) IsRecurring() bool {
+	return t.Recurrence != "" && t.Recurrence != TodoRecurrenceNone
+}
+
+// NextDeadline returns the deadline of the occurrence after this one: one
+// interval after the current deadline, skipping intervals that are already
+// over at now so a late chore does not come back overdue.
+func (t *Todo) NextDeadline(now time.Time) time.Time {
+	next := now
+	if t.Deadline != nil {
+		next = *t.Deadline
+	}
+	for {
+		switch t.Recurrence {
+		case TodoRecurrenceDaily:
+			next = next.AddDate(0, 0, 1)
+		case TodoRecurrenceWeekly:
+			next = next.AddDate(0, 0, 7)
+		case TodoRecurrenceMonthly:
+			next = next.AddDate(0, 1, 0)
+		default:
+			return next
+		}
+		if next.After(now) {
+			return next
+		}
+	}
+}
+
+// NextAssignee returns who is up after the current assignee in the rotation.
+// Without a rotation the chore stays with the current assignee.
+func (t *Todo) NextAssignee() *int64 {
+	if len(t.Rotation) == 0 {
+		return t.AssignedToID
+	}
+	next := t.Rotation[0]
+	if t.AssignedToID != nil {
+		for i, id := range t.Rotation {
+			if id == *t.AssignedToID {
+				next = t.Rotation[(i+1)%len(t.Rotation)]
+				break
+			}
+		}
+	}
+	return &next
+}
+
+// NormalizeTag turns "#School" into the stored tag name "school". It reports
+// false for words that are not tags: tags start with a letter and consist of
+// letters, digits, '_' and '-'.
+func NormalizeTag(s string) (string, bool) {
+	name := strings.ToLower(strings.TrimPrefix(s, "#"))
+	if name == "" || utf8.RuneCountInString(name) > 50 {
+		return "", false
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '_' || r == '-'):
+		default:
+			return "", false
+		}
+	}
+	return name, true
+}
@@ -2,28 +2,70 @@ package models
 
 import "time"
 
-// WishList represents a personal wish list for a family member
+// WishList represents a personal wish list for a family member.
+// ReservedHint lets the owner opt in to a spoiler-free indicator telling
+// them that something on the list has been reserved; HasReserved carries
+// that indicator and is only set for the owner when the hint is enabled.
 type WishList struct {
-	ID        int64      `json:"id" db:"id"`
-	FamilyID  int64      `json:"family_id" db:"family_id"`
-	UserID    int64      `json:"user_id" db:"user_id"`
-	Name      string     `json:"name" db:"name"`
-	CreatedAt time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
-	Items     []WishItem `json:"items,omitempty"`
-	User      *User      `json:"user,omitempty"`
+	ID           int64      `json:"id" db:"id"`
+	FamilyID     int64      `json:"family_id" db:"family_id"`
+	UserID       int64      `json:"user_id" db:"user_id"`
+	Name         string     `json:"name" db:"name"`
+	ReservedHint bool       `json:"reserved_hint" db:"reserved_hint"`
+	OccasionID   *int64     `json:"occasion_id" db:"occasion_id"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	Items        []WishItem `json:"items,omitempty"`
+	User         *User      `json:"user,omitempty"`
+	HasReserved  *bool      `json:"has_reserved,omitempty"`
 }
 
-// WishItem represents an item in a wish list
+// WishItem represents an item in a wish list. Priority ranks the item on
+// its list: 1 is the most wanted, 0 means unranked. For group gifts the
+// member holding the reservation is the organizer and Pledged is the sum of
+// all pledges toward the item.
 type WishItem struct {
+	ID           int64        `json:"id" db:"id"`
+	WishListID   int64        `json:"wish_list_id" db:"wish_list_id"`
+	Name         string       `json:"name" db:"name"`
+	URL          string       `json:"url" db:"url"`
+	Price        string       `json:"price" db:"price"`
+	Notes        string       `json:"notes" db:"notes"`
+	ImageURL     string       `json:"image_url" db:"image_url"`
+	Priority     int          `json:"priority" db:"priority"`
+	Reserved     bool         `json:"reserved" db:"reserved"`
+	ReservedByID *int64       `json:"reserved_by_id" db:"reserved_by_id"`
+	Purchased    bool         `json:"purchased" db:"purchased"`
+	PurchasedAt  *time.Time   `json:"purchased_at,omitempty" db:"purchased_at"`
+	Pledged      float64      `json:"pledged"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	ReservedBy   *User        `json:"reserved_by,omitempty"`
+	Pledges      []WishPledge `json:"pledges,omitempty"`
+}
+
+// WishPledge is a member's promised contribution toward a wish item
+type WishPledge struct {
 	ID         int64     `json:"id" db:"id"`
-	WishListID int64     `json:"wish_list_id" db:"wish_list_id"`
-	Name       string    `json:"name" db:"name"`
-	URL        string    `json:"url" db:"url"`
-	Price      string    `json:"price" db:"price"`
-	Notes      string    `json:"notes" db:"notes"`
-	Reserved   bool      `json:"reserved" db:"reserved"`
-	ReservedByID *int64  `json:"reserved_by_id" db:"reserved_by_id"`
+	WishItemID int64     `json:"wish_item_id" db:"wish_item_id"`
+	UserID     int64     `json:"user_id" db:"user_id"`
+	Amount     float64   `json:"amount" db:"amount"`
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	ReservedBy *User     `json:"reserved_by,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	User       *User     `json:"user,omitempty"`
+}
+
+// SettlementShare is what one contributor owes the organizer
+type SettlementShare struct {
+	User   *User   `json:"user"`
+	Amount float64 `json:"amount"`
+}
+
+// WishSettlement summarizes a purchased group gift: what the organizer paid
+// and how much each other contributor owes them
+type WishSettlement struct {
+	Item      *WishItem         `json:"item"`
+	Organizer *User             `json:"organizer"`
+	Paid      float64           `json:"paid"`
+	Pledged   float64           `json:"pledged"`
+	Shares    []SettlementShare `json:"shares"`
 }
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/Kerhoff/TodoboT/internal/dialog"
 	"github.com/Kerhoff/TodoboT/internal/models"
 )
 
@@ -14,6 +16,10 @@ type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) (*models.User, error)
 	Delete(ctx context.Context, id int64) error
+	GetSelectedFamily(ctx context.Context, userID int64) (int64, error)
+	SetSelectedFamily(ctx context.Context, userID, familyID int64) error
+	GetLanguage(ctx context.Context, userID int64) (string, error)
+	SetLanguage(ctx context.Context, userID int64, lang string) error
 }
 
 // TodoRepository defines the interface for todo data operations
@@ -24,6 +30,14 @@ type TodoRepository interface {
 	GetByAssignedUser(ctx context.Context, userID int64, filters TodoFilters) ([]*models.Todo, error)
 	Update(ctx context.Context, todo *models.Todo) (*models.Todo, error)
 	Delete(ctx context.Context, id int64) error
+	UnassignUser(ctx context.Context, chatID, userID int64) (int64, error)
+	ChangeStatus(ctx context.Context, event *models.TodoEvent) (bool, error)
+	GetEvents(ctx context.Context, todoID int64) ([]*models.TodoEvent, error)
+	GetNextOccurrence(ctx context.Context, todoID int64) (*models.Todo, error)
+	AddChecklistItem(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error)
+	GetChecklist(ctx context.Context, todoID int64) ([]*models.ChecklistItem, error)
+	SetChecklistItemDone(ctx context.Context, itemID int64, done bool, userID *int64) error
+	SetTags(ctx context.Context, todoID int64, tags []string) error
 }
 
 // CommentRepository defines the interface for comment data operations
@@ -38,10 +52,25 @@ type FamilyRepository interface {
 	Create(ctx context.Context, family *models.Family) (*models.Family, error)
 	GetByChatID(ctx context.Context, chatID int64) (*models.Family, error)
 	GetByID(ctx context.Context, id int64) (*models.Family, error)
-	AddMember(ctx context.Context, familyID, userID int64, role string) error
-	RemoveMember(ctx context.Context, familyID, userID int64) error
+	GetByUser(ctx context.Context, userID int64) ([]*models.Family, error)
+	GetChats(ctx context.Context, familyID int64) ([]int64, error)
+	MoveChat(ctx context.Context, chatID, familyID int64) error
+	UnlinkChat(ctx context.Context, familyID, chatID int64) error
+	CreateLinkCode(ctx context.Context, code string, familyID, createdByID int64, expiresAt time.Time) error
+	UseLinkCode(ctx context.Context, code string) (int64, error)
+	AddMember(ctx context.Context, familyID, userID int64) (string, error)
+	RemoveMember(ctx context.Context, familyID, userID int64) (string, error)
 	GetMembers(ctx context.Context, familyID int64) ([]*models.User, error)
+	GetMemberRole(ctx context.Context, familyID, userID int64) (string, error)
+	SetMemberRole(ctx context.Context, familyID, userID int64, role string) error
+	DemoteMember(ctx context.Context, familyID, userID int64) (bool, error)
+	PromoteChatAdmin(ctx context.Context, familyID, userID int64) (bool, error)
+	GetAdmins(ctx context.Context, familyID int64) ([]*models.User, error)
 	Update(ctx context.Context, family *models.Family) (*models.Family, error)
+	GetLanguage(ctx context.Context, familyID int64) (string, error)
+	SetLanguage(ctx context.Context, familyID int64, lang string) error
+	GetCapture(ctx context.Context, familyID int64) (bool, error)
+	SetCapture(ctx context.Context, familyID int64, on bool) error
 }
 
 // CalendarRepository defines the interface for calendar event operations
@@ -59,10 +88,25 @@ type BuyingListRepository interface {
 	GetListByChatID(ctx context.Context, chatID int64) (*models.BuyingList, error)
 	GetListByID(ctx context.Context, id int64) (*models.BuyingList, error)
 	AddItem(ctx context.Context, item *models.BuyingItem) (*models.BuyingItem, error)
+	GetItemByID(ctx context.Context, itemID int64) (*models.BuyingItem, error)
 	GetItems(ctx context.Context, listID int64, onlyUnbought bool) ([]*models.BuyingItem, error)
-	MarkBought(ctx context.Context, itemID, boughtByID int64) error
+	MarkBought(ctx context.Context, itemID, boughtByID int64, price *float64) error
 	DeleteItem(ctx context.Context, itemID int64) error
-	ClearBought(ctx context.Context, listID int64) error
+	ClearBought(ctx context.Context, listID, completedByID int64) (*models.ShoppingTrip, error)
+	GetHistory(ctx context.Context, listID int64, limit, offset int) ([]*models.BuyingItem, error)
+	GetSpendingStats(ctx context.Context, listID int64, since time.Time) (*models.SpendingStats, error)
+	GetTripByID(ctx context.Context, tripID int64) (*models.ShoppingTrip, error)
+	GetTrips(ctx context.Context, listID int64, limit int) ([]*models.ShoppingTrip, error)
+}
+
+// AttachmentRepository defines the interface for attachment metadata
+// operations. File contents live in a storage.BlobStore.
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error)
+	GetByID(ctx context.Context, id int64) (*models.Attachment, error)
+	GetByEntity(ctx context.Context, entityType models.AttachmentEntity, entityID int64) ([]*models.Attachment, error)
+	GetByEntities(ctx context.Context, entityType models.AttachmentEntity, entityIDs []int64) ([]*models.Attachment, error)
+	Delete(ctx context.Context, id int64) error
 }
 
 // WishListRepository defines the interface for wish list operations
@@ -71,11 +115,30 @@ type WishListRepository interface {
 	GetListByUser(ctx context.Context, userID, familyID int64) (*models.WishList, error)
 	GetListByID(ctx context.Context, id int64) (*models.WishList, error)
 	GetListsByFamily(ctx context.Context, familyID int64) ([]*models.WishList, error)
+	SetReservedHint(ctx context.Context, listID int64, enabled bool) error
+	SetOccasion(ctx context.Context, listID int64, occasionID *int64) error
 	AddItem(ctx context.Context, item *models.WishItem) (*models.WishItem, error)
+	GetItemByID(ctx context.Context, itemID int64) (*models.WishItem, error)
 	GetItems(ctx context.Context, listID int64) ([]*models.WishItem, error)
+	UpdateItem(ctx context.Context, item *models.WishItem) error
 	ReserveItem(ctx context.Context, itemID, reservedByID int64) error
 	UnreserveItem(ctx context.Context, itemID int64) error
 	DeleteItem(ctx context.Context, itemID int64) error
+	SetPledge(ctx context.Context, itemID, userID int64, amount float64) error
+	DeletePledge(ctx context.Context, itemID, userID int64) error
+	GetPledges(ctx context.Context, itemID int64) ([]*models.WishPledge, error)
+	MarkPurchased(ctx context.Context, itemID int64) error
+	ReleaseUserReservations(ctx context.Context, familyID, userID int64) (int64, error)
+}
+
+// OccasionRepository defines the interface for occasion operations
+type OccasionRepository interface {
+	Create(ctx context.Context, occasion *models.Occasion) (*models.Occasion, error)
+	GetByID(ctx context.Context, id int64) (*models.Occasion, error)
+	GetByFamily(ctx context.Context, familyID int64) ([]*models.Occasion, error)
+	GetAll(ctx context.Context) ([]*models.Occasion, error)
+	MarkReminded(ctx context.Context, id int64, date time.Time) error
+	Delete(ctx context.Context, id int64) error
 }
 
 // ReminderRepository defines the interface for reminder operations
@@ -90,14 +153,47 @@ type ReminderRepository interface {
 	Deactivate(ctx context.Context, id int64) error
 }
 
+// SearchRepository defines the interface for full-text search across a
+// family's data. Query is a Postgres tsquery in the 'simple' configuration.
+type SearchRepository interface {
+	Search(ctx context.Context, chatID int64, query string, types []models.SearchResultType, limit, offset int) ([]*models.SearchResult, int, error)
+}
+
+// DialogRepository defines the interface for storing the multi-step
+// conversations users are in
+type DialogRepository interface {
+	Get(ctx context.Context, chatID, userID int64, now time.Time) (*dialog.State, error)
+	Save(ctx context.Context, state *dialog.State, expiresAt time.Time) error
+	Delete(ctx context.Context, chatID, userID int64, now time.Time) (bool, error)
+}
+
 // TodoFilters represents filters for querying todos
 type TodoFilters struct {
 	Status   *models.TodoStatus
 	Priority *models.TodoPriority
-	Limit    int
-	Offset   int
+	// Tags only matches todos that carry all of the tags
+	Tags         []string
+	AssignedToID *int64
+	CreatedByID  *int64
+	DeadlineFrom *time.Time
+	DeadlineTo   *time.Time
+	// OverdueOnly only matches pending todos whose deadline has passed
+	OverdueOnly bool
+	// Sort orders the todos, newest first by default
+	Sort   TodoSort
+	Limit  int
+	Offset int
 }
 
+// TodoSort is an order todos can be listed in
+type TodoSort string
+
+const (
+	TodoSortCreated  TodoSort = "created"
+	TodoSortDeadline TodoSort = "deadline"
+	TodoSortPriority TodoSort = "priority"
+)
+
 // CalendarFilters represents filters for querying calendar events
 type CalendarFilters struct {
 	From  *string
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/repository"
+)
+
+type attachmentRepository struct {
+	db *sql.DB
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(db *sql.DB) repository.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, a *models.Attachment) (*models.Attachment, error) {
+	query := `
+		INSERT INTO attachments (family_id, chat_id, entity_type, entity_id, storage_key, file_name, content_type, size_bytes, telegram_file_id, uploaded_by_id, created_at)
+		VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at`
+
+	a.CreatedAt = time.Now()
+
+	err := r.db.QueryRowContext(ctx, query,
+		a.FamilyID,
+		a.ChatID,
+		a.EntityType,
+		a.EntityID,
+		a.StorageKey,
+		a.FileName,
+		a.ContentType,
+		a.SizeBytes,
+		a.TelegramFileID,
+		a.UploadedByID,
+		a.CreatedAt,
+	).Scan(&a.ID, &a.CreatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return a, nil
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id int64) (*models.Attachment, error) {
+	query := `
+		SELECT id, COALESCE(family_id, 0), chat_id, entity_type, entity_id, storage_key, file_name, content_type, size_bytes, telegram_file_id, uploaded_by_id, created_at
+		FROM attachments
+		WHERE id = $1`
+
+	a := &models.Attachment{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&a.ID,
+		&a.FamilyID,
+		&a.ChatID,
+		&a.EntityType,
+		&a.EntityID,
+		&a.StorageKey,
+		&a.FileName,
+		&a.ContentType,
+		&a.SizeBytes,
+		&a.TelegramFileID,
+		&a.UploadedByID,
+		&a.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return a, nil
+}
+
+func (r *attachmentRepository) GetByEntity(ctx context.Context, entityType models.AttachmentEntity, entityID int64) ([]*models.Attachment, error) {
+	query := `
+		SELECT id, COALESCE(family_id, 0), chat_id, entity_type, entity_id, storage_key, file_name, content_type, size_bytes, telegram_file_id, uploaded_by_id, created_at
+		FROM attachments
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, entityType, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAttachments(rows)
+}
+
+// GetByEntities returns the attachments of several records of one kind at
+// once, e.g. of the todos on a page of a list.
+func (r *attachmentRepository) GetByEntities(ctx context.Context, entityType models.AttachmentEntity, entityIDs []int64) ([]*models.Attachment, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, COALESCE(family_id, 0), chat_id, entity_type, entity_id, storage_key, file_name, content_type, size_bytes, telegram_file_id, uploaded_by_id, created_at
+		FROM attachments
+		WHERE entity_type = $1 AND entity_id = ANY($2)
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, entityType, pq.Array(entityIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAttachments(rows)
+}
+
+func scanAttachments(rows *sql.Rows) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	for rows.Next() {
+		a := &models.Attachment{}
+		if err := rows.Scan(
+			&a.ID,
+			&a.FamilyID,
+			&a.ChatID,
+			&a.EntityType,
+			&a.EntityID,
+			&a.StorageKey,
+			&a.FileName,
+			&a.ContentType,
+			&a.SizeBytes,
+			&a.TelegramFileID,
+			&a.UploadedByID,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM attachments WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment with ID %d not found", id)
+	}
+
+	return nil
+}
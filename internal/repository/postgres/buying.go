@@ -49,7 +49,7 @@ func (r *buyingListRepository) GetListByChatID(ctx context.Context, chatID int64
 	query := `
 		SELECT id, family_id, chat_id, name, created_by_id, created_at, updated_at
 		FROM buying_lists
-		WHERE chat_id = $1
+		WHERE chat_id IN ` + sameFamilyChats + `
 		ORDER BY created_at DESC
 		LIMIT 1`
 
@@ -103,8 +103,8 @@ func (r *buyingListRepository) GetListByID(ctx context.Context, id int64) (*mode
 
 func (r *buyingListRepository) AddItem(ctx context.Context, item *models.BuyingItem) (*models.BuyingItem, error) {
 	query := `
-		INSERT INTO buying_items (buying_list_id, name, quantity, bought, added_by_id, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO buying_items (buying_list_id, name, quantity, category, bought, added_by_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at`
 
 	item.Bought = false
@@ -114,6 +114,7 @@ func (r *buyingListRepository) AddItem(ctx context.Context, item *models.BuyingI
 		item.BuyingListID,
 		item.Name,
 		item.Quantity,
+		item.Category,
 		item.Bought,
 		item.AddedByID,
 		item.CreatedAt,
@@ -126,11 +127,44 @@ func (r *buyingListRepository) AddItem(ctx context.Context, item *models.BuyingI
 	return item, nil
 }
 
+func (r *buyingListRepository) GetItemByID(ctx context.Context, itemID int64) (*models.BuyingItem, error) {
+	query := `
+		SELECT id, buying_list_id, name, quantity, category, bought, bought_by_id, bought_at, price, archived, trip_id, added_by_id, created_at
+		FROM buying_items
+		WHERE id = $1`
+
+	item := &models.BuyingItem{}
+	err := r.db.QueryRowContext(ctx, query, itemID).Scan(
+		&item.ID,
+		&item.BuyingListID,
+		&item.Name,
+		&item.Quantity,
+		&item.Category,
+		&item.Bought,
+		&item.BoughtByID,
+		&item.BoughtAt,
+		&item.Price,
+		&item.Archived,
+		&item.TripID,
+		&item.AddedByID,
+		&item.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get buying item by ID: %w", err)
+	}
+
+	return item, nil
+}
+
 func (r *buyingListRepository) GetItems(ctx context.Context, listID int64, onlyUnbought bool) ([]*models.BuyingItem, error) {
 	query := `
-		SELECT id, buying_list_id, name, quantity, bought, bought_by_id, added_by_id, created_at
+		SELECT id, buying_list_id, name, quantity, category, bought, bought_by_id, bought_at, price, archived, trip_id, added_by_id, created_at
 		FROM buying_items
-		WHERE buying_list_id = $1`
+		WHERE buying_list_id = $1 AND archived = false`
 
 	if onlyUnbought {
 		query += " AND bought = false"
@@ -152,8 +186,13 @@ func (r *buyingListRepository) GetItems(ctx context.Context, listID int64, onlyU
 			&item.BuyingListID,
 			&item.Name,
 			&item.Quantity,
+			&item.Category,
 			&item.Bought,
 			&item.BoughtByID,
+			&item.BoughtAt,
+			&item.Price,
+			&item.Archived,
+			&item.TripID,
 			&item.AddedByID,
 			&item.CreatedAt,
 		); err != nil {
@@ -165,13 +204,13 @@ func (r *buyingListRepository) GetItems(ctx context.Context, listID int64, onlyU
 	return items, rows.Err()
 }
 
-func (r *buyingListRepository) MarkBought(ctx context.Context, itemID, boughtByID int64) error {
+func (r *buyingListRepository) MarkBought(ctx context.Context, itemID, boughtByID int64, price *float64) error {
 	query := `
 		UPDATE buying_items
-		SET bought = true, bought_by_id = $2
-		WHERE id = $1`
+		SET bought = true, bought_by_id = $2, bought_at = $3, price = COALESCE($4, price)
+		WHERE id = $1 AND archived = false`
 
-	result, err := r.db.ExecContext(ctx, query, itemID, boughtByID)
+	result, err := r.db.ExecContext(ctx, query, itemID, boughtByID, time.Now(), price)
 	if err != nil {
 		return fmt.Errorf("failed to mark item as bought: %w", err)
 	}
@@ -208,13 +247,277 @@ func (r *buyingListRepository) DeleteItem(ctx context.Context, itemID int64) err
 	return nil
 }
 
-func (r *buyingListRepository) ClearBought(ctx context.Context, listID int64) error {
-	query := `DELETE FROM buying_items WHERE buying_list_id = $1 AND bought = true`
+// ClearBought archives bought items so they disappear from the active list
+// while staying available as purchase history. The archived items are
+// grouped into a new shopping trip, which is returned; nil is returned when
+// there was nothing to clear.
+func (r *buyingListRepository) ClearBought(ctx context.Context, listID, completedByID int64) (*models.ShoppingTrip, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	trip := &models.ShoppingTrip{
+		BuyingListID:  listID,
+		CompletedByID: &completedByID,
+		CompletedAt:   time.Now(),
+	}
 
-	_, err := r.db.ExecContext(ctx, query, listID)
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO shopping_trips (buying_list_id, completed_by_id, completed_at)
+		VALUES ($1, $2, $3)
+		RETURNING id`,
+		trip.BuyingListID, trip.CompletedByID, trip.CompletedAt,
+	).Scan(&trip.ID)
 	if err != nil {
-		return fmt.Errorf("failed to clear bought items: %w", err)
+		return nil, fmt.Errorf("failed to create shopping trip: %w", err)
 	}
 
-	return nil
+	err = tx.QueryRowContext(ctx, `
+		WITH cleared AS (
+			UPDATE buying_items
+			SET archived = true, trip_id = $2
+			WHERE buying_list_id = $1 AND bought = true AND archived = false
+			RETURNING price
+		)
+		SELECT COUNT(*), COALESCE(SUM(price), 0) FROM cleared`,
+		listID, trip.ID,
+	).Scan(&trip.ItemCount, &trip.Total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear bought items: %w", err)
+	}
+
+	if trip.ItemCount == 0 {
+		return nil, nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE shopping_trips SET item_count = $2, total = $3 WHERE id = $1`,
+		trip.ID, trip.ItemCount, trip.Total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update shopping trip: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit shopping trip: %w", err)
+	}
+
+	return trip, nil
+}
+
+func (r *buyingListRepository) GetHistory(ctx context.Context, listID int64, limit, offset int) ([]*models.BuyingItem, error) {
+	query := `
+		SELECT bi.id, bi.buying_list_id, bi.name, bi.quantity, bi.category, bi.bought, bi.bought_by_id,
+		       bi.bought_at, bi.price, bi.archived, bi.trip_id, bi.added_by_id, bi.created_at,
+		       u.id, COALESCE(u.telegram_username, ''), u.first_name, COALESCE(u.last_name, '')
+		FROM buying_items bi
+		LEFT JOIN users u ON u.id = bi.bought_by_id
+		WHERE bi.buying_list_id = $1 AND bi.bought = true
+		ORDER BY bi.bought_at DESC NULLS LAST, bi.id DESC`
+	args := []interface{}{listID}
+	argIdx := 2
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, limit)
+		argIdx++
+	}
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIdx)
+		args = append(args, offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query purchase history: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*models.BuyingItem
+	for rows.Next() {
+		item := &models.BuyingItem{}
+		var (
+			buyerID                       sql.NullInt64
+			username, firstName, lastName sql.NullString
+		)
+		if err := rows.Scan(
+			&item.ID,
+			&item.BuyingListID,
+			&item.Name,
+			&item.Quantity,
+			&item.Category,
+			&item.Bought,
+			&item.BoughtByID,
+			&item.BoughtAt,
+			&item.Price,
+			&item.Archived,
+			&item.TripID,
+			&item.AddedByID,
+			&item.CreatedAt,
+			&buyerID,
+			&username,
+			&firstName,
+			&lastName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan purchase history item: %w", err)
+		}
+		if buyerID.Valid {
+			item.BoughtBy = &models.User{
+				ID:               buyerID.Int64,
+				TelegramUsername: username.String,
+				FirstName:        firstName.String,
+				LastName:         lastName.String,
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetSpendingStats aggregates the prices of items bought since the given
+// time. Items bought without a price are counted but add nothing to totals.
+func (r *buyingListRepository) GetSpendingStats(ctx context.Context, listID int64, since time.Time) (*models.SpendingStats, error) {
+	stats := &models.SpendingStats{
+		Since:      since,
+		ByMonth:    []models.SpendingBucket{},
+		ByMember:   []models.SpendingBucket{},
+		ByCategory: []models.SpendingBucket{},
+	}
+
+	totalQuery := `
+		SELECT COALESCE(SUM(price), 0), COUNT(*)
+		FROM buying_items
+		WHERE buying_list_id = $1 AND bought = true AND bought_at >= $2`
+
+	if err := r.db.QueryRowContext(ctx, totalQuery, listID, since).Scan(&stats.Total, &stats.Items); err != nil {
+		return nil, fmt.Errorf("failed to get spending total: %w", err)
+	}
+
+	monthQuery := `
+		SELECT to_char(date_trunc('month', bought_at), 'YYYY-MM'), COALESCE(SUM(price), 0), COUNT(*)
+		FROM buying_items
+		WHERE buying_list_id = $1 AND bought = true AND bought_at >= $2
+		GROUP BY 1
+		ORDER BY 1 ASC`
+
+	byMonth, err := r.querySpendingBuckets(ctx, monthQuery, listID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending by month: %w", err)
+	}
+	stats.ByMonth = byMonth
+
+	memberQuery := `
+		SELECT COALESCE('@' || NULLIF(u.telegram_username, ''), u.first_name, 'unknown'),
+		       COALESCE(SUM(bi.price), 0), COUNT(*)
+		FROM buying_items bi
+		LEFT JOIN users u ON u.id = bi.bought_by_id
+		WHERE bi.buying_list_id = $1 AND bi.bought = true AND bi.bought_at >= $2
+		GROUP BY 1
+		ORDER BY 2 DESC`
+
+	byMember, err := r.querySpendingBuckets(ctx, memberQuery, listID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending by member: %w", err)
+	}
+	stats.ByMember = byMember
+
+	categoryQuery := `
+		SELECT COALESCE(NULLIF(category, ''), 'other'), COALESCE(SUM(price), 0), COUNT(*)
+		FROM buying_items
+		WHERE buying_list_id = $1 AND bought = true AND bought_at >= $2
+		GROUP BY 1
+		ORDER BY 2 DESC`
+
+	byCategory, err := r.querySpendingBuckets(ctx, categoryQuery, listID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending by category: %w", err)
+	}
+	stats.ByCategory = byCategory
+
+	return stats, nil
+}
+
+func (r *buyingListRepository) querySpendingBuckets(ctx context.Context, query string, args ...interface{}) ([]models.SpendingBucket, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := []models.SpendingBucket{}
+	for rows.Next() {
+		var b models.SpendingBucket
+		if err := rows.Scan(&b.Key, &b.Total, &b.Items); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+
+	return buckets, rows.Err()
+}
+
+func (r *buyingListRepository) GetTripByID(ctx context.Context, tripID int64) (*models.ShoppingTrip, error) {
+	query := `
+		SELECT id, buying_list_id, completed_by_id, item_count, total, completed_at
+		FROM shopping_trips
+		WHERE id = $1`
+
+	trip := &models.ShoppingTrip{}
+	err := r.db.QueryRowContext(ctx, query, tripID).Scan(
+		&trip.ID,
+		&trip.BuyingListID,
+		&trip.CompletedByID,
+		&trip.ItemCount,
+		&trip.Total,
+		&trip.CompletedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get shopping trip: %w", err)
+	}
+
+	return trip, nil
+}
+
+func (r *buyingListRepository) GetTrips(ctx context.Context, listID int64, limit int) ([]*models.ShoppingTrip, error) {
+	query := `
+		SELECT id, buying_list_id, completed_by_id, item_count, total, completed_at
+		FROM shopping_trips
+		WHERE buying_list_id = $1
+		ORDER BY completed_at DESC`
+	args := []interface{}{listID}
+
+	if limit > 0 {
+		query += " LIMIT $2"
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shopping trips: %w", err)
+	}
+	defer rows.Close()
+
+	var trips []*models.ShoppingTrip
+	for rows.Next() {
+		trip := &models.ShoppingTrip{}
+		if err := rows.Scan(
+			&trip.ID,
+			&trip.BuyingListID,
+			&trip.CompletedByID,
+			&trip.ItemCount,
+			&trip.Total,
+			&trip.CompletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan shopping trip: %w", err)
+		}
+		trips = append(trips, trip)
+	}
+
+	return trips, rows.Err()
 }
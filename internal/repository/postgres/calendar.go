@@ -88,7 +88,7 @@ func (r *calendarRepository) GetByChatID(ctx context.Context, chatID int64, filt
 	query := `
 		SELECT id, family_id, chat_id, title, description, start_time, end_time, all_day, recurring, location, created_by_id, created_at, updated_at
 		FROM calendar_events
-		WHERE chat_id = $1`
+		WHERE chat_id IN ` + sameFamilyChats
 	args := []interface{}{chatID}
 	argIdx := 2
 
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// testDB connects to the database in TEST_DATABASE_URL and creates the
+// tables in a schema of the test's own, dropped when the test ends. Tests
+// using it are skipped when no test database is configured.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	db, err := sql.Open("postgres", withSearchPath(databaseURL, schema))
+	if err != nil {
+		t.Fatalf("open schema: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	files, err := filepath.Glob("../../../migrations/*.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read migration: %v", err)
+		}
+		if _, err := db.Exec(string(migration)); err != nil {
+			t.Fatalf("migration %s: %v", filepath.Base(file), err)
+		}
+	}
+	return db
+}
+
+// withSearchPath adds the schema search path to a URL or key=value
+// connection string.
+func withSearchPath(databaseURL, schema string) string {
+	u, err := url.Parse(databaseURL)
+	if err != nil || !strings.Contains(databaseURL, "://") {
+		return databaseURL + " search_path=" + schema
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// createTestUser creates a user with the Telegram ID.
+func createTestUser(t *testing.T, db *sql.DB, telegramID int64) *models.User {
+	t.Helper()
+	user, err := NewUserRepository(db).Create(context.Background(), &models.User{
+		TelegramID: telegramID,
+		FirstName:  fmt.Sprintf("User %d", telegramID),
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+// createTestFamily creates a family with the home chat.
+func createTestFamily(t *testing.T, db *sql.DB, chatID int64) *models.Family {
+	t.Helper()
+	family, err := NewFamilyRepository(db).Create(context.Background(), &models.Family{
+		ChatID: chatID,
+		Name:   fmt.Sprintf("Family %d", chatID),
+	})
+	if err != nil {
+		t.Fatalf("create family: %v", err)
+	}
+	return family
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/dialog"
+	"github.com/Kerhoff/TodoboT/internal/repository"
+)
+
+type dialogRepository struct {
+	db *sql.DB
+}
+
+// NewDialogRepository creates a new dialog repository
+func NewDialogRepository(db *sql.DB) repository.DialogRepository {
+	return &dialogRepository{db: db}
+}
+
+// Get returns the user's conversation in the chat, or nil if they are not in
+// one or it expired before now.
+func (r *dialogRepository) Get(ctx context.Context, chatID, userID int64, now time.Time) (*dialog.State, error) {
+	query := `
+		SELECT name, step, data
+		FROM dialog_states
+		WHERE chat_id = $1 AND telegram_user_id = $2 AND expires_at > $3`
+
+	state := &dialog.State{ChatID: chatID, UserID: userID}
+	var data []byte
+	err := r.db.QueryRowContext(ctx, query, chatID, userID, now).Scan(&state.Name, &state.Step, &data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get dialog: %w", err)
+	}
+	if err := json.Unmarshal(data, &state.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode dialog data: %w", err)
+	}
+	if state.Data == nil {
+		state.Data = make(map[string]string)
+	}
+
+	return state, nil
+}
+
+// Save stores the conversation, replacing the user's previous one in the
+// chat. Conversations that have expired by now are cleared out on the way.
+func (r *dialogRepository) Save(ctx context.Context, state *dialog.State, expiresAt time.Time) error {
+	data, err := json.Marshal(state.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode dialog data: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM dialog_states WHERE expires_at <= $1`, time.Now()); err != nil {
+		return fmt.Errorf("failed to clear expired dialogs: %w", err)
+	}
+
+	query := `
+		INSERT INTO dialog_states (chat_id, telegram_user_id, name, step, data, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (chat_id, telegram_user_id) DO UPDATE
+		SET name = $3, step = $4, data = $5, expires_at = $6`
+
+	_, err = r.db.ExecContext(ctx, query, state.ChatID, state.UserID, state.Name, state.Step, data, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to save dialog: %w", err)
+	}
+
+	return nil
+}
+
+// Delete forgets the user's conversation in the chat. It reports whether
+// there was one that had not expired before now.
+func (r *dialogRepository) Delete(ctx context.Context, chatID, userID int64, now time.Time) (bool, error) {
+	query := `
+		DELETE FROM dialog_states
+		WHERE chat_id = $1 AND telegram_user_id = $2
+		RETURNING expires_at > $3`
+
+	var active bool
+	err := r.db.QueryRowContext(ctx, query, chatID, userID, now).Scan(&active)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to delete dialog: %w", err)
+	}
+
+	return active, nil
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/dialog"
+)
+
+func TestDialogRepository(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	dialogs := NewDialogRepository(db)
+	now := time.Now()
+
+	state := &dialog.State{ChatID: -100, UserID: 1, Name: "event", Step: "date", Data: map[string]string{"title": "Picnic"}}
+	if err := dialogs.Save(ctx, state, now.Add(time.Minute)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := dialogs.Get(ctx, -100, 1, now)
+	if err != nil || got == nil {
+		t.Fatalf("Get = %+v, %v", got, err)
+	}
+	if got.Name != "event" || got.Step != "date" || got.Data["title"] != "Picnic" {
+		t.Errorf("Get = %+v, want the saved conversation", got)
+	}
+	if other, err := dialogs.Get(ctx, -100, 2, now); err != nil || other != nil {
+		t.Errorf("Get(other user) = %+v, %v, want none", other, err)
+	}
+	if expired, err := dialogs.Get(ctx, -100, 1, now.Add(2*time.Minute)); err != nil || expired != nil {
+		t.Errorf("Get(after expiry) = %+v, %v, want none", expired, err)
+	}
+
+	if ended, err := dialogs.Delete(ctx, -100, 1, now); err != nil || !ended {
+		t.Errorf("Delete = %v, %v, want ended", ended, err)
+	}
+	if ended, err := dialogs.Delete(ctx, -100, 1, now); err != nil || ended {
+		t.Errorf("second Delete = %v, %v, want nothing to end", ended, err)
+	}
+}
@@ -19,6 +19,16 @@ func NewFamilyRepository(db *sql.DB) repository.FamilyRepository {
 	return &familyRepository{db: db}
 }
 
+// sameFamilyChats is a subquery listing the chats that share a family with
+// the chat in $1. The chat itself is always included, so data of chats
+// without a family is still found.
+const sameFamilyChats = `(
+			SELECT $1::BIGINT
+			UNION
+			SELECT fc2.chat_id FROM family_chats fc1
+			JOIN family_chats fc2 ON fc2.family_id = fc1.family_id
+			WHERE fc1.chat_id = $1)`
+
 func (r *familyRepository) Create(ctx context.Context, family *models.Family) (*models.Family, error) {
 	query := `
 		INSERT INTO families (chat_id, name, created_at, updated_at)
@@ -29,7 +39,13 @@ func (r *familyRepository) Create(ctx context.Context, family *models.Family) (*
 	family.CreatedAt = now
 	family.UpdatedAt = now
 
-	err := r.db.QueryRowContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query,
 		family.ChatID,
 		family.Name,
 		family.CreatedAt,
@@ -40,14 +56,29 @@ func (r *familyRepository) Create(ctx context.Context, family *models.Family) (*
 		return nil, fmt.Errorf("failed to create family: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO family_chats (chat_id, family_id, linked_at)
+		VALUES ($1, $2, $3)`,
+		family.ChatID, family.ID, family.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link family chat: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return family, nil
 }
 
+// GetByChatID returns the family the chat belongs to, whether it is the
+// family's home chat or a linked one.
 func (r *familyRepository) GetByChatID(ctx context.Context, chatID int64) (*models.Family, error) {
 	query := `
-		SELECT id, chat_id, name, created_at, updated_at
-		FROM families
-		WHERE chat_id = $1`
+		SELECT f.id, f.chat_id, f.name, f.created_at, f.updated_at
+		FROM families f
+		JOIN family_chats fc ON fc.family_id = f.id
+		WHERE fc.chat_id = $1`
 
 	family := &models.Family{}
 	err := r.db.QueryRowContext(ctx, query, chatID).Scan(
@@ -93,40 +124,293 @@ func (r *familyRepository) GetByID(ctx context.Context, id int64) (*models.Famil
 	return family, nil
 }
 
-func (r *familyRepository) AddMember(ctx context.Context, familyID, userID int64, role string) error {
+// GetByUser returns the families the user is a member of, oldest
+// membership first.
+func (r *familyRepository) GetByUser(ctx context.Context, userID int64) ([]*models.Family, error) {
 	query := `
-		INSERT INTO family_members (family_id, user_id, role, joined_at)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (family_id, user_id) DO UPDATE SET role = $3`
+		SELECT f.id, f.chat_id, f.name, f.created_at, f.updated_at
+		FROM families f
+		INNER JOIN family_members fm ON fm.family_id = f.id
+		WHERE fm.user_id = $1
+		ORDER BY fm.joined_at ASC`
 
-	_, err := r.db.ExecContext(ctx, query, familyID, userID, role, time.Now())
+	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
-		return fmt.Errorf("failed to add family member: %w", err)
+		return nil, fmt.Errorf("failed to query families of user: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	var families []*models.Family
+	for rows.Next() {
+		family := &models.Family{}
+		if err := rows.Scan(
+			&family.ID,
+			&family.ChatID,
+			&family.Name,
+			&family.CreatedAt,
+			&family.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan family: %w", err)
+		}
+		families = append(families, family)
+	}
+
+	return families, rows.Err()
 }
 
-func (r *familyRepository) RemoveMember(ctx context.Context, familyID, userID int64) error {
-	query := `DELETE FROM family_members WHERE family_id = $1 AND user_id = $2`
+func (r *familyRepository) GetChats(ctx context.Context, familyID int64) ([]int64, error) {
+	query := `
+		SELECT chat_id
+		FROM family_chats
+		WHERE family_id = $1
+		ORDER BY linked_at ASC`
 
-	result, err := r.db.ExecContext(ctx, query, familyID, userID)
+	rows, err := r.db.QueryContext(ctx, query, familyID)
 	if err != nil {
-		return fmt.Errorf("failed to remove family member: %w", err)
+		return nil, fmt.Errorf("failed to query family chats: %w", err)
 	}
+	defer rows.Close()
 
-	rowsAffected, err := result.RowsAffected()
+	var chatIDs []int64
+	for rows.Next() {
+		var chatID int64
+		if err := rows.Scan(&chatID); err != nil {
+			return nil, fmt.Errorf("failed to scan family chat: %w", err)
+		}
+		chatIDs = append(chatIDs, chatID)
+	}
+
+	return chatIDs, rows.Err()
+}
+
+// MoveChat attaches the chat to the family. If the chat was the last chat
+// of another family, that family is merged in: its members, shopping list,
+// calendar, wish lists, reminders, occasions and attachments move over and
+// the empty family is deleted. Todos follow their chat on their own. If it
+// was the home chat of a family with other chats left, the oldest of its
+// group chats (or of its private chats, if no group is left) becomes the
+// new home chat.
+func (r *familyRepository) MoveChat(ctx context.Context, chatID, familyID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("member not found in family %d", familyID)
+	var oldFamilyID int64
+	err = tx.QueryRowContext(ctx, `SELECT family_id FROM family_chats WHERE chat_id = $1`, chatID).Scan(&oldFamilyID)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get family of chat: %w", err)
+	}
+	if oldFamilyID == familyID {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO family_chats (chat_id, family_id, linked_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id) DO UPDATE SET family_id = $2, linked_at = $3`,
+		chatID, familyID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to link family chat: %w", err)
+	}
+
+	if oldFamilyID != 0 {
+		var remaining int
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM family_chats WHERE family_id = $1`, oldFamilyID).Scan(&remaining)
+		if err != nil {
+			return fmt.Errorf("failed to count family chats: %w", err)
+		}
+		if remaining == 0 {
+			if err := mergeFamily(ctx, tx, oldFamilyID, familyID); err != nil {
+				return err
+			}
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE families SET updated_at = $3, chat_id = (
+					SELECT chat_id FROM family_chats WHERE family_id = $1
+					ORDER BY chat_id > 0, linked_at ASC
+					LIMIT 1)
+				WHERE id = $1 AND chat_id = $2`,
+				oldFamilyID, chatID, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to move family home chat: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
+// UnlinkChat detaches a linked chat from the family. The family's home chat
+// stays linked, and chats linked to another family are left alone.
+func (r *familyRepository) UnlinkChat(ctx context.Context, familyID, chatID int64) error {
+	query := `
+		DELETE FROM family_chats fc
+		USING families f
+		WHERE fc.family_id = $1 AND fc.chat_id = $2
+			AND f.id = fc.family_id AND f.chat_id <> fc.chat_id`
+
+	if _, err := r.db.ExecContext(ctx, query, familyID, chatID); err != nil {
+		return fmt.Errorf("failed to unlink family chat: %w", err)
+	}
+	return nil
+}
+
+// mergeFamily moves everything of family $1 into family $2 and deletes $1.
+// Shopping items and wishes are moved onto the lists the target family
+// already has, so nobody ends up with two lists.
+func mergeFamily(ctx context.Context, tx *sql.Tx, fromID, toID int64) error {
+	statements := []string{
+		`INSERT INTO family_members (family_id, user_id, role, joined_at)
+			SELECT $2, user_id, 'member', joined_at FROM family_members WHERE family_id = $1
+			ON CONFLICT (family_id, user_id) DO NOTHING`,
+
+		`UPDATE buying_items bi SET buying_list_id = t.id
+			FROM buying_lists o,
+				(SELECT id FROM buying_lists WHERE family_id = $2 ORDER BY created_at DESC LIMIT 1) t
+			WHERE bi.buying_list_id = o.id AND o.family_id = $1`,
+		`UPDATE shopping_trips st SET buying_list_id = t.id
+			FROM buying_lists o,
+				(SELECT id FROM buying_lists WHERE family_id = $2 ORDER BY created_at DESC LIMIT 1) t
+			WHERE st.buying_list_id = o.id AND o.family_id = $1`,
+		`DELETE FROM buying_lists
+			WHERE family_id = $1 AND EXISTS (SELECT 1 FROM buying_lists WHERE family_id = $2)`,
+		`UPDATE buying_lists SET family_id = $2 WHERE family_id = $1`,
+
+		`UPDATE wish_items wi SET wish_list_id = t.id
+			FROM wish_lists o, wish_lists t
+			WHERE wi.wish_list_id = o.id AND o.family_id = $1
+				AND t.family_id = $2 AND t.user_id = o.user_id`,
+		`DELETE FROM wish_lists o
+			WHERE o.family_id = $1
+				AND EXISTS (SELECT 1 FROM wish_lists t WHERE t.family_id = $2 AND t.user_id = o.user_id)`,
+		`UPDATE wish_lists SET family_id = $2 WHERE family_id = $1`,
+
+		`UPDATE calendar_events SET family_id = $2 WHERE family_id = $1`,
+		`UPDATE reminders SET family_id = $2 WHERE family_id = $1`,
+		`UPDATE attachments SET family_id = $2 WHERE family_id = $1`,
+		`DELETE FROM occasions o
+			WHERE o.family_id = $1 AND o.kind = 'birthday'
+				AND EXISTS (SELECT 1 FROM occasions t
+					WHERE t.family_id = $2 AND t.kind = 'birthday' AND t.celebrant_id = o.celebrant_id)`,
+		`UPDATE occasions SET family_id = $2 WHERE family_id = $1`,
+
+		`DELETE FROM families WHERE id = $1`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt, fromID, toID); err != nil {
+			return fmt.Errorf("failed to merge family %d into %d: %w", fromID, toID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *familyRepository) CreateLinkCode(ctx context.Context, code string, familyID, createdByID int64, expiresAt time.Time) error {
+	query := `
+		INSERT INTO family_link_codes (code, family_id, created_by_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.ExecContext(ctx, query, code, familyID, createdByID, expiresAt, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to create link code: %w", err)
+	}
+
+	return nil
+}
+
+// UseLinkCode redeems a link code and returns its family ID, or 0 when the
+// code is unknown or expired. Codes work only once.
+func (r *familyRepository) UseLinkCode(ctx context.Context, code string) (int64, error) {
+	query := `
+		DELETE FROM family_link_codes
+		WHERE code = $1
+		RETURNING family_id, expires_at`
+
+	var familyID int64
+	var expiresAt time.Time
+	err := r.db.QueryRowContext(ctx, query, code).Scan(&familyID, &expiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to use link code: %w", err)
+	}
+
+	if time.Now().After(expiresAt) {
+		return 0, nil
+	}
+
+	return familyID, nil
+}
+
+// AddMember adds the user to the family, as admin if the family has no
+// members yet and as a regular member otherwise. It returns the role given,
+// or "" if the user already was a member, whose role is left as it is.
+// Concurrent calls for the same family are serialized, so only one of them
+// can find the family empty.
+func (r *familyRepository) AddMember(ctx context.Context, familyID, userID int64) (string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM families WHERE id = $1 FOR UPDATE`, familyID); err != nil {
+		return "", fmt.Errorf("failed to lock family: %w", err)
+	}
+
+	query := `
+		INSERT INTO family_members (family_id, user_id, role, joined_at)
+		SELECT $1, $2,
+			CASE WHEN EXISTS (SELECT 1 FROM family_members WHERE family_id = $1)
+				THEN $3 ELSE $4 END,
+			$5
+		ON CONFLICT (family_id, user_id) DO NOTHING
+		RETURNING role`
+
+	var role string
+	err = tx.QueryRowContext(ctx, query, familyID, userID, models.FamilyRoleMember, models.FamilyRoleAdmin, time.Now()).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to add family member: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return role, nil
+}
+
+// RemoveMember removes the user from the family. It returns the role they
+// had, or "" if they were not a member, so of concurrent calls for the same
+// user only one gets a role.
+func (r *familyRepository) RemoveMember(ctx context.Context, familyID, userID int64) (string, error) {
+	query := `
+		DELETE FROM family_members WHERE family_id = $1 AND user_id = $2
+		RETURNING COALESCE(role, 'member')`
+
+	var role string
+	err := r.db.QueryRowContext(ctx, query, familyID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to remove family member: %w", err)
+	}
+
+	return role, nil
+}
+
 func (r *familyRepository) GetMembers(ctx context.Context, familyID int64) ([]*models.User, error) {
 	query := `
 		SELECT u.id, u.telegram_id, u.telegram_username, u.first_name, u.last_name, u.is_active, u.created_at, u.updated_at
@@ -135,6 +419,21 @@ func (r *familyRepository) GetMembers(ctx context.Context, familyID int64) ([]*m
 		WHERE fm.family_id = $1
 		ORDER BY fm.joined_at ASC`
 
+	return r.queryMembers(ctx, query, familyID)
+}
+
+func (r *familyRepository) GetAdmins(ctx context.Context, familyID int64) ([]*models.User, error) {
+	query := `
+		SELECT u.id, u.telegram_id, u.telegram_username, u.first_name, u.last_name, u.is_active, u.created_at, u.updated_at
+		FROM users u
+		INNER JOIN family_members fm ON fm.user_id = u.id
+		WHERE fm.family_id = $1 AND fm.role = 'admin'
+		ORDER BY fm.joined_at ASC`
+
+	return r.queryMembers(ctx, query, familyID)
+}
+
+func (r *familyRepository) queryMembers(ctx context.Context, query string, familyID int64) ([]*models.User, error) {
 	rows, err := r.db.QueryContext(ctx, query, familyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query family members: %w", err)
@@ -162,6 +461,105 @@ func (r *familyRepository) GetMembers(ctx context.Context, familyID int64) ([]*m
 	return members, rows.Err()
 }
 
+func (r *familyRepository) GetMemberRole(ctx context.Context, familyID, userID int64) (string, error) {
+	query := `
+		SELECT COALESCE(role, 'member')
+		FROM family_members
+		WHERE family_id = $1 AND user_id = $2`
+
+	var role string
+	err := r.db.QueryRowContext(ctx, query, familyID, userID).Scan(&role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get family member role: %w", err)
+	}
+
+	return role, nil
+}
+
+// SetMemberRole changes the member's role. It also lifts a demotion, so
+// chat administrators promoted again are kept admins from then on.
+func (r *familyRepository) SetMemberRole(ctx context.Context, familyID, userID int64, role string) error {
+	query := `UPDATE family_members SET role = $3, demoted = false WHERE family_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, familyID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to set family member role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("member not found in family %d", familyID)
+	}
+
+	return nil
+}
+
+// DemoteMember makes the member a regular member, marked as demoted, unless
+// no other admin would be left. It reports whether it did. Like AddMember it
+// locks the family, so admins demoting each other at the same time cannot
+// leave it without one.
+func (r *familyRepository) DemoteMember(ctx context.Context, familyID, userID int64) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM families WHERE id = $1 FOR UPDATE`, familyID); err != nil {
+		return false, fmt.Errorf("failed to lock family: %w", err)
+	}
+
+	query := `
+		UPDATE family_members SET role = $3, demoted = true
+		WHERE family_id = $1 AND user_id = $2
+			AND EXISTS (
+				SELECT 1 FROM family_members
+				WHERE family_id = $1 AND user_id <> $2 AND role = $4)`
+
+	result, err := tx.ExecContext(ctx, query, familyID, userID, models.FamilyRoleMember, models.FamilyRoleAdmin)
+	if err != nil {
+		return false, fmt.Errorf("failed to demote family member: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// PromoteChatAdmin makes the member an admin because they administer the
+// family's Telegram group, unless they were demoted. It reports whether the
+// member is an admin now.
+func (r *familyRepository) PromoteChatAdmin(ctx context.Context, familyID, userID int64) (bool, error) {
+	query := `
+		UPDATE family_members SET role = $3
+		WHERE family_id = $1 AND user_id = $2 AND NOT demoted`
+
+	result, err := r.db.ExecContext(ctx, query, familyID, userID, models.FamilyRoleAdmin)
+	if err != nil {
+		return false, fmt.Errorf("failed to promote chat admin: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (r *familyRepository) Update(ctx context.Context, family *models.Family) (*models.Family, error) {
 	query := `
 		UPDATE families
@@ -183,3 +581,78 @@ func (r *familyRepository) Update(ctx context.Context, family *models.Family) (*
 
 	return family, nil
 }
+
+// GetLanguage returns the language picked for the family's group chats, or
+// "" if nobody picked one.
+func (r *familyRepository) GetLanguage(ctx context.Context, familyID int64) (string, error) {
+	query := `SELECT COALESCE(language, '') FROM families WHERE id = $1`
+
+	var lang string
+	err := r.db.QueryRowContext(ctx, query, familyID).Scan(&lang)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get family language: %w", err)
+	}
+
+	return lang, nil
+}
+
+// SetLanguage stores the language of the family's group chats. An empty
+// lang clears the choice.
+func (r *familyRepository) SetLanguage(ctx context.Context, familyID int64, lang string) error {
+	query := `UPDATE families SET language = NULLIF($2, ''), updated_at = $3 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, familyID, lang, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set family language: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("family with ID %d not found", familyID)
+	}
+
+	return nil
+}
+
+// GetCapture reports whether the family's plain messages are read for
+// things to capture.
+func (r *familyRepository) GetCapture(ctx context.Context, familyID int64) (bool, error) {
+	query := `SELECT capture FROM families WHERE id = $1`
+
+	var on bool
+	err := r.db.QueryRowContext(ctx, query, familyID).Scan(&on)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get family capture: %w", err)
+	}
+
+	return on, nil
+}
+
+// SetCapture turns reading the family's plain messages on or off.
+func (r *familyRepository) SetCapture(ctx context.Context, familyID int64, on bool) error {
+	query := `UPDATE families SET capture = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, familyID, on, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set family capture: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("family with ID %d not found", familyID)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+func TestMoveHomeChat(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	families := NewFamilyRepository(db)
+
+	from := createTestFamily(t, db, -100)
+	to := createTestFamily(t, db, -300)
+	// A private chat linked before the other group does not become the
+	// home chat while a group is left
+	for _, chatID := range []int64{5, -200} {
+		if err := families.MoveChat(ctx, chatID, from.ID); err != nil {
+			t.Fatalf("link chat %d: %v", chatID, err)
+		}
+	}
+
+	if err := families.MoveChat(ctx, from.ChatID, to.ID); err != nil {
+		t.Fatalf("MoveChat: %v", err)
+	}
+
+	moved, err := families.GetByChatID(ctx, from.ChatID)
+	if err != nil || moved == nil || moved.ID != to.ID {
+		t.Fatalf("chat %d belongs to %+v (%v), want family %d", from.ChatID, moved, err, to.ID)
+	}
+	left, err := families.GetByID(ctx, from.ID)
+	if err != nil || left == nil {
+		t.Fatalf("GetByID(%d) = %+v, %v", from.ID, left, err)
+	}
+	if left.ChatID != -200 {
+		t.Errorf("home chat = %d, want the remaining group -200", left.ChatID)
+	}
+	chats, err := families.GetChats(ctx, from.ID)
+	if err != nil {
+		t.Fatalf("GetChats: %v", err)
+	}
+	if !slices.Contains(chats, left.ChatID) || slices.Contains(chats, from.ChatID) {
+		t.Errorf("chats = %v, home chat %d", chats, left.ChatID)
+	}
+	if home, err := families.GetByChatID(ctx, to.ChatID); err != nil || home == nil || home.ChatID != to.ChatID {
+		t.Errorf("target family = %+v (%v), want its home chat kept", home, err)
+	}
+}
+
+func TestMoveLastChat(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	families := NewFamilyRepository(db)
+
+	from := createTestFamily(t, db, -100)
+	to := createTestFamily(t, db, -300)
+	user := createTestUser(t, db, 1)
+	if _, err := families.AddMember(ctx, from.ID, user.ID); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+
+	if err := families.MoveChat(ctx, from.ChatID, to.ID); err != nil {
+		t.Fatalf("MoveChat: %v", err)
+	}
+
+	if left, err := families.GetByID(ctx, from.ID); err != nil || left != nil {
+		t.Errorf("GetByID(%d) = %+v, %v, want the family merged away", from.ID, left, err)
+	}
+	if role, err := families.GetMemberRole(ctx, to.ID, user.ID); err != nil || role != models.FamilyRoleMember {
+		t.Errorf("role in the merged family = %q, %v, want member", role, err)
+	}
+}
+
+func TestDemoteMember(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	families := NewFamilyRepository(db)
+
+	family := createTestFamily(t, db, -100)
+	first, second := createTestUser(t, db, 1), createTestUser(t, db, 2)
+	for _, user := range []int64{first.ID, second.ID} {
+		if _, err := families.AddMember(ctx, family.ID, user); err != nil {
+			t.Fatalf("AddMember: %v", err)
+		}
+	}
+	if err := families.SetMemberRole(ctx, family.ID, second.ID, models.FamilyRoleAdmin); err != nil {
+		t.Fatalf("SetMemberRole: %v", err)
+	}
+
+	if demoted, err := families.DemoteMember(ctx, family.ID, first.ID); err != nil || !demoted {
+		t.Fatalf("DemoteMember(first) = %v, %v, want demoted", demoted, err)
+	}
+	if demoted, err := families.DemoteMember(ctx, family.ID, second.ID); err != nil || demoted {
+		t.Errorf("DemoteMember(last admin) = %v, %v, want refused", demoted, err)
+	}
+
+	// Administering the group does not undo the demotion, promoting does
+	if granted, err := families.PromoteChatAdmin(ctx, family.ID, first.ID); err != nil || granted {
+		t.Errorf("PromoteChatAdmin(demoted) = %v, %v, want refused", granted, err)
+	}
+	if err := families.SetMemberRole(ctx, family.ID, first.ID, models.FamilyRoleAdmin); err != nil {
+		t.Fatalf("SetMemberRole: %v", err)
+	}
+	if granted, err := families.PromoteChatAdmin(ctx, family.ID, first.ID); err != nil || !granted {
+		t.Errorf("PromoteChatAdmin(promoted) = %v, %v, want granted", granted, err)
+	}
+}
+
+func TestRemoveMemberTwice(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	families := NewFamilyRepository(db)
+
+	family := createTestFamily(t, db, -100)
+	user := createTestUser(t, db, 1)
+	if _, err := families.AddMember(ctx, family.ID, user.ID); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+
+	if role, err := families.RemoveMember(ctx, family.ID, user.ID); err != nil || role != models.FamilyRoleAdmin {
+		t.Errorf("RemoveMember = %q, %v, want the admin role", role, err)
+	}
+	if role, err := families.RemoveMember(ctx, family.ID, user.ID); err != nil || role != "" {
+		t.Errorf("second RemoveMember = %q, %v, want no role and no error", role, err)
+	}
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/repository"
+)
+
+type occasionRepository struct {
+	db *sql.DB
+}
+
+// NewOccasionRepository creates a new occasion repository
+func NewOccasionRepository(db *sql.DB) repository.OccasionRepository {
+	return &occasionRepository{db: db}
+}
+
+// occasionColumns selects an occasion together with its celebrant, joined
+// as u.
+const occasionColumns = `
+		o.id, o.family_id, o.chat_id, o.kind, o.title, o.celebrant_id, o.month, o.day, o.year,
+		o.remind_days_before, o.last_reminded_for, o.created_by_id, o.created_at, o.updated_at,
+		u.telegram_id, COALESCE(u.telegram_username, ''), u.first_name, COALESCE(u.last_name, '')`
+
+func scanOccasion(row rowScanner) (*models.Occasion, error) {
+	o := &models.Occasion{}
+	var (
+		year                          sql.NullInt64
+		celebrantTGID                 sql.NullInt64
+		username, firstName, lastName sql.NullString
+	)
+	if err := row.Scan(
+		&o.ID,
+		&o.FamilyID,
+		&o.ChatID,
+		&o.Kind,
+		&o.Title,
+		&o.CelebrantID,
+		&o.Month,
+		&o.Day,
+		&year,
+		&o.RemindDaysBefore,
+		&o.LastRemindedFor,
+		&o.CreatedByID,
+		&o.CreatedAt,
+		&o.UpdatedAt,
+		&celebrantTGID,
+		&username,
+		&firstName,
+		&lastName,
+	); err != nil {
+		return nil, err
+	}
+	if year.Valid {
+		y := int(year.Int64)
+		o.Year = &y
+	}
+	if o.CelebrantID != nil {
+		o.Celebrant = &models.User{
+			ID:               *o.CelebrantID,
+			TelegramID:       celebrantTGID.Int64,
+			TelegramUsername: username.String,
+			FirstName:        firstName.String,
+			LastName:         lastName.String,
+		}
+	}
+	return o, nil
+}
+
+func (r *occasionRepository) Create(ctx context.Context, occasion *models.Occasion) (*models.Occasion, error) {
+	query := `
+		INSERT INTO occasions (family_id, chat_id, kind, title, celebrant_id, month, day, year, remind_days_before, created_by_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at, updated_at`
+
+	now := time.Now()
+	occasion.CreatedAt = now
+	occasion.UpdatedAt = now
+
+	if occasion.Kind == "" {
+		occasion.Kind = models.OccasionHoliday
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		occasion.FamilyID,
+		occasion.ChatID,
+		occasion.Kind,
+		occasion.Title,
+		occasion.CelebrantID,
+		occasion.Month,
+		occasion.Day,
+		occasion.Year,
+		occasion.RemindDaysBefore,
+		occasion.CreatedByID,
+		occasion.CreatedAt,
+		occasion.UpdatedAt,
+	).Scan(&occasion.ID, &occasion.CreatedAt, &occasion.UpdatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create occasion: %w", err)
+	}
+
+	return occasion, nil
+}
+
+func (r *occasionRepository) GetByID(ctx context.Context, id int64) (*models.Occasion, error) {
+	query := `
+		SELECT` + occasionColumns + `
+		FROM occasions o
+		LEFT JOIN users u ON u.id = o.celebrant_id
+		WHERE o.id = $1`
+
+	occasion, err := scanOccasion(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get occasion: %w", err)
+	}
+
+	return occasion, nil
+}
+
+func (r *occasionRepository) GetByFamily(ctx context.Context, familyID int64) ([]*models.Occasion, error) {
+	query := `
+		SELECT` + occasionColumns + `
+		FROM occasions o
+		LEFT JOIN users u ON u.id = o.celebrant_id
+		WHERE o.family_id = $1
+		ORDER BY o.month, o.day, o.title`
+
+	return r.query(ctx, query, familyID)
+}
+
+func (r *occasionRepository) GetAll(ctx context.Context) ([]*models.Occasion, error) {
+	query := `
+		SELECT` + occasionColumns + `
+		FROM occasions o
+		LEFT JOIN users u ON u.id = o.celebrant_id
+		ORDER BY o.family_id, o.month, o.day`
+
+	return r.query(ctx, query)
+}
+
+func (r *occasionRepository) query(ctx context.Context, query string, args ...any) ([]*models.Occasion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query occasions: %w", err)
+	}
+	defer rows.Close()
+
+	var occasions []*models.Occasion
+	for rows.Next() {
+		occasion, err := scanOccasion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan occasion: %w", err)
+		}
+		occasions = append(occasions, occasion)
+	}
+
+	return occasions, rows.Err()
+}
+
+func (r *occasionRepository) MarkReminded(ctx context.Context, id int64, date time.Time) error {
+	query := `
+		UPDATE occasions
+		SET last_reminded_for = $2, updated_at = $3
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, date.Format("2006-01-02"), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark occasion as reminded: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("occasion with ID %d not found", id)
+	}
+
+	return nil
+}
+
+func (r *occasionRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM occasions WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete occasion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("occasion with ID %d not found", id)
+	}
+
+	return nil
+}
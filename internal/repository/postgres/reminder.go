@@ -88,7 +88,7 @@ func (r *reminderRepository) GetByChatID(ctx context.Context, chatID int64) ([]*
 	query := `
 		SELECT id, family_id, chat_id, user_id, text, remind_at, repeat_interval, active, last_sent_at, created_at, updated_at
 		FROM reminders
-		WHERE chat_id = $1
+		WHERE chat_id IN ` + sameFamilyChats + `
 		ORDER BY remind_at ASC`
 
 	rows, err := r.db.QueryContext(ctx, query, chatID)
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+	"github.com/Kerhoff/TodoboT/internal/repository"
+)
+
+type searchRepository struct {
+	db *sql.DB
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *sql.DB) repository.SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search ranks the family's todos (by title, description and comments),
+// calendar events, shopping list items, wish items and active reminders
+// against the query. With types, only results of those types are found.
+// Results are grouped by type, the type with the best match first, and
+// ranked within each group. It also returns the total number of results.
+func (r *searchRepository) Search(ctx context.Context, chatID int64, query string, types []models.SearchResultType, limit, offset int) ([]*models.SearchResult, int, error) {
+	q := `WITH q AS (SELECT to_tsquery('simple', $2) AS q),
+		hits AS (
+			SELECT 'todo' AS type, t.id, t.title, ts_rank(t.search, q.q) AS rank
+			FROM todos t, q
+			WHERE t.chat_id IN ` + sameFamilyChats + ` AND t.search @@ q.q
+			UNION ALL
+			SELECT 'todo', t.id, t.title, ts_rank(c.search, q.q)
+			FROM comments c JOIN todos t ON t.id = c.todo_id, q
+			WHERE t.chat_id IN ` + sameFamilyChats + ` AND c.search @@ q.q
+			UNION ALL
+			SELECT 'event', e.id, e.title, ts_rank(e.search, q.q)
+			FROM calendar_events e, q
+			WHERE e.chat_id IN ` + sameFamilyChats + ` AND e.search @@ q.q
+			UNION ALL
+			SELECT 'buying_item', i.id, i.name, ts_rank(i.search, q.q)
+			FROM buying_items i JOIN buying_lists l ON l.id = i.buying_list_id, q
+			WHERE l.chat_id IN ` + sameFamilyChats + ` AND i.trip_id IS NULL AND i.search @@ q.q
+			UNION ALL
+			SELECT 'wish_item', w.id, w.name, ts_rank(w.search, q.q)
+			FROM wish_items w JOIN wish_lists wl ON wl.id = w.wish_list_id, q
+			WHERE wl.family_id IN (SELECT family_id FROM family_chats WHERE chat_id = $1) AND w.search @@ q.q
+			UNION ALL
+			SELECT 'reminder', rm.id, rm.text, ts_rank(rm.search, q.q)
+			FROM reminders rm, q
+			WHERE rm.chat_id IN ` + sameFamilyChats + ` AND rm.active AND rm.search @@ q.q
+		),
+		results AS (
+			SELECT type, id, MAX(title) AS title, SUM(rank) AS rank
+			FROM hits
+			WHERE cardinality($5::TEXT[]) = 0 OR type = ANY($5)
+			GROUP BY type, id
+		)
+		SELECT type, id, title, rank, COUNT(*) OVER ()
+		FROM results
+		ORDER BY MAX(rank) OVER (PARTITION BY type) DESC, type, rank DESC, id
+		LIMIT $3 OFFSET $4`
+
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, chatID, query, limit, offset, pq.Array(typeNames))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.SearchResult
+	var total int
+	for rows.Next() {
+		result := &models.SearchResult{}
+		if err := rows.Scan(&result.Type, &result.ID, &result.Title, &result.Rank, &total); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate search results: %w", err)
+	}
+	return results, total, nil
+}
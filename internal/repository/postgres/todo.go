@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/repository"
 )
@@ -18,9 +20,49 @@ func NewTodoRepository(db *sql.DB) repository.TodoRepository {
 	return &todoRepository{db: db}
 }
 
+// todoColumns selects a todo from todos together with its checklist
+// progress and tags.
+const todoColumns = `id, title, description, status, priority, deadline, created_by_id, assigned_to_id, chat_id, message_id,
+		recurrence, rotation, previous_id, auto_complete,
+		(SELECT COUNT(*) FILTER (WHERE c.done) FROM todo_checklist c WHERE c.todo_id = todos.id),
+		(SELECT COUNT(*) FROM todo_checklist c WHERE c.todo_id = todos.id),
+		ARRAY(SELECT t.name FROM todo_tags tt JOIN tags t ON t.id = tt.tag_id WHERE tt.todo_id = todos.id ORDER BY t.name),
+		created_at, updated_at`
+
+func scanTodo(row rowScanner) (*models.Todo, error) {
+	todo := &models.Todo{}
+	var rotation pq.Int64Array
+	var tags pq.StringArray
+	err := row.Scan(
+		&todo.ID, &todo.Title, &todo.Description, &todo.Status, &todo.Priority,
+		&todo.Deadline, &todo.CreatedByID, &todo.AssignedToID, &todo.ChatID, &todo.MessageID,
+		&todo.Recurrence, &rotation, &todo.PreviousID, &todo.AutoComplete,
+		&todo.ChecklistDone, &todo.ChecklistTotal, &tags, &todo.CreatedAt, &todo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	todo.Rotation = rotation
+	todo.Tags = tags
+	return todo, nil
+}
+
+func scanTodos(rows *sql.Rows) ([]*models.Todo, error) {
+	var todos []*models.Todo
+	for rows.Next() {
+		todo, err := scanTodo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan todo: %w", err)
+		}
+		todos = append(todos, todo)
+	}
+	return todos, rows.Err()
+}
+
 func (r *todoRepository) Create(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
-	query := `INSERT INTO todos (title, description, status, priority, deadline, created_by_id, assigned_to_id, chat_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	query := `INSERT INTO todos (title, description, status, priority, deadline, created_by_id, assigned_to_id, chat_id,
+			recurrence, rotation, previous_id, auto_complete, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id, created_at, updated_at`
 	now := time.Now()
 	todo.CreatedAt = now
@@ -31,26 +73,39 @@ func (r *todoRepository) Create(ctx context.Context, todo *models.Todo) (*models
 	if todo.Priority == "" {
 		todo.Priority = models.TodoPriorityMedium
 	}
-	err := r.db.QueryRowContext(ctx, query,
+	if todo.Recurrence == "" {
+		todo.Recurrence = models.TodoRecurrenceNone
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, query,
 		todo.Title, todo.Description, todo.Status, todo.Priority,
 		todo.Deadline, todo.CreatedByID, todo.AssignedToID, todo.ChatID,
+		todo.Recurrence, pq.Array(todo.Rotation), todo.PreviousID, todo.AutoComplete,
 		todo.CreatedAt, todo.UpdatedAt,
 	).Scan(&todo.ID, &todo.CreatedAt, &todo.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create todo: %w", err)
 	}
+	if err := setTodoTags(ctx, tx, todo.ID, todo.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return todo, nil
 }
 
 func (r *todoRepository) GetByID(ctx context.Context, id int64) (*models.Todo, error) {
-	query := `SELECT id, title, description, status, priority, deadline, created_by_id, assigned_to_id, chat_id, message_id, created_at, updated_at
+	query := `SELECT ` + todoColumns + `
 		FROM todos WHERE id = $1`
-	todo := &models.Todo{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&todo.ID, &todo.Title, &todo.Description, &todo.Status, &todo.Priority,
-		&todo.Deadline, &todo.CreatedByID, &todo.AssignedToID, &todo.ChatID,
-		&todo.MessageID, &todo.CreatedAt, &todo.UpdatedAt,
-	)
+	todo, err := scanTodo(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -61,114 +116,307 @@ func (r *todoRepository) GetByID(ctx context.Context, id int64) (*models.Todo, e
 }
 
 func (r *todoRepository) GetByChatID(ctx context.Context, chatID int64, filters repository.TodoFilters) ([]*models.Todo, error) {
-	query := `SELECT id, title, description, status, priority, deadline, created_by_id, assigned_to_id, chat_id, message_id, created_at, updated_at
-		FROM todos WHERE chat_id = $1`
-	args := []interface{}{chatID}
-	argIdx := 2
+	query, args := appendTodoFilters(`SELECT `+todoColumns+`
+		FROM todos WHERE chat_id IN `+sameFamilyChats, []interface{}{chatID}, filters)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todos: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTodos(rows)
+}
+
+func (r *todoRepository) GetByAssignedUser(ctx context.Context, userID int64, filters repository.TodoFilters) ([]*models.Todo, error) {
+	query, args := appendTodoFilters(`SELECT `+todoColumns+`
+		FROM todos WHERE assigned_to_id = $1`, []interface{}{userID}, filters)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assigned todos: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTodos(rows)
+}
+
+// appendTodoFilters adds the conditions, ordering and paging of filters to
+// a todo query that takes args.
+func appendTodoFilters(query string, args []interface{}, filters repository.TodoFilters) (string, []interface{}) {
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
 	if filters.Status != nil {
-		query += fmt.Sprintf(" AND status = $%d", argIdx)
-		args = append(args, *filters.Status)
-		argIdx++
+		query += " AND status = " + arg(*filters.Status)
 	}
 	if filters.Priority != nil {
-		query += fmt.Sprintf(" AND priority = $%d", argIdx)
-		args = append(args, *filters.Priority)
-		argIdx++
+		query += " AND priority = " + arg(*filters.Priority)
+	}
+	if len(filters.Tags) > 0 {
+		query += ` AND id IN (
+			SELECT tt.todo_id FROM todo_tags tt JOIN tags t ON t.id = tt.tag_id
+			WHERE t.name = ANY(` + arg(pq.Array(filters.Tags)) + `)
+			GROUP BY tt.todo_id
+			HAVING COUNT(*) = ` + arg(len(filters.Tags)) + `)`
+	}
+	if filters.AssignedToID != nil {
+		query += " AND assigned_to_id = " + arg(*filters.AssignedToID)
+	}
+	if filters.CreatedByID != nil {
+		query += " AND created_by_id = " + arg(*filters.CreatedByID)
+	}
+	if filters.DeadlineFrom != nil {
+		query += " AND deadline >= " + arg(*filters.DeadlineFrom)
+	}
+	if filters.DeadlineTo != nil {
+		query += " AND deadline < " + arg(*filters.DeadlineTo)
+	}
+	if filters.OverdueOnly {
+		query += " AND status = " + arg(models.TodoStatusPending) + " AND deadline < NOW()"
 	}
 
-	query += " ORDER BY created_at DESC"
+	switch filters.Sort {
+	case repository.TodoSortDeadline:
+		query += " ORDER BY deadline ASC NULLS LAST, created_at DESC"
+	case repository.TodoSortPriority:
+		query += " ORDER BY CASE priority WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, created_at DESC"
+	default:
+		query += " ORDER BY created_at DESC"
+	}
 	if filters.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argIdx)
-		args = append(args, filters.Limit)
-		argIdx++
+		query += " LIMIT " + arg(filters.Limit)
 	}
 	if filters.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET $%d", argIdx)
-		args = append(args, filters.Offset)
+		query += " OFFSET " + arg(filters.Offset)
 	}
+	return query, args
+}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+// Update saves the todo's details. Its status is left alone: that only
+// changes through ChangeStatus, so a status changed since the todo was
+// loaded is kept, and read back into the todo.
+func (r *todoRepository) Update(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
+	query := `UPDATE todos SET title=$2, description=$3, priority=$4, deadline=$5, assigned_to_id=$6,
+			recurrence=$7, rotation=$8, auto_complete=$9, updated_at=$10
+		WHERE id=$1 RETURNING status, updated_at`
+	todo.UpdatedAt = time.Now()
+	err := r.db.QueryRowContext(ctx, query,
+		todo.ID, todo.Title, todo.Description, todo.Priority,
+		todo.Deadline, todo.AssignedToID, todo.Recurrence, pq.Array(todo.Rotation), todo.AutoComplete, todo.UpdatedAt,
+	).Scan(&todo.Status, &todo.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query todos: %w", err)
+		return nil, fmt.Errorf("failed to update todo: %w", err)
+	}
+	return todo, nil
+}
+
+func (r *todoRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM todos WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete todo: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("todo %d not found", id)
+	}
+	return nil
+}
+
+func (r *todoRepository) UnassignUser(ctx context.Context, chatID, userID int64) (int64, error) {
+	query := `UPDATE todos SET assigned_to_id = NULL, updated_at = $3
+		WHERE chat_id IN ` + sameFamilyChats + ` AND assigned_to_id = $2 AND status = $4`
+	result, err := r.db.ExecContext(ctx, query, chatID, userID, time.Now(), models.TodoStatusPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unassign todos: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n, nil
+}
+
+// ChangeStatus moves the todo from event.FromStatus to event.ToStatus and
+// records the transition. It reports false, changing nothing, when the todo
+// is no longer in event.FromStatus.
+func (r *todoRepository) ChangeStatus(ctx context.Context, event *models.TodoEvent) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	event.CreatedAt = time.Now()
+	result, err := tx.ExecContext(ctx, `UPDATE todos SET status = $3, updated_at = $4 WHERE id = $1 AND status = $2`,
+		event.TodoID, event.FromStatus, event.ToStatus, event.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to change todo status: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	err = tx.QueryRowContext(ctx, `INSERT INTO todo_events (todo_id, user_id, from_status, to_status, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		event.TodoID, event.UserID, event.FromStatus, event.ToStatus, event.CreatedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record todo event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+func (r *todoRepository) GetEvents(ctx context.Context, todoID int64) ([]*models.TodoEvent, error) {
+	query := `SELECT e.id, e.todo_id, e.user_id, e.from_status, e.to_status, e.created_at,
+			u.id, u.telegram_id, u.telegram_username, u.first_name, u.last_name
+		FROM todo_events e
+		LEFT JOIN users u ON u.id = e.user_id
+		WHERE e.todo_id = $1
+		ORDER BY e.created_at ASC, e.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query todo events: %w", err)
 	}
 	defer rows.Close()
 
-	var todos []*models.Todo
+	var events []*models.TodoEvent
 	for rows.Next() {
-		todo := &models.Todo{}
+		event := &models.TodoEvent{}
+		var userID, telegramID sql.NullInt64
+		var username, firstName, lastName sql.NullString
 		if err := rows.Scan(
-			&todo.ID, &todo.Title, &todo.Description, &todo.Status, &todo.Priority,
-			&todo.Deadline, &todo.CreatedByID, &todo.AssignedToID, &todo.ChatID,
-			&todo.MessageID, &todo.CreatedAt, &todo.UpdatedAt,
+			&event.ID, &event.TodoID, &event.UserID, &event.FromStatus, &event.ToStatus, &event.CreatedAt,
+			&userID, &telegramID, &username, &firstName, &lastName,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan todo: %w", err)
+			return nil, fmt.Errorf("failed to scan todo event: %w", err)
 		}
-		todos = append(todos, todo)
+		if userID.Valid {
+			event.User = &models.User{
+				ID:               userID.Int64,
+				TelegramID:       telegramID.Int64,
+				TelegramUsername: username.String,
+				FirstName:        firstName.String,
+				LastName:         lastName.String,
+			}
+		}
+		events = append(events, event)
 	}
-	return todos, rows.Err()
+	return events, rows.Err()
 }
 
-func (r *todoRepository) GetByAssignedUser(ctx context.Context, userID int64, filters repository.TodoFilters) ([]*models.Todo, error) {
-	query := `SELECT id, title, description, status, priority, deadline, created_by_id, assigned_to_id, chat_id, message_id, created_at, updated_at
-		FROM todos WHERE assigned_to_id = $1`
-	args := []interface{}{userID}
-	argIdx := 2
-
-	if filters.Status != nil {
-		query += fmt.Sprintf(" AND status = $%d", argIdx)
-		args = append(args, *filters.Status)
-		argIdx++
+// GetNextOccurrence returns the occurrence of a recurring todo that was
+// created when the given one was completed, or nil if there is none.
+func (r *todoRepository) GetNextOccurrence(ctx context.Context, todoID int64) (*models.Todo, error) {
+	query := `SELECT ` + todoColumns + `
+		FROM todos WHERE previous_id = $1
+		ORDER BY id DESC LIMIT 1`
+	todo, err := scanTodo(r.db.QueryRowContext(ctx, query, todoID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get next occurrence: %w", err)
 	}
-	query += " ORDER BY created_at DESC"
-	if filters.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT $%d", argIdx)
-		args = append(args, filters.Limit)
+	return todo, nil
+}
+
+// AddChecklistItem appends an item to the todo's checklist and numbers it.
+func (r *todoRepository) AddChecklistItem(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	query := `INSERT INTO todo_checklist (todo_id, position, text, created_at)
+		SELECT $1, COALESCE(MAX(position), 0) + 1, $2, $3 FROM todo_checklist WHERE todo_id = $1
+		RETURNING id, position, created_at`
+	item.CreatedAt = time.Now()
+	err := r.db.QueryRowContext(ctx, query, item.TodoID, item.Text, item.CreatedAt).
+		Scan(&item.ID, &item.Position, &item.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add checklist item: %w", err)
 	}
+	return item, nil
+}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+func (r *todoRepository) GetChecklist(ctx context.Context, todoID int64) ([]*models.ChecklistItem, error) {
+	query := `SELECT id, todo_id, position, text, done, done_by_id, done_at, created_at
+		FROM todo_checklist WHERE todo_id = $1
+		ORDER BY position ASC`
+	rows, err := r.db.QueryContext(ctx, query, todoID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query assigned todos: %w", err)
+		return nil, fmt.Errorf("failed to query checklist: %w", err)
 	}
 	defer rows.Close()
 
-	var todos []*models.Todo
+	var items []*models.ChecklistItem
 	for rows.Next() {
-		todo := &models.Todo{}
+		item := &models.ChecklistItem{}
 		if err := rows.Scan(
-			&todo.ID, &todo.Title, &todo.Description, &todo.Status, &todo.Priority,
-			&todo.Deadline, &todo.CreatedByID, &todo.AssignedToID, &todo.ChatID,
-			&todo.MessageID, &todo.CreatedAt, &todo.UpdatedAt,
+			&item.ID, &item.TodoID, &item.Position, &item.Text,
+			&item.Done, &item.DoneByID, &item.DoneAt, &item.CreatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("failed to scan todo: %w", err)
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
 		}
-		todos = append(todos, todo)
+		items = append(items, item)
 	}
-	return todos, rows.Err()
+	return items, rows.Err()
 }
 
-func (r *todoRepository) Update(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
-	query := `UPDATE todos SET title=$2, description=$3, status=$4, priority=$5, deadline=$6, assigned_to_id=$7, updated_at=$8
-		WHERE id=$1 RETURNING updated_at`
-	todo.UpdatedAt = time.Now()
-	err := r.db.QueryRowContext(ctx, query,
-		todo.ID, todo.Title, todo.Description, todo.Status, todo.Priority,
-		todo.Deadline, todo.AssignedToID, todo.UpdatedAt,
-	).Scan(&todo.UpdatedAt)
+// SetChecklistItemDone ticks an item off, recording who did it, or unticks
+// it.
+func (r *todoRepository) SetChecklistItemDone(ctx context.Context, itemID int64, done bool, userID *int64) error {
+	query := `UPDATE todo_checklist SET done = $2,
+			done_by_id = CASE WHEN $2 THEN $3::BIGINT END,
+			done_at = CASE WHEN $2 THEN $4::TIMESTAMPTZ END
+		WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, itemID, done, userID, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("failed to update todo: %w", err)
+		return fmt.Errorf("failed to update checklist item: %w", err)
 	}
-	return todo, nil
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("checklist item %d not found", itemID)
+	}
+	return nil
 }
 
-func (r *todoRepository) Delete(ctx context.Context, id int64) error {
-	result, err := r.db.ExecContext(ctx, `DELETE FROM todos WHERE id = $1`, id)
+// SetTags replaces the tags of the todo.
+func (r *todoRepository) SetTags(ctx context.Context, todoID int64, tags []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to delete todo: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	n, _ := result.RowsAffected()
-	if n == 0 {
-		return fmt.Errorf("todo %d not found", id)
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM todo_tags WHERE todo_id = $1`, todoID); err != nil {
+		return fmt.Errorf("failed to clear todo tags: %w", err)
+	}
+	if err := setTodoTags(ctx, tx, todoID, tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// setTodoTags adds the tags to the todo, creating tags that do not exist
+// yet.
+func setTodoTags(ctx context.Context, tx *sql.Tx, todoID int64, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO tags (name) SELECT UNNEST($1::TEXT[]) ON CONFLICT (name) DO NOTHING`,
+		pq.Array(tags))
+	if err != nil {
+		return fmt.Errorf("failed to create tags: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO todo_tags (todo_id, tag_id)
+		SELECT $1, id FROM tags WHERE name = ANY($2)
+		ON CONFLICT DO NOTHING`, todoID, pq.Array(tags))
+	if err != nil {
+		return fmt.Errorf("failed to tag todo: %w", err)
 	}
 	return nil
 }
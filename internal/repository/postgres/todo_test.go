@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+func TestUpdateKeepsStatus(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	todos := NewTodoRepository(db)
+
+	user := createTestUser(t, db, 1)
+	todo, err := todos.Create(ctx, &models.Todo{Title: "Water the plants", CreatedByID: user.ID, ChatID: -100})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Someone completes the todo while another edit has it loaded
+	stale := *todo
+	changed, err := todos.ChangeStatus(ctx, &models.TodoEvent{
+		TodoID:     todo.ID,
+		UserID:     &user.ID,
+		FromStatus: models.TodoStatusPending,
+		ToStatus:   models.TodoStatusCompleted,
+	})
+	if err != nil || !changed {
+		t.Fatalf("ChangeStatus = %v, %v", changed, err)
+	}
+
+	deadline := time.Now().Add(24 * time.Hour)
+	stale.Deadline = &deadline
+	if _, err := todos.Update(ctx, &stale); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if stale.Status != models.TodoStatusCompleted {
+		t.Errorf("updated todo has status %s, want it read back as completed", stale.Status)
+	}
+
+	saved, err := todos.GetByID(ctx, todo.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if saved.Status != models.TodoStatusCompleted {
+		t.Errorf("status = %s, want completed kept", saved.Status)
+	}
+	if saved.Deadline == nil {
+		t.Error("deadline was not saved")
+	}
+}
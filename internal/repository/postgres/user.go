@@ -174,4 +174,76 @@ func (r *userRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// GetSelectedFamily returns the family the user picked with /family, or 0
+// if they did not pick one.
+func (r *userRepository) GetSelectedFamily(ctx context.Context, userID int64) (int64, error) {
+	query := `
+		SELECT COALESCE(selected_family_id, 0)
+		FROM user_settings
+		WHERE user_id = $1`
+
+	var familyID int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&familyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get selected family: %w", err)
+	}
+
+	return familyID, nil
+}
+
+// SetSelectedFamily stores the family the user works on in their private
+// chat. A familyID of 0 clears the selection.
+func (r *userRepository) SetSelectedFamily(ctx context.Context, userID, familyID int64) error {
+	query := `
+		INSERT INTO user_settings (user_id, selected_family_id, updated_at)
+		VALUES ($1, NULLIF($2::BIGINT, 0), $3)
+		ON CONFLICT (user_id) DO UPDATE SET selected_family_id = NULLIF($2::BIGINT, 0), updated_at = $3`
+
+	_, err := r.db.ExecContext(ctx, query, userID, familyID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set selected family: %w", err)
+	}
+
+	return nil
+}
+
+// GetLanguage returns the language the user picked with /lang, or "" if
+// they did not pick one.
+func (r *userRepository) GetLanguage(ctx context.Context, userID int64) (string, error) {
+	query := `
+		SELECT COALESCE(language, '')
+		FROM user_settings
+		WHERE user_id = $1`
+
+	var lang string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&lang)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get language: %w", err)
+	}
+
+	return lang, nil
+}
+
+// SetLanguage stores the language the bot talks to the user in. An empty
+// lang clears the choice.
+func (r *userRepository) SetLanguage(ctx context.Context, userID int64, lang string) error {
+	query := `
+		INSERT INTO user_settings (user_id, language, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3)
+		ON CONFLICT (user_id) DO UPDATE SET language = NULLIF($2, ''), updated_at = $3`
+
+	_, err := r.db.ExecContext(ctx, query, userID, lang, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set language: %w", err)
+	}
+
+	return nil
+}
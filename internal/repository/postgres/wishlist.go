@@ -21,8 +21,8 @@ func NewWishListRepository(db *sql.DB) repository.WishListRepository {
 
 func (r *wishListRepository) CreateList(ctx context.Context, list *models.WishList) (*models.WishList, error) {
 	query := `
-		INSERT INTO wish_lists (family_id, user_id, name, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO wish_lists (family_id, user_id, name, reserved_hint, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, created_at, updated_at`
 
 	now := time.Now()
@@ -33,6 +33,7 @@ func (r *wishListRepository) CreateList(ctx context.Context, list *models.WishLi
 		list.FamilyID,
 		list.UserID,
 		list.Name,
+		list.ReservedHint,
 		list.CreatedAt,
 		list.UpdatedAt,
 	).Scan(&list.ID, &list.CreatedAt, &list.UpdatedAt)
@@ -46,7 +47,7 @@ func (r *wishListRepository) CreateList(ctx context.Context, list *models.WishLi
 
 func (r *wishListRepository) GetListByUser(ctx context.Context, userID, familyID int64) (*models.WishList, error) {
 	query := `
-		SELECT id, family_id, user_id, name, created_at, updated_at
+		SELECT id, family_id, user_id, name, reserved_hint, occasion_id, created_at, updated_at
 		FROM wish_lists
 		WHERE user_id = $1 AND family_id = $2
 		ORDER BY created_at DESC
@@ -58,6 +59,8 @@ func (r *wishListRepository) GetListByUser(ctx context.Context, userID, familyID
 		&list.FamilyID,
 		&list.UserID,
 		&list.Name,
+		&list.ReservedHint,
+		&list.OccasionID,
 		&list.CreatedAt,
 		&list.UpdatedAt,
 	)
@@ -74,7 +77,7 @@ func (r *wishListRepository) GetListByUser(ctx context.Context, userID, familyID
 
 func (r *wishListRepository) GetListByID(ctx context.Context, id int64) (*models.WishList, error) {
 	query := `
-		SELECT id, family_id, user_id, name, created_at, updated_at
+		SELECT id, family_id, user_id, name, reserved_hint, occasion_id, created_at, updated_at
 		FROM wish_lists
 		WHERE id = $1`
 
@@ -84,6 +87,8 @@ func (r *wishListRepository) GetListByID(ctx context.Context, id int64) (*models
 		&list.FamilyID,
 		&list.UserID,
 		&list.Name,
+		&list.ReservedHint,
+		&list.OccasionID,
 		&list.CreatedAt,
 		&list.UpdatedAt,
 	)
@@ -100,7 +105,7 @@ func (r *wishListRepository) GetListByID(ctx context.Context, id int64) (*models
 
 func (r *wishListRepository) GetListsByFamily(ctx context.Context, familyID int64) ([]*models.WishList, error) {
 	query := `
-		SELECT id, family_id, user_id, name, created_at, updated_at
+		SELECT id, family_id, user_id, name, reserved_hint, occasion_id, created_at, updated_at
 		FROM wish_lists
 		WHERE family_id = $1
 		ORDER BY created_at ASC`
@@ -119,6 +124,8 @@ func (r *wishListRepository) GetListsByFamily(ctx context.Context, familyID int6
 			&list.FamilyID,
 			&list.UserID,
 			&list.Name,
+			&list.ReservedHint,
+			&list.OccasionID,
 			&list.CreatedAt,
 			&list.UpdatedAt,
 		); err != nil {
@@ -130,10 +137,33 @@ func (r *wishListRepository) GetListsByFamily(ctx context.Context, familyID int6
 	return lists, rows.Err()
 }
 
+func (r *wishListRepository) SetOccasion(ctx context.Context, listID int64, occasionID *int64) error {
+	query := `
+		UPDATE wish_lists
+		SET occasion_id = $2, updated_at = $3
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, listID, occasionID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to link wish list to occasion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("wish list with ID %d not found", listID)
+	}
+
+	return nil
+}
+
 func (r *wishListRepository) AddItem(ctx context.Context, item *models.WishItem) (*models.WishItem, error) {
 	query := `
-		INSERT INTO wish_items (wish_list_id, name, url, price, notes, reserved, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO wish_items (wish_list_id, name, url, price, notes, image_url, priority, reserved, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id, created_at`
 
 	item.Reserved = false
@@ -145,6 +175,8 @@ func (r *wishListRepository) AddItem(ctx context.Context, item *models.WishItem)
 		item.URL,
 		item.Price,
 		item.Notes,
+		item.ImageURL,
+		item.Priority,
 		item.Reserved,
 		item.CreatedAt,
 	).Scan(&item.ID, &item.CreatedAt)
@@ -156,12 +188,86 @@ func (r *wishListRepository) AddItem(ctx context.Context, item *models.WishItem)
 	return item, nil
 }
 
+// wishItemColumns lists the columns read by scanWishItem. The reserver is
+// joined in as u.
+const wishItemColumns = `
+		wi.id, wi.wish_list_id, wi.name, COALESCE(wi.url, ''), COALESCE(wi.price, ''), COALESCE(wi.notes, ''),
+		wi.image_url, wi.priority, wi.reserved, wi.reserved_by_id, wi.purchased, wi.purchased_at,
+		COALESCE((SELECT SUM(p.amount) FROM wish_pledges p WHERE p.wish_item_id = wi.id), 0),
+		wi.created_at,
+		u.id, u.telegram_id, COALESCE(u.telegram_username, ''), u.first_name, COALESCE(u.last_name, '')`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWishItem(row rowScanner) (*models.WishItem, error) {
+	item := &models.WishItem{}
+	var (
+		reserverID, reserverTGID      sql.NullInt64
+		username, firstName, lastName sql.NullString
+	)
+	if err := row.Scan(
+		&item.ID,
+		&item.WishListID,
+		&item.Name,
+		&item.URL,
+		&item.Price,
+		&item.Notes,
+		&item.ImageURL,
+		&item.Priority,
+		&item.Reserved,
+		&item.ReservedByID,
+		&item.Purchased,
+		&item.PurchasedAt,
+		&item.Pledged,
+		&item.CreatedAt,
+		&reserverID,
+		&reserverTGID,
+		&username,
+		&firstName,
+		&lastName,
+	); err != nil {
+		return nil, err
+	}
+	if reserverID.Valid {
+		item.ReservedBy = &models.User{
+			ID:               reserverID.Int64,
+			TelegramID:       reserverTGID.Int64,
+			TelegramUsername: username.String,
+			FirstName:        firstName.String,
+			LastName:         lastName.String,
+		}
+	}
+	return item, nil
+}
+
+func (r *wishListRepository) GetItemByID(ctx context.Context, itemID int64) (*models.WishItem, error) {
+	query := `
+		SELECT` + wishItemColumns + `
+		FROM wish_items wi
+		LEFT JOIN users u ON u.id = wi.reserved_by_id
+		WHERE wi.id = $1`
+
+	item, err := scanWishItem(r.db.QueryRowContext(ctx, query, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get wish item by ID: %w", err)
+	}
+
+	return item, nil
+}
+
 func (r *wishListRepository) GetItems(ctx context.Context, listID int64) ([]*models.WishItem, error) {
 	query := `
-		SELECT id, wish_list_id, name, url, price, notes, reserved, reserved_by_id, created_at
-		FROM wish_items
-		WHERE wish_list_id = $1
-		ORDER BY created_at ASC`
+		SELECT` + wishItemColumns + `
+		FROM wish_items wi
+		LEFT JOIN users u ON u.id = wi.reserved_by_id
+		WHERE wi.wish_list_id = $1
+		ORDER BY wi.priority = 0, wi.priority, wi.created_at ASC`
 
 	rows, err := r.db.QueryContext(ctx, query, listID)
 	if err != nil {
@@ -171,18 +277,8 @@ func (r *wishListRepository) GetItems(ctx context.Context, listID int64) ([]*mod
 
 	var items []*models.WishItem
 	for rows.Next() {
-		item := &models.WishItem{}
-		if err := rows.Scan(
-			&item.ID,
-			&item.WishListID,
-			&item.Name,
-			&item.URL,
-			&item.Price,
-			&item.Notes,
-			&item.Reserved,
-			&item.ReservedByID,
-			&item.CreatedAt,
-		); err != nil {
+		item, err := scanWishItem(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan wish item: %w", err)
 		}
 		items = append(items, item)
@@ -191,6 +287,60 @@ func (r *wishListRepository) GetItems(ctx context.Context, listID int64) ([]*mod
 	return items, rows.Err()
 }
 
+func (r *wishListRepository) SetReservedHint(ctx context.Context, listID int64, enabled bool) error {
+	query := `
+		UPDATE wish_lists
+		SET reserved_hint = $2, updated_at = $3
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, listID, enabled, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update wish list: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("wish list with ID %d not found", listID)
+	}
+
+	return nil
+}
+
+func (r *wishListRepository) UpdateItem(ctx context.Context, item *models.WishItem) error {
+	query := `
+		UPDATE wish_items
+		SET name = $2, url = $3, price = $4, notes = $5, image_url = $6, priority = $7
+		WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		item.ID,
+		item.Name,
+		item.URL,
+		item.Price,
+		item.Notes,
+		item.ImageURL,
+		item.Priority,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update wish item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("wish item with ID %d not found", item.ID)
+	}
+
+	return nil
+}
+
 func (r *wishListRepository) ReserveItem(ctx context.Context, itemID, reservedByID int64) error {
 	query := `
 		UPDATE wish_items
@@ -256,3 +406,148 @@ func (r *wishListRepository) DeleteItem(ctx context.Context, itemID int64) error
 
 	return nil
 }
+
+func (r *wishListRepository) SetPledge(ctx context.Context, itemID, userID int64, amount float64) error {
+	query := `
+		INSERT INTO wish_pledges (wish_item_id, user_id, amount, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (wish_item_id, user_id)
+		DO UPDATE SET amount = EXCLUDED.amount, updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.ExecContext(ctx, query, itemID, userID, amount, time.Now()); err != nil {
+		return fmt.Errorf("failed to save pledge: %w", err)
+	}
+
+	return nil
+}
+
+func (r *wishListRepository) DeletePledge(ctx context.Context, itemID, userID int64) error {
+	query := `DELETE FROM wish_pledges WHERE wish_item_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, itemID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pledge: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no pledge by user %d for wish item %d", userID, itemID)
+	}
+
+	return nil
+}
+
+func (r *wishListRepository) GetPledges(ctx context.Context, itemID int64) ([]*models.WishPledge, error) {
+	query := `
+		SELECT p.id, p.wish_item_id, p.user_id, p.amount, p.created_at, p.updated_at,
+		       u.telegram_id, COALESCE(u.telegram_username, ''), u.first_name, COALESCE(u.last_name, '')
+		FROM wish_pledges p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.wish_item_id = $1
+		ORDER BY p.created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pledges: %w", err)
+	}
+	defer rows.Close()
+
+	var pledges []*models.WishPledge
+	for rows.Next() {
+		p := &models.WishPledge{User: &models.User{}}
+		if err := rows.Scan(
+			&p.ID,
+			&p.WishItemID,
+			&p.UserID,
+			&p.Amount,
+			&p.CreatedAt,
+			&p.UpdatedAt,
+			&p.User.TelegramID,
+			&p.User.TelegramUsername,
+			&p.User.FirstName,
+			&p.User.LastName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan pledge: %w", err)
+		}
+		p.User.ID = p.UserID
+		pledges = append(pledges, p)
+	}
+
+	return pledges, rows.Err()
+}
+
+func (r *wishListRepository) MarkPurchased(ctx context.Context, itemID int64) error {
+	query := `
+		UPDATE wish_items
+		SET purchased = true, purchased_at = $2
+		WHERE id = $1 AND purchased = false`
+
+	result, err := r.db.ExecContext(ctx, query, itemID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark wish item as purchased: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("wish item with ID %d not found or already purchased", itemID)
+	}
+
+	return nil
+}
+
+// ReleaseUserReservations withdraws the user's pledges on unpurchased items
+// in the family and hands each of their reservations to the earliest
+// remaining pledger, or releases it when nobody else pledged. It returns the
+// number of reservations affected.
+func (r *wishListRepository) ReleaseUserReservations(ctx context.Context, familyID, userID int64) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM wish_pledges p
+		USING wish_items wi, wish_lists wl
+		WHERE p.wish_item_id = wi.id AND wi.wish_list_id = wl.id
+			AND wl.family_id = $1 AND p.user_id = $2 AND wi.purchased = false`,
+		familyID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to withdraw pledges: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE wish_items wi
+		SET reserved_by_id = (
+				SELECT p.user_id FROM wish_pledges p
+				WHERE p.wish_item_id = wi.id
+				ORDER BY p.created_at ASC, p.id ASC
+				LIMIT 1),
+			reserved = EXISTS (SELECT 1 FROM wish_pledges p WHERE p.wish_item_id = wi.id)
+		FROM wish_lists wl
+		WHERE wi.wish_list_id = wl.id AND wl.family_id = $1
+			AND wi.reserved_by_id = $2 AND wi.purchased = false`,
+		familyID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release reservations: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rowsAffected, nil
+}
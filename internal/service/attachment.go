@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// SaveAttachment writes the file content to the blob store and records its
+// metadata. The storage key is derived from the chat and entity so files of
+// one family stay grouped together. If the metadata cannot be stored the
+// blob is removed again.
+func (s *Service) SaveAttachment(ctx context.Context, a *models.Attachment, content io.Reader) (*models.Attachment, error) {
+	a.StorageKey = path.Join(
+		fmt.Sprintf("%d", a.ChatID),
+		string(a.EntityType),
+		fmt.Sprintf("%d-%d%s", a.EntityID, time.Now().UnixNano(), path.Ext(a.FileName)),
+	)
+
+	size, err := s.Blobs.Put(ctx, a.StorageKey, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	a.SizeBytes = size
+
+	created, err := s.Attachments.Create(ctx, a)
+	if err != nil {
+		if delErr := s.Blobs.Delete(ctx, a.StorageKey); delErr != nil {
+			s.logger.Errorf("Failed to remove orphaned blob %s: %v", a.StorageKey, delErr)
+		}
+		return nil, err
+	}
+
+	s.logger.Infof("Stored attachment %d for %s %d (%d bytes)", created.ID, a.EntityType, a.EntityID, size)
+	return created, nil
+}
+
+// AttachTelegramFile links a file sent to the bot to a record. With
+// DownloadMedia the file is copied to the blob store; otherwise only its
+// file_id is kept and the file is fetched from Telegram when opened.
+func (s *Service) AttachTelegramFile(ctx context.Context, a *models.Attachment) (*models.Attachment, error) {
+	if s.DownloadMedia {
+		content, err := s.TelegramFiles.Open(ctx, a.TelegramFileID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download attachment: %w", err)
+		}
+		defer content.Close()
+		return s.SaveAttachment(ctx, a, content)
+	}
+
+	created, err := s.Attachments.Create(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Linked Telegram file as attachment %d for %s %d", created.ID, a.EntityType, a.EntityID)
+	return created, nil
+}
+
+// LoadAttachments returns the attachments of the records of one kind with
+// the given IDs, grouped by record ID.
+func (s *Service) LoadAttachments(ctx context.Context, entityType models.AttachmentEntity, ids []int64) (map[int64][]models.Attachment, error) {
+	attachments, err := s.Attachments.GetByEntities(ctx, entityType, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64][]models.Attachment)
+	for _, a := range attachments {
+		byID[a.EntityID] = append(byID[a.EntityID], *a)
+	}
+	return byID, nil
+}
+
+// OpenAttachment returns a reader for the content of an attachment loaded
+// with Attachments.GetByID, from the blob store or, for files that were not
+// downloaded, from Telegram. Callers check access to the attachment's family
+// first. The caller must close the reader.
+func (s *Service) OpenAttachment(ctx context.Context, a *models.Attachment) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	var err error
+	if a.StorageKey != "" {
+		rc, err = s.Blobs.Open(ctx, a.StorageKey)
+	} else {
+		rc, err = s.TelegramFiles.Open(ctx, a.TelegramFileID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment %d: %w", a.ID, err)
+	}
+
+	return rc, nil
+}
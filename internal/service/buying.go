@@ -0,0 +1,14 @@
+package service
+
+import "time"
+
+// SpendingPeriodStart returns the start of the spending report window that
+// covers the given number of calendar months, counting the month of now as
+// the first one.
+func SpendingPeriodStart(now time.Time, months int) time.Time {
+	if months < 1 {
+		months = 1
+	}
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return start.AddDate(0, -(months - 1), 0)
+}
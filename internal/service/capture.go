@@ -0,0 +1,17 @@
+package service
+
+import "context"
+
+// SetFamilyCapture turns reading the family's plain messages for things to
+// buy, do or be reminded of on or off. Only family admins may change it.
+func (s *Service) SetFamilyCapture(ctx context.Context, familyID, userID int64, on bool) error {
+	if err := s.Authorize(ctx, familyID, userID); err != nil {
+		return err
+	}
+	if err := s.Families.SetCapture(ctx, familyID, on); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d set capture of family %d to %t", userID, familyID, on)
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// ErrChecklistItemNotFound is returned when a todo has no checklist item with
+// the given number.
+var ErrChecklistItemNotFound = errors.New("checklist item not found")
+
+// CheckResult tells what ticking a checklist item changed.
+type CheckResult struct {
+	Item *models.ChecklistItem
+	// Completed is set when the last open item was ticked off and the todo
+	// completed itself.
+	Completed bool
+	// Next is the next occurrence of a recurring todo that completed itself.
+	Next *models.Todo
+}
+
+// AddChecklistItem adds an item to the end of the todo's checklist. Items
+// cannot be added to todos that are no longer pending.
+func (s *Service) AddChecklistItem(ctx context.Context, todo *models.Todo, text string) (*models.ChecklistItem, error) {
+	if !todo.IsPending() {
+		return nil, ErrTodoTransition
+	}
+
+	item, err := s.Todos.AddChecklistItem(ctx, &models.ChecklistItem{
+		TodoID: todo.ID,
+		Text:   strings.TrimSpace(text),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	todo.ChecklistTotal++
+	return item, nil
+}
+
+// ToggleChecklistItem ticks item number n of the todo's checklist off, or
+// back on if it was already done. When the todo completes automatically and
+// the last open item is ticked off, the todo is completed on behalf of the
+// user, provided they may complete it.
+func (s *Service) ToggleChecklistItem(ctx context.Context, todo *models.Todo, userID int64, n int) (*CheckResult, error) {
+	items, err := s.Todos.GetChecklist(ctx, todo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var item *models.ChecklistItem
+	for _, i := range items {
+		if i.Position == n {
+			item = i
+		}
+	}
+	if item == nil {
+		return nil, ErrChecklistItemNotFound
+	}
+
+	item.Done = !item.Done
+	if err := s.Todos.SetChecklistItemDone(ctx, item.ID, item.Done, &userID); err != nil {
+		return nil, err
+	}
+
+	result := &CheckResult{Item: item}
+	todo.ChecklistDone = 0
+	for _, i := range items {
+		if i.Done {
+			todo.ChecklistDone++
+		}
+	}
+	todo.ChecklistTotal = len(items)
+
+	if !item.Done || !todo.AutoComplete || !todo.IsPending() || todo.ChecklistDone < todo.ChecklistTotal {
+		return result, nil
+	}
+
+	next, err := s.TransitionTodo(ctx, todo, userID, models.TodoStatusCompleted)
+	if errors.Is(err, ErrForbidden) || errors.Is(err, ErrTodoTransition) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Completed = true
+	result.Next = next
+	return result, nil
+}
+
+// SetAutoComplete turns automatic completion of the todo on or off. The
+// creator, the assignee and family admins may change it.
+func (s *Service) SetAutoComplete(ctx context.Context, todo *models.Todo, userID int64, on bool) error {
+	owners := []int64{todo.CreatedByID}
+	if todo.AssignedToID != nil {
+		owners = append(owners, *todo.AssignedToID)
+	}
+	if err := s.AuthorizeChat(ctx, todo.ChatID, userID, owners...); err != nil {
+		return err
+	}
+
+	todo.AutoComplete = on
+	if _, err := s.Todos.Update(ctx, todo); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d set auto-complete of todo %d to %t", userID, todo.ID, on)
+	return nil
+}
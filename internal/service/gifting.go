@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+var (
+	// ErrWishPurchased is returned when a purchased gift is changed.
+	ErrWishPurchased = errors.New("wish item has already been purchased")
+	// ErrWishHasPledges is returned when the organizer tries to step back
+	// while other members still pledge toward the item.
+	ErrWishHasPledges = errors.New("other members have pledged toward this wish item")
+)
+
+var amountRegex = regexp.MustCompile(`\d[\d\s.,]*`)
+
+// WishTarget extracts the numeric target amount from a free-form wish
+// price such as "1,299.00 EUR" or "49,90 €".
+func WishTarget(price string) (float64, bool) {
+	raw := amountRegex.FindString(price)
+	if raw == "" {
+		return 0, false
+	}
+	raw = strings.Join(strings.Fields(raw), "")
+	raw = strings.TrimRight(raw, ".,")
+
+	// With both separators the last one is the decimal point
+	if strings.Contains(raw, ",") && strings.Contains(raw, ".") {
+		if strings.LastIndex(raw, ",") > strings.LastIndex(raw, ".") {
+			raw = strings.ReplaceAll(raw, ".", "")
+		} else {
+			raw = strings.ReplaceAll(raw, ",", "")
+		}
+	}
+	raw = strings.Replace(raw, ",", ".", 1)
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// PledgeWish records the user's contribution toward a wish item, replacing
+// an earlier pledge. The first member to pledge toward an unreserved item
+// reserves it and becomes the organizer. Owners cannot pledge toward their
+// own wishes.
+func (s *Service) PledgeWish(ctx context.Context, itemID, userID int64, amount float64) (*models.WishItem, error) {
+	item, list, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if list.UserID == userID {
+		return nil, ErrWishForbidden
+	}
+	if item.Purchased {
+		return nil, ErrWishPurchased
+	}
+
+	if !item.Reserved {
+		if err := s.WishList.ReserveItem(ctx, itemID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.WishList.SetPledge(ctx, itemID, userID, amount); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("User %d pledged %.2f toward wish item %d", userID, amount, itemID)
+	return s.WishList.GetItemByID(ctx, itemID)
+}
+
+// UnpledgeWish withdraws the user's pledge. When the organizer withdraws and
+// nobody else pledged, the reservation is released as well; while others
+// still pledge, the organizer has to stay.
+func (s *Service) UnpledgeWish(ctx context.Context, itemID, userID int64) error {
+	item, _, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if item.Purchased {
+		return ErrWishPurchased
+	}
+
+	pledges, err := s.WishList.GetPledges(ctx, itemID)
+	if err != nil {
+		return err
+	}
+
+	pledged, others := false, false
+	for _, p := range pledges {
+		if p.UserID == userID {
+			pledged = true
+		} else {
+			others = true
+		}
+	}
+	if !pledged {
+		return ErrWishForbidden
+	}
+
+	isOrganizer := item.ReservedByID != nil && *item.ReservedByID == userID
+	if isOrganizer && others {
+		return ErrWishHasPledges
+	}
+
+	if err := s.WishList.DeletePledge(ctx, itemID, userID); err != nil {
+		return err
+	}
+	if isOrganizer {
+		if err := s.WishList.UnreserveItem(ctx, itemID); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Infof("User %d withdrew pledge for wish item %d", userID, itemID)
+	return nil
+}
+
+// LoadPledges fills item.Pledges.
+func (s *Service) LoadPledges(ctx context.Context, item *models.WishItem) error {
+	pledges, err := s.WishList.GetPledges(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+	item.Pledges = make([]models.WishPledge, 0, len(pledges))
+	for _, p := range pledges {
+		item.Pledges = append(item.Pledges, *p)
+	}
+	return nil
+}
+
+// MarkWishPurchased lets the organizer record that the gift was bought and
+// returns who owes them what. paid is what the organizer actually spent; if
+// nil, the sum of all pledges is assumed. When the gift was cheaper than
+// pledged, each share shrinks proportionally; anything above the pledges is
+// covered by the organizer.
+func (s *Service) MarkWishPurchased(ctx context.Context, itemID, userID int64, paid *float64) (*models.WishSettlement, error) {
+	item, _, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.ReservedByID == nil || *item.ReservedByID != userID {
+		return nil, ErrWishForbidden
+	}
+	if item.Purchased {
+		return nil, ErrWishPurchased
+	}
+
+	if err := s.LoadPledges(ctx, item); err != nil {
+		return nil, err
+	}
+	if err := s.WishList.MarkPurchased(ctx, itemID); err != nil {
+		return nil, err
+	}
+	item.Purchased = true
+
+	settlement := &models.WishSettlement{
+		Item:      item,
+		Organizer: item.ReservedBy,
+		Pledged:   item.Pledged,
+		Paid:      item.Pledged,
+		Shares:    []models.SettlementShare{},
+	}
+	if paid != nil {
+		settlement.Paid = *paid
+	}
+
+	ratio := 1.0
+	if settlement.Pledged > 0 && settlement.Paid < settlement.Pledged {
+		ratio = settlement.Paid / settlement.Pledged
+	}
+	for _, p := range item.Pledges {
+		if p.UserID == userID {
+			continue
+		}
+		settlement.Shares = append(settlement.Shares, models.SettlementShare{
+			User:   p.User,
+			Amount: math.Round(p.Amount*ratio*100) / 100,
+		})
+	}
+
+	s.logger.Infof("User %d marked wish item %d as purchased for %.2f", userID, itemID, settlement.Paid)
+	return settlement, nil
+}
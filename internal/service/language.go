@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+)
+
+// ErrUnsupportedLanguage is returned when a language has no catalog.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// Language returns the language to talk in to the Telegram user in the
+// chat: in group chats the one picked for the chat's family, then the one
+// the user picked, then the language of their Telegram app (appLanguage,
+// e.g. "ru-RU"). Lookup errors are logged and skipped; a reply in the wrong
+// language is better than none.
+func (s *Service) Language(ctx context.Context, chatID, telegramID int64, appLanguage string) string {
+	var userID int64
+	if telegramID != 0 {
+		user, err := s.Users.GetByTelegramID(ctx, telegramID)
+		if err != nil {
+			s.logger.Warnf("Failed to get user for language: %v", err)
+		}
+		if user != nil {
+			userID = user.ID
+		}
+	}
+
+	if lang := s.pickedLanguage(ctx, chatID, userID); lang != "" {
+		return lang
+	}
+	if lang := i18n.Match(appLanguage); lang != "" {
+		return lang
+	}
+	return i18n.Default
+}
+
+// ChatLanguage returns the language for messages the bot sends on its own
+// to a chat, e.g. reminders. userID is the user they are about, or 0.
+func (s *Service) ChatLanguage(ctx context.Context, chatID, userID int64) string {
+	if lang := s.pickedLanguage(ctx, chatID, userID); lang != "" {
+		return lang
+	}
+	return i18n.Default
+}
+
+// pickedLanguage returns the language chosen with /lang for the chat, or ""
+// if nobody chose one. Group chats (which have negative IDs) use their
+// family's choice, falling back to the user's.
+func (s *Service) pickedLanguage(ctx context.Context, chatID, userID int64) string {
+	if chatID < 0 {
+		family, err := s.Families.GetByChatID(ctx, chatID)
+		if err != nil {
+			s.logger.Warnf("Failed to get family for language: %v", err)
+		}
+		if family != nil {
+			lang, err := s.Families.GetLanguage(ctx, family.ID)
+			if err != nil {
+				s.logger.Warnf("Failed to get family language: %v", err)
+			}
+			if lang != "" {
+				return lang
+			}
+		}
+	}
+
+	if userID != 0 {
+		lang, err := s.Users.GetLanguage(ctx, userID)
+		if err != nil {
+			s.logger.Warnf("Failed to get user language: %v", err)
+		}
+		if lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// SetUserLanguage makes the bot talk to the user in lang, or in their
+// Telegram app's language again when lang is "".
+func (s *Service) SetUserLanguage(ctx context.Context, userID int64, lang string) error {
+	if lang != "" && i18n.Match(lang) != lang {
+		return ErrUnsupportedLanguage
+	}
+	if err := s.Users.SetLanguage(ctx, userID, lang); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d set language %q", userID, lang)
+	return nil
+}
+
+// SetFamilyLanguage makes the bot talk in lang in the family's group chats,
+// or in each user's own language again when lang is "". Only family admins
+// may change it.
+func (s *Service) SetFamilyLanguage(ctx context.Context, familyID, userID int64, lang string) error {
+	if lang != "" && i18n.Match(lang) != lang {
+		return ErrUnsupportedLanguage
+	}
+	if err := s.Authorize(ctx, familyID, userID); err != nil {
+		return err
+	}
+	if err := s.Families.SetLanguage(ctx, familyID, lang); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d set language %q for family %d", userID, lang, familyID)
+	return nil
+}
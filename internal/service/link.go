@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// ErrInvalidLinkCode is returned when a link code is unknown, expired or was
+// already used.
+var ErrInvalidLinkCode = errors.New("invalid or expired link code")
+
+const (
+	// linkCodeLength is the number of characters in a link code.
+	linkCodeLength = 8
+	// linkCodeTTL is how long a link code can be redeemed.
+	linkCodeTTL = 24 * time.Hour
+	// linkCodeAlphabet leaves out characters that are easily mixed up.
+	linkCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// CreateLinkCode creates a one-time code that attaches another chat to the
+// family. Only family admins may create codes.
+func (s *Service) CreateLinkCode(ctx context.Context, familyID, userID int64) (string, time.Time, error) {
+	if err := s.Authorize(ctx, familyID, userID); err != nil {
+		return "", time.Time{}, err
+	}
+
+	buf := make([]byte, linkCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate link code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = linkCodeAlphabet[int(b)%len(linkCodeAlphabet)]
+	}
+	code := string(buf)
+
+	expiresAt := time.Now().Add(linkCodeTTL)
+	if err := s.Families.CreateLinkCode(ctx, code, familyID, userID, expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+
+	s.logger.Infof("User %d created a link code for family %d", userID, familyID)
+	return code, expiresAt, nil
+}
+
+// JoinFamily redeems a link code in the chat: the chat becomes part of the
+// code's family and the user a member of it. A chat that had a family of its
+// own leaves it; when it was that family's only chat, the old family is
+// merged into the new one. Callers must make sure the user may move the
+// chat.
+func (s *Service) JoinFamily(ctx context.Context, code string, chatID, userID int64) (*models.Family, error) {
+	familyID, err := s.Families.UseLinkCode(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	if familyID == 0 {
+		return nil, ErrInvalidLinkCode
+	}
+
+	family, err := s.Families.GetByID(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if family == nil {
+		return nil, ErrInvalidLinkCode
+	}
+
+	if err := s.Families.MoveChat(ctx, chatID, familyID); err != nil {
+		return nil, err
+	}
+	if err := s.EnsureFamilyMember(ctx, familyID, userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("User %d linked chat %d to family %d", userID, chatID, familyID)
+	return family, nil
+}
+
+// SameFamily reports whether two chats share their data, i.e. are the same
+// chat or linked to the same family.
+func (s *Service) SameFamily(ctx context.Context, chatA, chatB int64) (bool, error) {
+	if chatA == chatB {
+		return true, nil
+	}
+
+	family, err := s.Families.GetByChatID(ctx, chatA)
+	if err != nil {
+		return false, fmt.Errorf("failed to lookup family (chat_id=%d): %w", chatA, err)
+	}
+	if family == nil {
+		return false, nil
+	}
+
+	chatIDs, err := s.Families.GetChats(ctx, family.ID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(chatIDs, chatB), nil
+}
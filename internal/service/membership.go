@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// LeaveSummary tells what happened to a member's things when they left the
+// family.
+type LeaveSummary struct {
+	// UnassignedTodos is how many of their pending todos became unassigned.
+	UnassignedTodos int64
+	// ReleasedWishes is how many wish reservations were handed to the next
+	// pledger or released.
+	ReleasedWishes int64
+	// StoppedReminders is how many of their reminders in the chat were
+	// deactivated.
+	StoppedReminders int
+	// NewAdmin is set when the leaver was the last admin and someone else
+	// was promoted.
+	NewAdmin *models.User
+}
+
+// MemberJoined adds the user to the family and reports whether they were
+// new. Telegram sends both a service message and a chat_member update for
+// the same join, handled concurrently, so callers use the result to greet
+// only once: the insert decides, and only one of them can add the user.
+func (s *Service) MemberJoined(ctx context.Context, familyID, userID int64) (bool, error) {
+	role, err := s.Families.AddMember(ctx, familyID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to add user %d to family %d: %w", userID, familyID, err)
+	}
+	if role == "" {
+		return false, nil
+	}
+
+	s.logger.Infof("Added user %d to family %d as %s", userID, familyID, role)
+	return true, nil
+}
+
+// MemberLeft removes the user from the family and cleans up after them:
+// their pending todos in the family chat become unassigned so others can
+// pick them up, their wish reservations and pledges are withdrawn (a group
+// gift goes to the next pledger), and their reminders in the family's chats
+// stop.
+// Their private chat with the bot is unlinked from the family, so it no
+// longer reaches the family's things, unless it is the family's home chat.
+// Their own wish list is kept in case they come back. If they were the last
+// admin, the longest-standing remaining member is promoted. It returns nil
+// when the user was not a member, so of duplicate or concurrent leaves only
+// one cleans up.
+//
+// Callers only call it once the user is in none of the family's group
+// chats: leaving one of several linked groups does not leave the family.
+func (s *Service) MemberLeft(ctx context.Context, family *models.Family, userID int64) (*LeaveSummary, error) {
+	role, err := s.Families.RemoveMember(ctx, family.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove user %d from family %d: %w", userID, family.ID, err)
+	}
+	if role == "" {
+		return nil, nil
+	}
+
+	summary := &LeaveSummary{}
+
+	if summary.UnassignedTodos, err = s.Todos.UnassignUser(ctx, family.ChatID, userID); err != nil {
+		return nil, err
+	}
+	if summary.ReleasedWishes, err = s.WishList.ReleaseUserReservations(ctx, family.ID, userID); err != nil {
+		return nil, err
+	}
+
+	chatIDs, err := s.Families.GetChats(ctx, family.ID)
+	if err != nil {
+		return nil, err
+	}
+	reminders, err := s.Reminders.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reminders {
+		if !r.Active || !slices.Contains(chatIDs, r.ChatID) {
+			continue
+		}
+		if err := s.Reminders.Deactivate(ctx, r.ID); err != nil {
+			return nil, err
+		}
+		summary.StoppedReminders++
+	}
+
+	user, err := s.Users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user %d: %w", userID, err)
+	}
+	if user != nil {
+		// Private chats have the ID of the user
+		if err := s.Families.UnlinkChat(ctx, family.ID, user.TelegramID); err != nil {
+			return nil, err
+		}
+	}
+
+	if role == models.FamilyRoleAdmin {
+		admins, err := s.Families.GetAdmins(ctx, family.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get admins of family %d: %w", family.ID, err)
+		}
+		if len(admins) == 0 {
+			members, err := s.Families.GetMembers(ctx, family.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get members for family %d: %w", family.ID, err)
+			}
+			if len(members) > 0 {
+				if err := s.Families.SetMemberRole(ctx, family.ID, members[0].ID, models.FamilyRoleAdmin); err != nil {
+					return nil, err
+				}
+				summary.NewAdmin = members[0]
+			}
+		}
+	}
+
+	s.logger.Infof("User %d left family %d: %d todos unassigned, %d reservations released, %d reminders stopped",
+		userID, family.ID, summary.UnassignedTodos, summary.ReleasedWishes, summary.StoppedReminders)
+	return summary, nil
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+var (
+	// ErrOccasionNotFound is returned when an occasion does not exist in the
+	// family.
+	ErrOccasionNotFound = errors.New("occasion not found")
+	// ErrOccasionForbidden is returned when the user may not change an
+	// occasion.
+	ErrOccasionForbidden = errors.New("not allowed to change this occasion")
+)
+
+// occasionReminderHour is the local hour from which occasion reminders are
+// sent, so nobody is woken up by them.
+const occasionReminderHour = 9
+
+// SaveBirthday stores the celebrant's birthday in the family, replacing the
+// one recorded before.
+func (s *Service) SaveBirthday(ctx context.Context, occasion *models.Occasion) (*models.Occasion, error) {
+	occasion.Kind = models.OccasionBirthday
+
+	existing, err := s.Occasions.GetByFamily(ctx, occasion.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range existing {
+		if o.Kind == models.OccasionBirthday && o.CelebrantID != nil && occasion.CelebrantID != nil &&
+			*o.CelebrantID == *occasion.CelebrantID {
+			if err := s.Occasions.Delete(ctx, o.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.Occasions.Create(ctx, occasion)
+}
+
+// familyOccasion loads an occasion and makes sure it belongs to the family.
+func (s *Service) familyOccasion(ctx context.Context, occasionID, familyID int64) (*models.Occasion, error) {
+	occasion, err := s.Occasions.GetByID(ctx, occasionID)
+	if err != nil {
+		return nil, err
+	}
+	if occasion == nil || occasion.FamilyID != familyID {
+		return nil, ErrOccasionNotFound
+	}
+	return occasion, nil
+}
+
+// DeleteOccasion removes an occasion of the family. Birthdays can be removed
+// by the celebrant and whoever added them, holidays by their creator, and
+// anything by family admins.
+func (s *Service) DeleteOccasion(ctx context.Context, occasionID, familyID, userID int64) error {
+	occasion, err := s.familyOccasion(ctx, occasionID, familyID)
+	if err != nil {
+		return err
+	}
+	owners := []int64{occasion.CreatedByID}
+	if occasion.CelebrantID != nil {
+		owners = append(owners, *occasion.CelebrantID)
+	}
+	if err := s.Authorize(ctx, familyID, userID, owners...); err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return ErrOccasionForbidden
+		}
+		return err
+	}
+
+	if err := s.Occasions.Delete(ctx, occasionID); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d deleted occasion %d", userID, occasionID)
+	return nil
+}
+
+// LinkWishList links the user's wish list in the family to an occasion of
+// the same family, or unlinks it when occasionID is nil.
+func (s *Service) LinkWishList(ctx context.Context, familyID, userID int64, occasionID *int64) (*models.Occasion, error) {
+	var occasion *models.Occasion
+	if occasionID != nil {
+		var err error
+		if occasion, err = s.familyOccasion(ctx, *occasionID, familyID); err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := s.WishList.GetListByUser(ctx, userID, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list, err = s.WishList.CreateList(ctx, &models.WishList{
+			FamilyID: familyID,
+			UserID:   userID,
+			Name:     "My Wish List",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.WishList.SetOccasion(ctx, list.ID, occasionID); err != nil {
+		return nil, err
+	}
+	return occasion, nil
+}
+
+// OccasionEvents returns the family's occasions falling between from and to
+// as all-day calendar events titled in lang. They are generated on the fly
+// and carry the occasion ID instead of an event ID.
+func (s *Service) OccasionEvents(ctx context.Context, lang string, familyID int64, from, to time.Time) ([]*models.CalendarEvent, error) {
+	occasions, err := s.Occasions.GetByFamily(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*models.CalendarEvent
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for _, o := range occasions {
+		for year := start.Year(); year <= to.Year(); year++ {
+			date := o.DateIn(year, from.Location())
+			if date.Before(start) || date.After(to) {
+				continue
+			}
+			id := o.ID
+			events = append(events, &models.CalendarEvent{
+				FamilyID:    o.FamilyID,
+				ChatID:      o.ChatID,
+				Title:       OccasionTitle(lang, o, date),
+				StartTime:   date,
+				AllDay:      true,
+				Recurring:   "yearly",
+				CreatedByID: o.CreatedByID,
+				OccasionID:  &id,
+			})
+		}
+	}
+
+	slices.SortFunc(events, func(a, b *models.CalendarEvent) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+	return events, nil
+}
+
+// OccasionTitle describes an occasion as it falls on the given date in
+// lang, e.g. "🎂 Anna's birthday (30)".
+func OccasionTitle(lang string, o *models.Occasion, date time.Time) string {
+	if o.Kind != models.OccasionBirthday {
+		return "🎉 " + o.Title
+	}
+	name := o.Title
+	if o.Celebrant != nil {
+		name = i18n.T(lang, "occasion.birthday_of", o.Celebrant.FirstName)
+	}
+	if age := o.AgeOn(date); age > 0 {
+		return fmt.Sprintf("🎂 %s (%d)", name, age)
+	}
+	return "🎂 " + name
+}
+
+// processOccasions sends the reminders for occasions that are coming up
+// within their reminder window and have not been announced yet.
+func (s *Service) processOccasions(ctx context.Context, callback ReminderCallback) {
+	now := time.Now()
+	if now.Hour() < occasionReminderHour {
+		return
+	}
+
+	occasions, err := s.Occasions.GetAll(ctx)
+	if err != nil {
+		s.logger.Errorf("Failed to get occasions: %v", err)
+		return
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, o := range occasions {
+		next := o.NextDate(now)
+		if today.Before(next.AddDate(0, 0, -o.RemindDaysBefore)) {
+			continue
+		}
+		if o.LastRemindedFor != nil && o.LastRemindedFor.Format("2006-01-02") == next.Format("2006-01-02") {
+			continue
+		}
+
+		if err := s.remindOccasion(ctx, o, next, today, callback); err != nil {
+			s.logger.Errorf("Failed to send reminder for occasion %d: %v", o.ID, err)
+			continue
+		}
+		if err := s.Occasions.MarkReminded(ctx, o.ID, next); err != nil {
+			s.logger.Errorf("Failed to update occasion %d: %v", o.ID, err)
+		}
+	}
+}
+
+// remindOccasion tells every family member except the celebrants about the
+// upcoming occasion, listing what is still free to gift from each
+// celebrant's wish list. Occasions nobody is celebrated on go to the family
+// chat instead.
+func (s *Service) remindOccasion(ctx context.Context, o *models.Occasion, date, today time.Time, callback ReminderCallback) error {
+	lists, err := s.WishList.GetListsByFamily(ctx, o.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	// Birthdays celebrate one member; other occasions everyone who linked
+	// their wish list to them.
+	celebrants := make(map[int64]*models.WishList)
+	if o.CelebrantID != nil {
+		celebrants[*o.CelebrantID] = nil
+	}
+	for _, l := range lists {
+		_, isCelebrant := celebrants[l.UserID]
+		if isCelebrant || (l.OccasionID != nil && *l.OccasionID == o.ID) {
+			celebrants[l.UserID] = l
+		}
+	}
+
+	heading := func(lang string) string {
+		return fmt.Sprintf("%s *%s*\n📆 %s", markup.Escape(OccasionTitle(lang, o, date)),
+			occasionWhen(lang, date, today), i18n.Date(lang, date, "Mon, 02 Jan"))
+	}
+
+	if len(celebrants) == 0 {
+		callback(o.ChatID, heading(s.ChatLanguage(ctx, o.ChatID, 0)), nil)
+		return nil
+	}
+
+	members, err := s.Families.GetMembers(ctx, o.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	// What is still free on each celebrant's wish list; the messages are put
+	// together per member since members may use different languages.
+	type wishes struct {
+		name string
+		free []string
+	}
+	var free []wishes
+	for userID, list := range celebrants {
+		if list == nil {
+			continue
+		}
+		items, err := s.WishList.GetItems(ctx, list.ID)
+		if err != nil {
+			return err
+		}
+
+		w := wishes{}
+		for _, m := range members {
+			if m.ID == userID {
+				w.name = markup.Escape(m.FirstName)
+			}
+		}
+		for _, item := range items {
+			if item.Reserved || item.Purchased {
+				continue
+			}
+			line := fmt.Sprintf("• *#%d* %s", item.ID, markup.Escape(item.Name))
+			if item.Price != "" {
+				line += " — " + markup.Escape(item.Price)
+			}
+			w.free = append(w.free, line)
+		}
+		free = append(free, w)
+	}
+
+	texts := make(map[string]string)
+	for _, m := range members {
+		if _, isCelebrant := celebrants[m.ID]; isCelebrant || m.TelegramID == 0 {
+			continue
+		}
+		lang := s.ChatLanguage(ctx, m.TelegramID, m.ID)
+		text, ok := texts[lang]
+		if !ok {
+			var sb strings.Builder
+			sb.WriteString(heading(lang))
+			for _, w := range free {
+				name := i18n.T(lang, "occasion.their")
+				if w.name != "" {
+					name = i18n.T(lang, "occasion.whose", w.name)
+				}
+				if len(w.free) == 0 {
+					sb.WriteString("\n\n" + i18n.T(lang, "occasion.all_taken", name))
+					continue
+				}
+				sb.WriteString("\n\n" + i18n.T(lang, "occasion.still_free", name, strings.Join(w.free, "\n")))
+			}
+			sb.WriteString("\n\n" + i18n.T(lang, "occasion.reserve_hint"))
+			text = sb.String()
+			texts[lang] = text
+		}
+		callback(m.TelegramID, text, nil)
+	}
+	return nil
+}
+
+// occasionWhen describes how far away the date is.
+func occasionWhen(lang string, date, today time.Time) string {
+	days := int(date.Sub(today).Hours()/24 + 0.5)
+	switch days {
+	case 0:
+		return i18n.T(lang, "occasion.today")
+	case 1:
+		return i18n.T(lang, "occasion.tomorrow")
+	default:
+		return i18n.N(lang, "occasion.in_days", days)
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+var (
+	// ErrForbidden is returned when the user may not change something in
+	// the family.
+	ErrForbidden = errors.New("permission denied")
+	// ErrNotFamilyMember is returned when a role is changed for someone
+	// outside the family.
+	ErrNotFamilyMember = errors.New("user is not a member of this family")
+	// ErrLastAdmin is returned when the only admin of a family would be
+	// demoted.
+	ErrLastAdmin = errors.New("a family needs at least one admin")
+)
+
+// FamilyRole returns the user's role in the family, or "" if they are not a
+// member.
+func (s *Service) FamilyRole(ctx context.Context, familyID, userID int64) (string, error) {
+	role, err := s.Families.GetMemberRole(ctx, familyID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get role of user %d in family %d: %w", userID, familyID, err)
+	}
+	return role, nil
+}
+
+// IsFamilyAdmin reports whether the user is an admin of the family.
+func (s *Service) IsFamilyAdmin(ctx context.Context, familyID, userID int64) (bool, error) {
+	role, err := s.FamilyRole(ctx, familyID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role == models.FamilyRoleAdmin, nil
+}
+
+// Authorize checks that the user may change or delete something in the
+// family. Owners (creators, assignees, ...) passed as ownerIDs may always
+// act on their own things; anyone else needs to be a family admin. It
+// returns ErrForbidden when the user is not allowed.
+func (s *Service) Authorize(ctx context.Context, familyID, userID int64, ownerIDs ...int64) error {
+	if slices.Contains(ownerIDs, userID) {
+		return nil
+	}
+
+	admin, err := s.IsFamilyAdmin(ctx, familyID, userID)
+	if err != nil {
+		return err
+	}
+	if !admin {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// AuthorizeChat is Authorize for data that is keyed by chat rather than by
+// family.
+func (s *Service) AuthorizeChat(ctx context.Context, chatID, userID int64, ownerIDs ...int64) error {
+	if slices.Contains(ownerIDs, userID) {
+		return nil
+	}
+
+	family, err := s.Families.GetByChatID(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("failed to lookup family (chat_id=%d): %w", chatID, err)
+	}
+	if family == nil {
+		return ErrForbidden
+	}
+	return s.Authorize(ctx, family.ID, userID)
+}
+
+// SetFamilyRole changes the role of a family member. Only admins may change
+// roles, and the last admin cannot be demoted. Demoted members are not made
+// admins again for administering the family's Telegram group; only being
+// promoted lifts that.
+func (s *Service) SetFamilyRole(ctx context.Context, familyID, actorID, targetID int64, role string) error {
+	if role != models.FamilyRoleAdmin && role != models.FamilyRoleMember {
+		return fmt.Errorf("unknown family role %q", role)
+	}
+	if err := s.Authorize(ctx, familyID, actorID); err != nil {
+		return err
+	}
+
+	current, err := s.FamilyRole(ctx, familyID, targetID)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return ErrNotFamilyMember
+	}
+	if current == role {
+		return nil
+	}
+
+	if role == models.FamilyRoleMember {
+		demoted, err := s.Families.DemoteMember(ctx, familyID, targetID)
+		if err != nil {
+			return err
+		}
+		if !demoted {
+			return ErrLastAdmin
+		}
+	} else if err := s.Families.SetMemberRole(ctx, familyID, targetID, role); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d made user %d %s of family %d", actorID, targetID, role, familyID)
+	return nil
+}
+
+// GrantChatAdmin makes the user a family admin because they administer the
+// family's Telegram group, unless they were demoted with /demote. It reports
+// whether they are an admin now. Callers are responsible for having verified
+// that with Telegram.
+func (s *Service) GrantChatAdmin(ctx context.Context, familyID, userID int64) (bool, error) {
+	if err := s.EnsureFamilyMember(ctx, familyID, userID); err != nil {
+		return false, err
+	}
+	granted, err := s.Families.PromoteChatAdmin(ctx, familyID, userID)
+	if err != nil || !granted {
+		return false, err
+	}
+
+	s.logger.Infof("Granted admin in family %d to chat administrator %d", familyID, userID)
+	return true, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// ErrReminderNotFound is returned when a reminder does not exist.
+var ErrReminderNotFound = errors.New("reminder not found")
+
+// SnoozeReminder makes a reminder that went off fire again at the given
+// time. A one-time reminder is simply rescheduled; a repeating one keeps
+// its schedule and gets a one-time copy instead, which is returned. The
+// owner and family admins may snooze a reminder; others get ErrForbidden.
+func (s *Service) SnoozeReminder(ctx context.Context, reminderID, userID int64, at time.Time) (*models.Reminder, error) {
+	reminder, err := s.Reminders.GetByID(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder == nil {
+		return nil, ErrReminderNotFound
+	}
+	if err := s.Authorize(ctx, reminder.FamilyID, userID, reminder.UserID); err != nil {
+		return nil, err
+	}
+
+	if reminder.Repeat == models.ReminderRepeatNone {
+		reminder.RemindAt = at
+		reminder.Active = true
+		reminder, err = s.Reminders.Update(ctx, reminder)
+	} else {
+		reminder, err = s.Reminders.Create(ctx, &models.Reminder{
+			FamilyID: reminder.FamilyID,
+			ChatID:   reminder.ChatID,
+			UserID:   reminder.UserID,
+			Text:     reminder.Text,
+			RemindAt: at,
+			Repeat:   models.ReminderRepeatNone,
+			Active:   true,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("User %d snoozed reminder %d until %s", userID, reminderID, at.Format(time.RFC3339))
+	return reminder, nil
+}
@@ -5,20 +5,27 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+	"github.com/Kerhoff/TodoboT/internal/markup"
 	"github.com/Kerhoff/TodoboT/internal/models"
 )
 
 // ReminderCallback is a function that sends a reminder message to a chat.
-type ReminderCallback func(chatID int64, text string)
+// reminder is the reminder that went off, or nil for occasion reminders.
+type ReminderCallback func(chatID int64, text string, reminder *models.Reminder)
 
 // StartReminderScheduler runs a background loop that checks for due reminders
-// every 30 seconds and invokes the callback for each one. It blocks until the
-// context is cancelled, so it should be launched in a separate goroutine.
+// every 30 seconds and invokes the callback for each one. Upcoming occasions
+// are checked hourly. It blocks until the context is cancelled, so it should
+// be launched in a separate goroutine.
 func (s *Service) StartReminderScheduler(ctx context.Context, callback ReminderCallback) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
+	occasionTicker := time.NewTicker(time.Hour)
+	defer occasionTicker.Stop()
 
 	s.logger.Info("Reminder scheduler started")
+	s.processOccasions(ctx, callback)
 
 	for {
 		select {
@@ -27,6 +34,8 @@ func (s *Service) StartReminderScheduler(ctx context.Context, callback ReminderC
 			return
 		case <-ticker.C:
 			s.processReminders(ctx, callback)
+		case <-occasionTicker.C:
+			s.processOccasions(ctx, callback)
 		}
 	}
 }
@@ -42,7 +51,8 @@ func (s *Service) processReminders(ctx context.Context, callback ReminderCallbac
 	}
 
 	for _, r := range reminders {
-		callback(r.ChatID, fmt.Sprintf("\u23f0 *Reminder*\n%s", r.Text))
+		lang := s.ChatLanguage(ctx, r.ChatID, r.UserID)
+		callback(r.ChatID, fmt.Sprintf("\u23f0 *%s*\n%s", i18n.T(lang, "reminder.heading"), markup.Escape(r.Text)), r)
 
 		now := time.Now()
 		r.LastSentAt = &now
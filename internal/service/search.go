@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// ErrEmptySearch is returned when a search query has no words to look for.
+var ErrEmptySearch = errors.New("search query has no words")
+
+// maxSearchWords caps how many words of a query are searched for.
+const maxSearchWords = 10
+
+// SearchPage is one page of search results.
+type SearchPage struct {
+	Results []*models.SearchResult `json:"results"`
+	Total   int                    `json:"total"`
+	Offset  int                    `json:"offset"`
+	Limit   int                    `json:"limit"`
+}
+
+// Search looks for text in the data of the chat's family. Every word has to
+// match, and words match by prefix, so "passport" also finds "Passports".
+// Given types, only results of those types are returned.
+func (s *Service) Search(ctx context.Context, chatID int64, text string, limit, offset int, types ...models.SearchResultType) (*SearchPage, error) {
+	query := searchQuery(text)
+	if query == "" {
+		return nil, ErrEmptySearch
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	results, total, err := s.SearchIndex.Search(ctx, chatID, query, types, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []*models.SearchResult{}
+	}
+	return &SearchPage{Results: results, Total: total, Offset: offset, Limit: limit}, nil
+}
+
+// FamilySearchResult is a search result found in one of the user's
+// families.
+type FamilySearchResult struct {
+	*models.SearchResult
+	Family *models.Family
+}
+
+// SearchUserFamilies looks for text in every family the user belongs to,
+// wherever they ask from. Only results of the given types are returned, at
+// most limit of them, family by family.
+func (s *Service) SearchUserFamilies(ctx context.Context, userID int64, text string, limit int, types ...models.SearchResultType) ([]FamilySearchResult, error) {
+	families, err := s.Families.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []FamilySearchResult
+	for _, family := range families {
+		page, err := s.Search(ctx, family.ChatID, text, limit-len(found), 0, types...)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range page.Results {
+			found = append(found, FamilySearchResult{SearchResult: result, Family: family})
+		}
+		if len(found) >= limit {
+			break
+		}
+	}
+	return found, nil
+}
+
+// searchQuery turns free text into a prefix tsquery such as
+// "dentist:* & anna:*". Only letters and digits are kept, so the result is
+// always a valid query.
+func searchQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchWords {
+		words = words[:maxSearchWords]
+	}
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
@@ -7,24 +7,39 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Kerhoff/TodoboT/internal/dialog"
 	"github.com/Kerhoff/TodoboT/internal/models"
 	"github.com/Kerhoff/TodoboT/internal/repository"
+	"github.com/Kerhoff/TodoboT/internal/storage"
+	"github.com/Kerhoff/TodoboT/internal/urlmeta"
 	"github.com/sirupsen/logrus"
 )
 
 // Service is the central business logic layer that holds all repositories
 // and provides high-level methods for the application.
 type Service struct {
-	db        *sql.DB
-	logger    *logrus.Logger
-	Users     repository.UserRepository
-	Todos     repository.TodoRepository
-	Comments  repository.CommentRepository
-	Families  repository.FamilyRepository
-	Calendar  repository.CalendarRepository
-	Buying    repository.BuyingListRepository
-	WishList  repository.WishListRepository
-	Reminders repository.ReminderRepository
+	db          *sql.DB
+	logger      *logrus.Logger
+	Users       repository.UserRepository
+	Todos       repository.TodoRepository
+	Comments    repository.CommentRepository
+	Families    repository.FamilyRepository
+	Calendar    repository.CalendarRepository
+	Buying      repository.BuyingListRepository
+	WishList    repository.WishListRepository
+	Reminders   repository.ReminderRepository
+	Occasions   repository.OccasionRepository
+	Attachments repository.AttachmentRepository
+	SearchIndex repository.SearchRepository
+	Blobs       storage.BlobStore
+	Links       urlmeta.Fetcher
+	// TelegramFiles opens files that were sent to the bot by their file_id.
+	TelegramFiles storage.FileSource
+	// DownloadMedia keeps copies of media attached in chats in Blobs rather
+	// than only their Telegram file_id.
+	DownloadMedia bool
+	// Dialogs tracks the multi-step conversations users are in.
+	Dialogs *dialog.Store
 }
 
 // New creates a new Service with all required dependencies.
@@ -37,12 +52,22 @@ func New(db *sql.DB, logger *logrus.Logger,
 	buying repository.BuyingListRepository,
 	wishList repository.WishListRepository,
 	reminders repository.ReminderRepository,
+	occasions repository.OccasionRepository,
+	attachments repository.AttachmentRepository,
+	searchIndex repository.SearchRepository,
+	dialogs repository.DialogRepository,
+	blobs storage.BlobStore,
+	telegramFiles storage.FileSource,
+	links urlmeta.Fetcher,
 ) *Service {
 	return &Service{
 		db: db, logger: logger,
 		Users: users, Todos: todos, Comments: comments,
 		Families: families, Calendar: calendar, Buying: buying,
-		WishList: wishList, Reminders: reminders,
+		WishList: wishList, Reminders: reminders, Occasions: occasions,
+		Attachments: attachments, SearchIndex: searchIndex,
+		Blobs: blobs, TelegramFiles: telegramFiles, Links: links,
+		Dialogs: dialog.NewStore(dialogs, dialog.DefaultTimeout),
 	}
 }
 
@@ -133,8 +158,9 @@ func (s *Service) EnsureFamily(ctx context.Context, chatID int64, chatTitle stri
 		return family, nil
 	}
 
-	// Family exists — update name if the chat title has changed.
-	if chatTitle != "" && family.Name != chatTitle {
+	// Family exists — update name if the title of its home chat has changed.
+	// Linked chats keep their own titles.
+	if chatTitle != "" && family.Name != chatTitle && family.ChatID == chatID {
 		family.Name = chatTitle
 		family.UpdatedAt = time.Now()
 		family, err = s.Families.Update(ctx, family)
@@ -149,23 +175,31 @@ func (s *Service) EnsureFamily(ctx context.Context, chatID int64, chatTitle stri
 
 // EnsureFamilyMember makes sure the given user is a member of the family
 // associated with the specified chat. If the user is already a member, this
-// is a no-op. New members are added with the "member" role.
+// is a no-op and their role stays. The first member becomes the family
+// admin; everyone after is added with the "member" role.
 func (s *Service) EnsureFamilyMember(ctx context.Context, familyID int64, userID int64) error {
+	role, err := s.Families.AddMember(ctx, familyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to add user %d to family %d: %w", userID, familyID, err)
+	}
+	if role != "" {
+		s.logger.Infof("Added user %d to family %d as %s", userID, familyID, role)
+	}
+	return nil
+}
+
+// IsFamilyMember reports whether the given user belongs to the family.
+func (s *Service) IsFamilyMember(ctx context.Context, familyID, userID int64) (bool, error) {
 	members, err := s.Families.GetMembers(ctx, familyID)
 	if err != nil {
-		return fmt.Errorf("failed to get members for family %d: %w", familyID, err)
+		return false, fmt.Errorf("failed to get members for family %d: %w", familyID, err)
 	}
 
 	for _, m := range members {
 		if m.ID == userID {
-			return nil // already a member
+			return true, nil
 		}
 	}
 
-	if err := s.Families.AddMember(ctx, familyID, userID, "member"); err != nil {
-		return fmt.Errorf("failed to add user %d to family %d: %w", userID, familyID, err)
-	}
-
-	s.logger.Infof("Added user %d to family %d", userID, familyID)
-	return nil
+	return false, nil
 }
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// ErrTodoTransition is returned when a todo cannot move to the requested
+// status from the one it is in.
+var ErrTodoTransition = errors.New("todo cannot change to that status")
+
+// todoTransitions lists the statuses each todo status may move to. Pending
+// todos are completed or cancelled; both can be reopened.
+var todoTransitions = map[models.TodoStatus][]models.TodoStatus{
+	models.TodoStatusPending:   {models.TodoStatusCompleted, models.TodoStatusCancelled},
+	models.TodoStatusCompleted: {models.TodoStatusPending},
+	models.TodoStatusCancelled: {models.TodoStatusPending},
+}
+
+// canTransitionTodo reports whether a todo may move between the statuses.
+func canTransitionTodo(from, to models.TodoStatus) bool {
+	return slices.Contains(todoTransitions[from], to)
+}
+
+// TransitionTodo moves the todo to a new status on behalf of the user and
+// records who did it. The creator, the assignee and family admins may change
+// a todo's status; others get ErrForbidden. Moves the state machine does not
+// allow, including to the status the todo is already in, return
+// ErrTodoTransition. The todo is updated in place.
+//
+// Completing a recurring todo creates its next occurrence, which is
+// returned; cancelling one ends the series.
+func (s *Service) TransitionTodo(ctx context.Context, todo *models.Todo, userID int64, to models.TodoStatus) (*models.Todo, error) {
+	owners := []int64{todo.CreatedByID}
+	if todo.AssignedToID != nil {
+		owners = append(owners, *todo.AssignedToID)
+	}
+	if err := s.AuthorizeChat(ctx, todo.ChatID, userID, owners...); err != nil {
+		return nil, err
+	}
+
+	if !canTransitionTodo(todo.Status, to) {
+		return nil, ErrTodoTransition
+	}
+
+	event := &models.TodoEvent{
+		TodoID:     todo.ID,
+		UserID:     &userID,
+		FromStatus: todo.Status,
+		ToStatus:   to,
+	}
+	changed, err := s.Todos.ChangeStatus(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		// Someone else changed the status in the meantime
+		return nil, ErrTodoTransition
+	}
+
+	todo.Status = to
+	todo.UpdatedAt = event.CreatedAt
+
+	s.logger.Infof("User %d moved todo %d from %s to %s", userID, todo.ID, event.FromStatus, to)
+
+	if to != models.TodoStatusCompleted || !todo.IsRecurring() {
+		return nil, nil
+	}
+	return s.nextOccurrence(ctx, todo)
+}
+
+// nextOccurrence creates the occurrence of a recurring todo that follows the
+// completed one, due one interval later and assigned to the next member of
+// the rotation, with a fresh copy of its checklist. A todo that was reopened
+// and completed again keeps the occurrence created the first time.
+func (s *Service) nextOccurrence(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
+	existing, err := s.Todos.GetNextOccurrence(ctx, todo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	deadline := todo.NextDeadline(time.Now())
+	previousID := todo.ID
+	next, err := s.Todos.Create(ctx, &models.Todo{
+		Title:        todo.Title,
+		Description:  todo.Description,
+		Status:       models.TodoStatusPending,
+		Priority:     todo.Priority,
+		Deadline:     &deadline,
+		CreatedByID:  todo.CreatedByID,
+		AssignedToID: todo.NextAssignee(),
+		ChatID:       todo.ChatID,
+		Recurrence:   todo.Recurrence,
+		Rotation:     todo.Rotation,
+		PreviousID:   &previousID,
+		AutoComplete: todo.AutoComplete,
+		Tags:         todo.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence of todo %d: %w", todo.ID, err)
+	}
+
+	items, err := s.Todos.GetChecklist(ctx, todo.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if _, err := s.AddChecklistItem(ctx, next, item.Text); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Infof("Created todo %d as next occurrence of %d", next.ID, todo.ID)
+	return next, nil
+}
+
+// CreateChore adds a recurring todo. It is assigned to the first member of
+// the rotation, who must all belong to the family of the todo's chat, and is
+// due one interval from now unless a deadline is set.
+func (s *Service) CreateChore(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
+	if !todo.IsRecurring() {
+		return nil, fmt.Errorf("chore %q has no recurrence", todo.Title)
+	}
+	if err := s.checkRotation(ctx, todo.ChatID, todo.Rotation); err != nil {
+		return nil, err
+	}
+
+	if todo.AssignedToID == nil && len(todo.Rotation) > 0 {
+		first := todo.Rotation[0]
+		todo.AssignedToID = &first
+	}
+	if todo.Deadline == nil {
+		deadline := todo.NextDeadline(time.Now())
+		todo.Deadline = &deadline
+	}
+	todo.Status = models.TodoStatusPending
+
+	return s.Todos.Create(ctx, todo)
+}
+
+// SetRotation changes the order in which a recurring todo passes between
+// members. It applies from the next occurrence on; the creator and family
+// admins may change it.
+func (s *Service) SetRotation(ctx context.Context, todo *models.Todo, userID int64, rotation []int64) error {
+	if err := s.AuthorizeChat(ctx, todo.ChatID, userID, todo.CreatedByID); err != nil {
+		return err
+	}
+	if err := s.checkRotation(ctx, todo.ChatID, rotation); err != nil {
+		return err
+	}
+
+	todo.Rotation = rotation
+	if _, err := s.Todos.Update(ctx, todo); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d set rotation of todo %d to %v", userID, todo.ID, rotation)
+	return nil
+}
+
+// checkRotation makes sure everyone in the rotation is a member of the
+// chat's family.
+func (s *Service) checkRotation(ctx context.Context, chatID int64, rotation []int64) error {
+	if len(rotation) == 0 {
+		return nil
+	}
+
+	family, err := s.Families.GetByChatID(ctx, chatID)
+	if err != nil {
+		return fmt.Errorf("failed to lookup family (chat_id=%d): %w", chatID, err)
+	}
+	if family == nil {
+		return ErrNotFamilyMember
+	}
+	for _, userID := range rotation {
+		role, err := s.FamilyRole(ctx, family.ID, userID)
+		if err != nil {
+			return err
+		}
+		if role == "" {
+			return ErrNotFamilyMember
+		}
+	}
+	return nil
+}
+
+// TodoHistory returns the status transitions of a todo, oldest first.
+func (s *Service) TodoHistory(ctx context.Context, todoID int64) ([]*models.TodoEvent, error) {
+	events, err := s.Todos.GetEvents(ctx, todoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of todo %d: %w", todoID, err)
+	}
+	return events, nil
+}
+
+// SetTodoDeadline changes when the todo is due, or removes its deadline when
+// deadline is nil. The creator, the assignee and family admins may change it;
+// others get ErrForbidden. The todo is updated in place.
+func (s *Service) SetTodoDeadline(ctx context.Context, todo *models.Todo, userID int64, deadline *time.Time) error {
+	owners := []int64{todo.CreatedByID}
+	if todo.AssignedToID != nil {
+		owners = append(owners, *todo.AssignedToID)
+	}
+	if err := s.AuthorizeChat(ctx, todo.ChatID, userID, owners...); err != nil {
+		return err
+	}
+
+	todo.Deadline = deadline
+	if _, err := s.Todos.Update(ctx, todo); err != nil {
+		return err
+	}
+
+	if deadline == nil {
+		s.logger.Infof("User %d removed the deadline of todo %d", userID, todo.ID)
+	} else {
+		s.logger.Infof("User %d set the deadline of todo %d to %s", userID, todo.ID, deadline.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// HideReservations strips reservation details from the items of a list the
+// viewer owns, so the owner cannot see what is being bought for them. Other
+// viewers keep the full reservation state. When the owner has opted in to
+// the reserved hint, list.HasReserved tells them whether anything was
+// reserved without saying what.
+func HideReservations(list *models.WishList, items []*models.WishItem, viewerID int64) {
+	if list.UserID != viewerID {
+		return
+	}
+
+	anyReserved := false
+	for _, item := range items {
+		if item.Reserved {
+			anyReserved = true
+		}
+		item.Reserved = false
+		item.ReservedByID = nil
+		item.ReservedBy = nil
+		item.Purchased = false
+		item.PurchasedAt = nil
+		item.Pledged = 0
+		item.Pledges = nil
+	}
+
+	if list.ReservedHint {
+		list.HasReserved = &anyReserved
+	}
+}
+
+var (
+	// ErrWishNotFound is returned when a wish item does not exist.
+	ErrWishNotFound = errors.New("wish item not found")
+	// ErrWishForbidden is returned when the user may not change a wish item.
+	ErrWishForbidden = errors.New("not allowed to change this wish item")
+)
+
+// WishUpdate holds the fields of a wish item to change. Nil fields are left
+// untouched.
+type WishUpdate struct {
+	Name     *string
+	URL      *string
+	Price    *string
+	Notes    *string
+	Priority *int
+}
+
+// wishWithList loads an item together with the list it is on.
+func (s *Service) wishWithList(ctx context.Context, itemID int64) (*models.WishItem, *models.WishList, error) {
+	item, err := s.WishList.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if item == nil {
+		return nil, nil, ErrWishNotFound
+	}
+
+	list, err := s.WishList.GetListByID(ctx, item.WishListID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if list == nil {
+		return nil, nil, ErrWishNotFound
+	}
+
+	return item, list, nil
+}
+
+// authorizeWish checks that the user may change a wish on the list: its
+// owner or a family admin.
+func (s *Service) authorizeWish(ctx context.Context, list *models.WishList, userID int64) error {
+	err := s.Authorize(ctx, list.FamilyID, userID, list.UserID)
+	if errors.Is(err, ErrForbidden) {
+		return ErrWishForbidden
+	}
+	return err
+}
+
+// UpdateWish applies the update to a wish item. Only the list owner and
+// family admins may edit wishes. The item returned to the owner has its
+// reservation details hidden, as in the lists.
+func (s *Service) UpdateWish(ctx context.Context, itemID, userID int64, upd WishUpdate) (*models.WishItem, error) {
+	item, list, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeWish(ctx, list, userID); err != nil {
+		return nil, err
+	}
+
+	if upd.Name != nil {
+		item.Name = *upd.Name
+	}
+	if upd.URL != nil {
+		item.URL = *upd.URL
+	}
+	if upd.Price != nil {
+		item.Price = *upd.Price
+	}
+	if upd.Notes != nil {
+		item.Notes = *upd.Notes
+	}
+	if upd.Priority != nil {
+		item.Priority = *upd.Priority
+	}
+
+	if err := s.WishList.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("User %d updated wish item %d", userID, itemID)
+	HideReservations(list, []*models.WishItem{item}, userID)
+	return item, nil
+}
+
+// DeleteWish removes a wish item. Only the list owner and family admins may
+// delete it.
+func (s *Service) DeleteWish(ctx context.Context, itemID, userID int64) error {
+	_, list, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeWish(ctx, list, userID); err != nil {
+		return err
+	}
+
+	if err := s.WishList.DeleteItem(ctx, itemID); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d deleted wish item %d", userID, itemID)
+	return nil
+}
+
+// ReserveWish reserves a wish item for the user. Owners cannot reserve their
+// own wishes.
+func (s *Service) ReserveWish(ctx context.Context, itemID, userID int64) error {
+	_, list, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if list.UserID == userID {
+		return ErrWishForbidden
+	}
+
+	return s.WishList.ReserveItem(ctx, itemID, userID)
+}
+
+// UnreserveWish releases a reservation. Only the user who reserved the item
+// may release it; the owner is not told about reservations at all. A group
+// gift organizer can only step back while nobody else has pledged.
+func (s *Service) UnreserveWish(ctx context.Context, itemID, userID int64) error {
+	item, _, err := s.wishWithList(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if !item.Reserved || item.ReservedByID == nil || *item.ReservedByID != userID {
+		return ErrWishForbidden
+	}
+	if item.Purchased {
+		return ErrWishPurchased
+	}
+	if item.Pledged > 0 {
+		pledges, err := s.WishList.GetPledges(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		for _, p := range pledges {
+			if p.UserID != userID {
+				return ErrWishHasPledges
+			}
+		}
+		if err := s.WishList.DeletePledge(ctx, itemID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.WishList.UnreserveItem(ctx, itemID); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d released reservation of wish item %d", userID, itemID)
+	return nil
+}
+
+// wishEnrichTimeout bounds how long adding a wish waits for the shop page.
+const wishEnrichTimeout = 5 * time.Second
+
+// EnrichWish fills in missing details of a wish item from the page at its
+// URL: the name when none was given, the price and the product image.
+// Failures are only logged, so a slow or broken shop never prevents adding
+// the wish; an item without a name is then named after the shop.
+func (s *Service) EnrichWish(ctx context.Context, item *models.WishItem) {
+	if item.URL == "" {
+		return
+	}
+	defer func() {
+		if item.Name == "" {
+			item.Name = wishNameFromURL(item.URL)
+		}
+	}()
+	if s.Links == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, wishEnrichTimeout)
+	defer cancel()
+
+	meta, err := s.Links.Fetch(ctx, item.URL)
+	if err != nil {
+		s.logger.WithError(err).Warnf("Failed to fetch metadata for wish URL %s", item.URL)
+		return
+	}
+
+	if item.Name == "" {
+		item.Name = truncateRunes(meta.Title, 500)
+	}
+	if item.Price == "" {
+		item.Price = truncateRunes(meta.FormatPrice(), 100)
+	}
+	if item.ImageURL == "" {
+		item.ImageURL = meta.Image
+	}
+}
+
+// wishNameFromURL names a wish after the shop when the page had no title.
+func wishNameFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return truncateRunes(rawURL, 500)
+	}
+	return strings.TrimPrefix(u.Host, "www.")
+}
+
+// truncateRunes shortens s to at most n characters.
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
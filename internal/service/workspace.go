@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kerhoff/TodoboT/internal/models"
+)
+
+// SelectFamily makes the family the one the user works on in their private
+// chat with the bot. A familyID of 0 goes back to the private chat's own
+// family. The user must be a member of the selected family.
+func (s *Service) SelectFamily(ctx context.Context, userID, familyID int64) error {
+	if familyID != 0 {
+		role, err := s.FamilyRole(ctx, familyID, userID)
+		if err != nil {
+			return err
+		}
+		if role == "" {
+			return ErrNotFamilyMember
+		}
+	}
+
+	if err := s.Users.SetSelectedFamily(ctx, userID, familyID); err != nil {
+		return err
+	}
+
+	s.logger.Infof("User %d selected family %d", userID, familyID)
+	return nil
+}
+
+// SelectedFamily returns the family the user picked for their private chat,
+// or nil if they did not pick one. A selection the user is no longer a
+// member of is dropped.
+func (s *Service) SelectedFamily(ctx context.Context, userID int64) (*models.Family, error) {
+	familyID, err := s.Users.GetSelectedFamily(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if familyID == 0 {
+		return nil, nil
+	}
+
+	role, err := s.FamilyRole(ctx, familyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		if err := s.Users.SetSelectedFamily(ctx, userID, 0); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	family, err := s.Families.GetByID(ctx, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get family %d: %w", familyID, err)
+	}
+	return family, nil
+}
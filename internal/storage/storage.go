@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when a blob does not exist in the store
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore defines the interface for storing binary attachments such as
+// receipt photos. Keys are slash-separated relative paths chosen by the
+// caller.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FileSource defines the interface for reading blobs kept elsewhere, such as
+// files sent to the bot that stay on the Telegram servers.
+type FileSource interface {
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
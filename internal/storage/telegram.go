@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	telegramAPI = "https://api.telegram.org"
+	// maxTelegramFileSize matches the Bot API limit for downloading files
+	maxTelegramFileSize = 20 << 20
+)
+
+// TelegramFiles is a FileSource for files sent to the bot, keyed by their
+// Telegram file_id. File IDs stay valid for the bot that received them, so
+// files need not be copied to be served later.
+type TelegramFiles struct {
+	token  string
+	client *http.Client
+}
+
+// NewTelegramFiles creates a TelegramFiles for the bot with the given token
+func NewTelegramFiles(token string) *TelegramFiles {
+	return &TelegramFiles{token: token, client: &http.Client{Timeout: time.Minute}}
+}
+
+// Open downloads the file with the given file_id
+func (t *TelegramFiles) Open(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	if fileID == "" {
+		return nil, ErrNotFound
+	}
+
+	var info struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+		Result      struct {
+			FilePath string `json:"file_path"`
+		} `json:"result"`
+	}
+	resp, err := t.get(ctx, fmt.Sprintf("%s/bot%s/getFile?file_id=%s", telegramAPI, t.token, url.QueryEscape(fileID)))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode file info: %w", err)
+	}
+	if !info.OK || info.Result.FilePath == "" {
+		if resp.StatusCode == http.StatusBadRequest {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get file info: %s", info.Description)
+	}
+
+	file, err := t.get(ctx, fmt.Sprintf("%s/file/bot%s/%s", telegramAPI, t.token, info.Result.FilePath))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case file.StatusCode == http.StatusNotFound:
+		file.Body.Close()
+		return nil, ErrNotFound
+	case file.StatusCode != http.StatusOK:
+		file.Body.Close()
+		return nil, fmt.Errorf("failed to download file: unexpected status %s", file.Status)
+	case file.ContentLength > maxTelegramFileSize:
+		file.Body.Close()
+		return nil, fmt.Errorf("failed to download file: file too large (%d bytes)", file.ContentLength)
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file.Body, maxTelegramFileSize), file.Body}, nil
+}
+
+// get sends a GET request. Errors leave out the URL, which holds the token.
+func (t *TelegramFiles) get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, errors.New("failed to build Telegram request")
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("failed to reach Telegram: %w", err)
+	}
+	return resp, nil
+}
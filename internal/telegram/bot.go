@@ -6,6 +6,8 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/markup"
 )
 
 // Bot wraps the Telegram bot API
@@ -31,6 +33,17 @@ func NewBot(token string, logger *logrus.Logger) (*Bot, error) {
 	}, nil
 }
 
+// allowedUpdates are the kinds of updates the bot asks for, both when
+// polling and by webhook. chat_member updates are only sent when asked for
+// explicitly.
+var allowedUpdates = []string{
+	tgbotapi.UpdateTypeMessage,
+	tgbotapi.UpdateTypeCallbackQuery,
+	tgbotapi.UpdateTypeInlineQuery,
+	tgbotapi.UpdateTypeMyChatMember,
+	tgbotapi.UpdateTypeChatMember,
+}
+
 // SetWebhook sets up webhook for the bot
 func (b *Bot) SetWebhook(webhookURL string) error {
 	wh, err := tgbotapi.NewWebhook(webhookURL)
@@ -38,6 +51,8 @@ func (b *Bot) SetWebhook(webhookURL string) error {
 		return fmt.Errorf("failed to create webhook: %w", err)
 	}
 
+	wh.AllowedUpdates = allowedUpdates
+
 	_, err = b.api.Request(wh)
 	if err != nil {
 		return fmt.Errorf("failed to set webhook: %w", err)
@@ -57,6 +72,7 @@ func (b *Bot) Start(ctx context.Context) error {
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
+	u.AllowedUpdates = allowedUpdates
 
 	updates := b.api.GetUpdatesChan(u)
 
@@ -91,13 +107,31 @@ func (b *Bot) handleUpdate(update tgbotapi.Update) {
 		b.router.HandleMessage(b.api, update.Message)
 	} else if update.CallbackQuery != nil {
 		b.router.HandleCallbackQuery(b.api, update.CallbackQuery)
+	} else if update.InlineQuery != nil {
+		b.router.HandleInlineQuery(b.api, update.InlineQuery)
+	} else if update.MyChatMember != nil {
+		b.router.HandleChatMember(b.api, update.MyChatMember)
+	} else if update.ChatMember != nil {
+		b.router.HandleChatMember(b.api, update.ChatMember)
 	}
 }
 
 // SendMessage sends a message to a chat
 func (b *Bot) SendMessage(chatID int64, text string) error {
-	msg := tgbotapi.NewMessage(chatID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
+	msg := markup.NewMessage(chatID, text)
+
+	_, err := b.api.Send(msg)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return nil
+}
+
+// SendMessageWithKeyboard sends a message to a chat with inline buttons
+func (b *Bot) SendMessageWithKeyboard(chatID int64, text string, keyboard tgbotapi.InlineKeyboardMarkup) error {
+	msg := markup.NewMessage(chatID, text)
+	msg.ReplyMarkup = keyboard
 
 	_, err := b.api.Send(msg)
 	if err != nil {
@@ -109,8 +143,7 @@ func (b *Bot) SendMessage(chatID int64, text string) error {
 
 // EditMessage edits an existing message
 func (b *Bot) EditMessage(chatID int64, messageID int, text string) error {
-	msg := tgbotapi.NewEditMessageText(chatID, messageID, text)
-	msg.ParseMode = tgbotapi.ModeMarkdown
+	msg := markup.NewEditMessageText(chatID, messageID, text)
 
 	_, err := b.api.Send(msg)
 	if err != nil {
@@ -132,9 +165,41 @@ func (b *Bot) DeleteMessage(chatID int64, messageID int) error {
 	return nil
 }
 
-// RegisterCommand registers a command handler on the router
-func (b *Bot) RegisterCommand(command string, handler CommandHandler) {
-	b.router.RegisterCommand(command, handler)
+// RegisterCommand registers a command handler on the router, to be listed
+// in the given section of /help and in the command menus of the scope
+func (b *Bot) RegisterCommand(command, section string, scope Scope, handler CommandHandler) {
+	b.router.RegisterCommand(command, section, scope, handler)
+}
+
+// Commands returns the registered commands in the order they were
+// registered
+func (b *Bot) Commands() []Command {
+	return b.router.Commands()
+}
+
+// RegisterCallback registers an inline keyboard callback handler
+func (b *Bot) RegisterCallback(prefix string, handler CallbackHandler) {
+	b.router.RegisterCallback(prefix, handler)
+}
+
+// SetMemberHandler sets the handler for chat membership changes
+func (b *Bot) SetMemberHandler(handler MemberHandler) {
+	b.router.SetMemberHandler(handler)
+}
+
+// AddReplyHandler adds a handler for messages that are not commands
+func (b *Bot) AddReplyHandler(handler ReplyHandler) {
+	b.router.AddReplyHandler(handler)
+}
+
+// SetInlineHandler sets the handler for inline queries
+func (b *Bot) SetInlineHandler(handler InlineHandler) {
+	b.router.SetInlineHandler(handler)
+}
+
+// SetLocalizer sets what picks the language of the router's own replies
+func (b *Bot) SetLocalizer(localizer Localizer) {
+	b.router.SetLocalizer(localizer)
 }
 
 // SendRaw sends a raw tgbotapi.Chattable message
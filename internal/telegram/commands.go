@@ -0,0 +1,93 @@
+package telegram
+
+import (
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+)
+
+// Scope says in which of the command menus Telegram clients show a command
+// is listed. Commands can be typed anywhere regardless.
+type Scope int
+
+const (
+	// Family commands are listed in group chats.
+	Family Scope = 1 << iota
+	// Personal commands are listed in private chats.
+	Personal
+	// Admin commands are listed for the admins of group chats only.
+	Admin
+
+	// Everywhere commands are listed in group and private chats.
+	Everywhere = Family | Personal
+)
+
+// Command describes a registered command. Its description in the menus is
+// the message "command.<name>" and its lines in /help are the message
+// "help.<name>".
+type Command struct {
+	Name string
+	// Section groups commands in /help, under the heading
+	// "help.section.<section>". Commands without one are left out of /help.
+	Section string
+	Scope   Scope
+}
+
+// menus are the command menus published: the commands listed in each are
+// those with any of its scopes.
+var menus = []struct {
+	scope  tgbotapi.BotCommandScope
+	listed Scope
+}{
+	{tgbotapi.NewBotCommandScopeAllPrivateChats(), Personal},
+	{tgbotapi.NewBotCommandScopeAllGroupChats(), Family},
+	// Telegram shows admins only the most specific menu, so theirs repeats
+	// the group chats' one
+	{tgbotapi.NewBotCommandScopeAllChatAdministrators(), Family | Admin},
+}
+
+// Commands returns the registered commands in the order they were
+// registered
+func (r *Router) Commands() []Command {
+	return r.commands
+}
+
+// menu returns the commands with any of the scopes, described in lang
+func (r *Router) menu(lang string, listed Scope) []tgbotapi.BotCommand {
+	var commands []tgbotapi.BotCommand
+	for _, command := range r.commands {
+		if command.Scope&listed == 0 {
+			continue
+		}
+		commands = append(commands, tgbotapi.BotCommand{
+			Command:     command.Name,
+			Description: i18n.T(lang, "command."+command.Name),
+		})
+	}
+	return commands
+}
+
+// PublishCommands sets the command menus Telegram clients show, in every
+// supported language. Users whose language is not supported get the default
+// one's.
+func (b *Bot) PublishCommands() error {
+	for _, menu := range menus {
+		for _, lang := range i18n.Languages() {
+			commands := b.router.menu(lang, menu.listed)
+			if _, err := b.api.Request(tgbotapi.NewSetMyCommandsWithScopeAndLanguage(menu.scope, lang, commands...)); err != nil {
+				return fmt.Errorf("failed to set %s commands in %s: %w", menu.scope.Type, lang, err)
+			}
+			if lang != i18n.Default {
+				continue
+			}
+			if _, err := b.api.Request(tgbotapi.NewSetMyCommandsWithScope(menu.scope, commands...)); err != nil {
+				return fmt.Errorf("failed to set %s commands: %w", menu.scope.Type, err)
+			}
+		}
+	}
+
+	b.logger.Infof("Published %d commands", len(b.router.commands))
+	return nil
+}
@@ -0,0 +1,227 @@
+package telegram
+
+import (
+	"fmt"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
+)
+
+// DatePicker is an inline keyboard for picking a day, and optionally a time
+// of day, in a few taps: a month grid with buttons to the previous and next
+// month, then the hour, then the minute.
+//
+// Every button's callback data is Prefix followed by the picker's state.
+// The callback handler registered for the prefix passes what follows it to
+// Update, which returns the keyboard to show next or what was picked.
+// Telegram allows 64 bytes of callback data, of which the picker takes 17.
+type DatePicker struct {
+	// Prefix starts the callback data of every button, e.g. "deadline:7:".
+	Prefix string
+	// Lang is the language of weekday and month names.
+	Lang string
+	// Time asks for the hour and minute after the day.
+	Time bool
+	// NoTime labels a button next to the hours that picks the day without
+	// a time, e.g. for all-day events. Without a label there is none.
+	NoTime string
+	// From is the earliest moment that can be picked; earlier days and
+	// times are left blank. The zero time allows any.
+	From time.Time
+	// Footer is a row of the caller's own buttons, e.g. to cancel, shown
+	// under every page of the picker.
+	Footer []tgbotapi.InlineKeyboardButton
+}
+
+// Callback data of the picker's pages and picks, after Prefix.
+const (
+	pickerNoop   = "n"
+	pickerMonth  = "m" // m2026-11: show the month
+	pickerDay    = "d" // d2026-11-03: pick the day
+	pickerHour   = "h" // h2026-11-03T18: pick the hour
+	pickerMinute = "t" // t2026-11-03T18:30: pick the minute
+	pickerNoTime = "a" // a2026-11-03: pick the day without a time
+)
+
+// Keyboard returns the picker's month grid for the month containing month.
+func (p DatePicker) Keyboard(month time.Time) tgbotapi.InlineKeyboardMarkup {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	// Header: previous month, this month, next month
+	prev := p.noop(" ")
+	if p.From.IsZero() || first.After(p.From) {
+		prev = p.button("«", pickerMonth+first.AddDate(0, -1, 0).Format("2006-01"))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		prev,
+		p.noop(i18n.Date(p.Lang, first, "Jan 2006")),
+		p.button("»", pickerMonth+first.AddDate(0, 1, 0).Format("2006-01")),
+	))
+
+	// Weekday names, starting on Monday
+	monday := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+	var names []tgbotapi.InlineKeyboardButton
+	for i := range 7 {
+		names = append(names, p.noop(i18n.Date(p.Lang, monday.AddDate(0, 0, i), "Mon")))
+	}
+	rows = append(rows, names)
+
+	// Days, padded to whole weeks
+	var week []tgbotapi.InlineKeyboardButton
+	for range (int(first.Weekday()) + 6) % 7 {
+		week = append(week, p.noop(" "))
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		label := fmt.Sprint(day.Day())
+		if day.Equal(today) {
+			label = "[" + label + "]"
+		}
+		if p.before(day.AddDate(0, 0, 1)) {
+			week = append(week, p.noop(" "))
+		} else {
+			week = append(week, p.button(label, pickerDay+day.Format("2006-01-02")))
+		}
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = nil
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, p.noop(" "))
+		}
+		rows = append(rows, week)
+	}
+
+	return p.markup(rows)
+}
+
+// Update handles a press on one of the picker's buttons, given the callback
+// data after Prefix. It returns the keyboard to show next or, once the user
+// is done, the picked moment and whether it has a time of day. A press on a
+// label, or on a day or time before From, returns neither.
+func (p DatePicker) Update(data string) (next *tgbotapi.InlineKeyboardMarkup, picked time.Time, withTime bool) {
+	kind, value := data[:min(len(data), 1)], data[min(len(data), 1):]
+
+	switch kind {
+	case pickerMonth:
+		month, err := time.ParseInLocation("2006-01", value, time.Local)
+		if err != nil {
+			return nil, time.Time{}, false
+		}
+		keyboard := p.Keyboard(month)
+		return &keyboard, time.Time{}, false
+
+	case pickerDay:
+		day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil || p.before(day.AddDate(0, 0, 1)) {
+			return nil, time.Time{}, false
+		}
+		if !p.Time {
+			return nil, day, false
+		}
+		keyboard := p.hours(day)
+		return &keyboard, time.Time{}, false
+
+	case pickerHour:
+		hour, err := time.ParseInLocation("2006-01-02T15", value, time.Local)
+		if err != nil || p.before(hour.Add(time.Hour)) {
+			return nil, time.Time{}, false
+		}
+		keyboard := p.minutes(hour)
+		return &keyboard, time.Time{}, false
+
+	case pickerMinute:
+		t, err := time.ParseInLocation("2006-01-02T15:04", value, time.Local)
+		if err != nil || p.before(t.Add(time.Minute)) {
+			return nil, time.Time{}, false
+		}
+		return nil, t, true
+
+	case pickerNoTime:
+		day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil || p.NoTime == "" || p.before(day.AddDate(0, 0, 1)) {
+			return nil, time.Time{}, false
+		}
+		return nil, day, false
+	}
+	return nil, time.Time{}, false
+}
+
+// hours returns the page for picking the hour on day.
+func (p DatePicker) hours(day time.Time) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{{
+		p.button("«", pickerMonth+day.Format("2006-01")),
+		p.noop(i18n.Date(p.Lang, day, "Mon, 02 Jan 2006")),
+	}}
+
+	var row []tgbotapi.InlineKeyboardButton
+	for h := range 24 {
+		hour := time.Date(day.Year(), day.Month(), day.Day(), h, 0, 0, 0, time.Local)
+		if p.before(hour.Add(time.Hour)) {
+			row = append(row, p.noop(" "))
+		} else {
+			row = append(row, p.button(hour.Format("15"), pickerHour+hour.Format("2006-01-02T15")))
+		}
+		if len(row) == 6 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	if p.NoTime != "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(p.button(p.NoTime, pickerNoTime+day.Format("2006-01-02"))))
+	}
+	return p.markup(rows)
+}
+
+// minutes returns the page for picking the minute in the hour, in steps of
+// five minutes.
+func (p DatePicker) minutes(hour time.Time) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{{
+		p.button("«", pickerDay+hour.Format("2006-01-02")),
+		p.noop(i18n.Date(p.Lang, hour, "Mon, 02 Jan 2006")),
+	}}
+
+	var row []tgbotapi.InlineKeyboardButton
+	for m := 0; m < 60; m += 5 {
+		t := hour.Add(time.Duration(m) * time.Minute)
+		if p.before(t.Add(time.Minute)) {
+			row = append(row, p.noop(" "))
+		} else {
+			row = append(row, p.button(t.Format("15:04"), pickerMinute+t.Format("2006-01-02T15:04")))
+		}
+		if len(row) == 6 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	return p.markup(rows)
+}
+
+// before reports whether a period ending at end is over before From.
+func (p DatePicker) before(end time.Time) bool {
+	return !p.From.IsZero() && !end.After(p.From)
+}
+
+func (p DatePicker) button(label, data string) tgbotapi.InlineKeyboardButton {
+	return tgbotapi.NewInlineKeyboardButtonData(label, p.Prefix+data)
+}
+
+// noop is a button that only shows a label.
+func (p DatePicker) noop(label string) tgbotapi.InlineKeyboardButton {
+	return p.button(label, pickerNoop)
+}
+
+func (p DatePicker) markup(rows [][]tgbotapi.InlineKeyboardButton) tgbotapi.InlineKeyboardMarkup {
+	if len(p.Footer) > 0 {
+		rows = append(rows, p.Footer)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
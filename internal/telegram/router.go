@@ -5,12 +5,20 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/sirupsen/logrus"
+
+	"github.com/Kerhoff/TodoboT/internal/i18n"
 )
 
 // Router handles message routing and command parsing
 type Router struct {
 	logger   *logrus.Logger
-	handlers map[string]CommandHandler
+	handlers  map[string]CommandHandler
+	commands  []Command
+	callbacks map[string]CallbackHandler
+	members   MemberHandler
+	localizer Localizer
+	replies   []ReplyHandler
+	inline    InlineHandler
 }
 
 // CommandHandler defines the interface for command handlers
@@ -18,20 +26,134 @@ type CommandHandler interface {
 	Handle(bot *tgbotapi.BotAPI, message *tgbotapi.Message, args []string) error
 }
 
+// CallbackHandler defines the interface for inline keyboard button handlers.
+// Callback data has the form "prefix:data"; the handler registered for the
+// prefix receives the data part.
+type CallbackHandler interface {
+	HandleCallback(bot *tgbotapi.BotAPI, query *tgbotapi.CallbackQuery, data string) error
+}
+
+// MemberHandler is notified when a user joins or leaves a chat, including
+// the bot itself. Telegram reports the same change both as a service message
+// and as a chat member update, so implementations must be idempotent.
+type MemberHandler interface {
+	HandleMember(bot *tgbotapi.BotAPI, chat *tgbotapi.Chat, user *tgbotapi.User, joined bool) error
+}
+
+// ReplyHandler handles messages that are not commands, e.g. answers in
+// multi-step conversations. It reports whether it took the message.
+type ReplyHandler interface {
+	HandleReply(bot *tgbotapi.BotAPI, message *tgbotapi.Message) (bool, error)
+}
+
+// InlineHandler answers inline queries, which users type as "@bot query"
+// in any chat.
+type InlineHandler interface {
+	HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) error
+}
+
+// Localizer picks the language to talk to a user in a chat.
+type Localizer interface {
+	Language(chat *tgbotapi.Chat, user *tgbotapi.User) string
+}
+
 // NewRouter creates a new message router
 func NewRouter(logger *logrus.Logger) *Router {
 	return &Router{
-		logger:   logger,
-		handlers: make(map[string]CommandHandler),
+		logger:    logger,
+		handlers:  make(map[string]CommandHandler),
+		callbacks: make(map[string]CallbackHandler),
 	}
 }
 
-// RegisterCommand registers a command handler
-func (r *Router) RegisterCommand(command string, handler CommandHandler) {
+// RegisterCommand registers a command handler. The section and scope say
+// where /help and the command menus list the command.
+func (r *Router) RegisterCommand(command, section string, scope Scope, handler CommandHandler) {
 	r.handlers[command] = handler
+	r.commands = append(r.commands, Command{Name: command, Section: section, Scope: scope})
 	r.logger.Debugf("Registered command: %s", command)
 }
 
+// RegisterCallback registers a handler for callback data starting with
+// prefix and a colon
+func (r *Router) RegisterCallback(prefix string, handler CallbackHandler) {
+	r.callbacks[prefix] = handler
+	r.logger.Debugf("Registered callback: %s", prefix)
+}
+
+// SetMemberHandler sets the handler for chat membership changes
+func (r *Router) SetMemberHandler(handler MemberHandler) {
+	r.members = handler
+}
+
+// AddReplyHandler adds a handler for messages that are not commands. The
+// handlers are tried in the order they were added until one takes the
+// message.
+func (r *Router) AddReplyHandler(handler ReplyHandler) {
+	r.replies = append(r.replies, handler)
+}
+
+// SetInlineHandler sets the handler for inline queries
+func (r *Router) SetInlineHandler(handler InlineHandler) {
+	r.inline = handler
+}
+
+// SetLocalizer sets what picks the language of the router's own replies
+func (r *Router) SetLocalizer(localizer Localizer) {
+	r.localizer = localizer
+}
+
+// language returns the language to reply to the message in
+func (r *Router) language(message *tgbotapi.Message) string {
+	if r.localizer != nil {
+		return r.localizer.Language(message.Chat, message.From)
+	}
+	if lang := i18n.Match(message.From.LanguageCode); lang != "" {
+		return lang
+	}
+	return i18n.Default
+}
+
+// HandleChatMember handles my_chat_member and chat_member updates
+func (r *Router) HandleChatMember(bot *tgbotapi.BotAPI, update *tgbotapi.ChatMemberUpdated) {
+	wasIn, isIn := IsChatMember(update.OldChatMember), IsChatMember(update.NewChatMember)
+	if wasIn == isIn || update.NewChatMember.User == nil {
+		return
+	}
+	r.handleMember(bot, &update.Chat, update.NewChatMember.User, isIn)
+}
+
+// IsChatMember reports whether the status counts as being in the chat
+func IsChatMember(member tgbotapi.ChatMember) bool {
+	switch member.Status {
+	case "creator", "administrator", "member":
+		return true
+	case "restricted":
+		return member.IsMember
+	}
+	return false
+}
+
+func (r *Router) handleMember(bot *tgbotapi.BotAPI, chat *tgbotapi.Chat, user *tgbotapi.User, joined bool) {
+	if r.members == nil {
+		return
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"chat_id": chat.ID,
+		"user_id": user.ID,
+		"joined":  joined,
+	}).Info("Chat membership changed")
+
+	if err := r.members.HandleMember(bot, chat, user, joined); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"chat_id": chat.ID,
+			"user_id": user.ID,
+			"error":   err,
+		}).Error("Member handler failed")
+	}
+}
+
 // HandleMessage handles incoming messages
 func (r *Router) HandleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
 	// Log the incoming message
@@ -43,13 +165,30 @@ func (r *Router) HandleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message)
 		"text":       message.Text,
 	}).Info("Received message")
 
+	// Service messages about users joining or leaving
+	for i := range message.NewChatMembers {
+		r.handleMember(bot, message.Chat, &message.NewChatMembers[i], true)
+	}
+	if message.LeftChatMember != nil {
+		r.handleMember(bot, message.Chat, message.LeftChatMember, false)
+	}
+
+	// Photos and documents carry their command in the caption
+	if message.Text == "" && message.Caption != "" {
+		captioned := *message
+		captioned.Text = message.Caption
+		captioned.Entities = message.CaptionEntities
+		message = &captioned
+	}
+
 	// Only process text messages
 	if message.Text == "" {
 		return
 	}
 
-	// Check if it's a command
+	// Plain text may answer a question the bot asked
 	if !message.IsCommand() {
+		r.handleReply(bot, message)
 		return
 	}
 
@@ -67,8 +206,8 @@ func (r *Router) HandleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message)
 			}).Error("Command handler failed")
 
 			// Send error message to user
-			errorMsg := tgbotapi.NewMessage(message.Chat.ID, "❌ An error occurred while processing your command. Please try again.")
-			bot.Send(errorMsg)
+			errorMsg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(r.language(message), "router.error"))
+			Send(bot, r.logger, errorMsg)
 		}
 	} else {
 		// Unknown command
@@ -78,11 +217,45 @@ func (r *Router) HandleMessage(bot *tgbotapi.BotAPI, message *tgbotapi.Message)
 			"user_id": message.From.ID,
 		}).Warn("Unknown command")
 
-		unknownMsg := tgbotapi.NewMessage(message.Chat.ID, "❓ Unknown command. Use /help to see available commands.")
-		bot.Send(unknownMsg)
+		unknownMsg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(r.language(message), "router.unknown"))
+		Send(bot, r.logger, unknownMsg)
 	}
 }
 
+// handleReply passes a message that is not a command on to the reply
+// handlers until one takes it
+func (r *Router) handleReply(bot *tgbotapi.BotAPI, message *tgbotapi.Message) {
+	for _, replies := range r.replies {
+		if r.reply(bot, message, replies) {
+			return
+		}
+	}
+}
+
+// reply passes the message to one reply handler and reports whether it
+// took the message. Failed handlers count as having taken it.
+func (r *Router) reply(bot *tgbotapi.BotAPI, message *tgbotapi.Message, replies ReplyHandler) bool {
+	handled, err := replies.HandleReply(bot, message)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"chat_id": message.Chat.ID,
+			"user_id": message.From.ID,
+			"error":   err,
+		}).Error("Reply handler failed")
+
+		errorMsg := tgbotapi.NewMessage(message.Chat.ID, i18n.T(r.language(message), "router.error"))
+		Send(bot, r.logger, errorMsg)
+		return true
+	}
+	if handled {
+		r.logger.WithFields(logrus.Fields{
+			"chat_id": message.Chat.ID,
+			"user_id": message.From.ID,
+		}).Debug("Message handled by reply handler")
+	}
+	return handled
+}
+
 // HandleCallbackQuery handles callback queries from inline keyboards
 func (r *Router) HandleCallbackQuery(bot *tgbotapi.BotAPI, callbackQuery *tgbotapi.CallbackQuery) {
 	// Log the callback query
@@ -96,5 +269,38 @@ func (r *Router) HandleCallbackQuery(bot *tgbotapi.BotAPI, callbackQuery *tgbota
 	callback := tgbotapi.NewCallback(callbackQuery.ID, "")
 	bot.Request(callback)
 
-	// TODO: Implement callback query routing when needed
+	prefix, data, _ := strings.Cut(callbackQuery.Data, ":")
+	handler, exists := r.callbacks[prefix]
+	if !exists {
+		r.logger.WithField("data", callbackQuery.Data).Warn("Unknown callback")
+		return
+	}
+
+	if err := handler.HandleCallback(bot, callbackQuery, data); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"callback": prefix,
+			"user_id":  callbackQuery.From.ID,
+			"error":    err,
+		}).Error("Callback handler failed")
+	}
+}
+
+// HandleInlineQuery handles inline queries
+func (r *Router) HandleInlineQuery(bot *tgbotapi.BotAPI, query *tgbotapi.InlineQuery) {
+	r.logger.WithFields(logrus.Fields{
+		"inline_query_id": query.ID,
+		"user_id":         query.From.ID,
+		"query":           query.Query,
+	}).Info("Received inline query")
+
+	if r.inline == nil {
+		return
+	}
+
+	if err := r.inline.HandleInlineQuery(bot, query); err != nil {
+		r.logger.WithFields(logrus.Fields{
+			"user_id": query.From.ID,
+			"error":   err,
+		}).Error("Inline handler failed")
+	}
 }
\ No newline at end of file
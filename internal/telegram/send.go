@@ -0,0 +1,20 @@
+package telegram
+
+import (
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// Send sends c and logs a failure. Replies are best effort: a handler that
+// could not answer has still done its work, so the failure is not returned,
+// but it is not dropped silently either. Edits that change nothing, e.g.
+// when a button is pressed twice, are not failures.
+func Send(bot *tgbotapi.BotAPI, logger logrus.FieldLogger, c tgbotapi.Chattable) {
+	_, err := bot.Send(c)
+	if err == nil || strings.Contains(err.Error(), "message is not modified") {
+		return
+	}
+	logger.WithError(err).Errorf("Failed to send %T", c)
+}
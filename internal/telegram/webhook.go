@@ -0,0 +1,32 @@
+package telegram
+
+import (
+	"net/http"
+)
+
+// maxUpdateSize bounds the body of a webhook request. Updates are far
+// smaller.
+const maxUpdateSize = 1 << 20
+
+// WebhookHandler serves the updates Telegram posts to the URL given to
+// SetWebhook. Each update is answered right away and handled in the
+// background, as when polling.
+func (b *Bot) WebhookHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxUpdateSize)
+		update, err := b.api.HandleUpdate(r)
+		if err != nil {
+			b.logger.WithError(err).Warn("Failed to read webhook update")
+			http.Error(w, "bad update", http.StatusBadRequest)
+			return
+		}
+
+		b.HandleWebhook(*update)
+	})
+}
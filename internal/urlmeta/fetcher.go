@@ -0,0 +1,196 @@
+// Package urlmeta extracts product metadata (title, price, image) from web
+// pages so wish items added by URL can be filled in automatically.
+package urlmeta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds the whole request including redirects.
+	DefaultTimeout = 5 * time.Second
+	// DefaultMaxBytes is how much of a page is read before giving up.
+	DefaultMaxBytes = 1 << 20
+	maxRedirects    = 5
+)
+
+var (
+	// ErrUnsupportedURL is returned for anything that is not http(s).
+	ErrUnsupportedURL = errors.New("only http and https URLs are supported")
+	// ErrPrivateAddress is returned when a URL resolves to a loopback,
+	// private or otherwise internal address.
+	ErrPrivateAddress = errors.New("refusing to connect to a private address")
+	// ErrNotHTML is returned when the page is not an HTML document.
+	ErrNotHTML = errors.New("not an HTML page")
+)
+
+// Metadata is what could be learned about a page. Fields that were not
+// found are left empty.
+type Metadata struct {
+	Title    string
+	Price    string
+	Currency string
+	Image    string
+}
+
+// Fetcher loads metadata for a URL.
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*Metadata, error)
+}
+
+// Options configures an HTTPFetcher. Zero values use the defaults.
+type Options struct {
+	Timeout  time.Duration
+	MaxBytes int64
+}
+
+// HTTPFetcher fetches pages over HTTP(S) and parses their OpenGraph and
+// JSON-LD metadata.
+type HTTPFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+// NewHTTPFetcher creates an HTTPFetcher. Connections to internal addresses
+// are refused after DNS resolution, so redirects and DNS tricks cannot reach
+// them either.
+func NewHTTPFetcher(opts Options) *HTTPFetcher {
+	return newHTTPFetcher(opts, isPrivateAddress)
+}
+
+// newHTTPFetcher creates an HTTPFetcher that refuses to connect to the
+// "host:port" addresses blocked reports.
+func newHTTPFetcher(opts Options, blocked func(address string) bool) *HTTPFetcher {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+
+	dialer := &net.Dialer{
+		Timeout: opts.Timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if blocked(address) {
+				return ErrPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		// No proxy: the address check must apply to the real destination
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   opts.Timeout,
+		ResponseHeaderTimeout: opts.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return ErrUnsupportedURL
+				}
+				return nil
+			},
+		},
+		maxBytes: opts.MaxBytes,
+	}
+}
+
+// Fetch downloads the page and extracts its metadata.
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (*Metadata, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrUnsupportedURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; TodoboT/1.0; +https://github.com/Kerhoff/TodoboT)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", u.Host, resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "html") {
+		return nil, ErrNotHTML
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", u.Host, err)
+	}
+
+	meta := Parse(string(body))
+	if meta.Image != "" {
+		meta.Image = resolveURL(resp.Request.URL, meta.Image)
+	}
+	return meta, nil
+}
+
+// isPrivateAddress reports whether the dialed "host:port" address is not a
+// public unicast one.
+func isPrivateAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip == nil || isPrivateIP(ip)
+}
+
+// isPrivateIP reports whether ip is not a public unicast address.
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	// Carrier-grade NAT (100.64.0.0/10) is not covered by IsPrivate
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return true
+	}
+	return false
+}
+
+// resolveURL makes a possibly relative reference absolute.
+func resolveURL(base *url.URL, ref string) string {
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	abs := base.ResolveReference(r)
+	if abs.Scheme != "http" && abs.Scheme != "https" {
+		return ""
+	}
+	return abs.String()
+}
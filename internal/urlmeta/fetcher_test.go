@@ -0,0 +1,193 @@
+package urlmeta
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testFetcher returns a fetcher that treats public as a public address and
+// every other address as private.
+func testFetcher(opts Options, public *httptest.Server) *HTTPFetcher {
+	allowed := public.Listener.Addr().String()
+	return newHTTPFetcher(opts, func(address string) bool {
+		return address != allowed
+	})
+}
+
+func servePage(t *testing.T, contentType, page string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(page))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want Metadata
+	}{
+		{
+			name: "opengraph",
+			page: `<html><head><title>Shop | Kettle</title>
+				<meta property="og:title" content="Kettle &amp; Cups">
+				<meta property="product:price:amount" content="49.99">
+				<meta property="product:price:currency" content="EUR">
+				<meta property="og:image" content="/img/kettle.jpg">
+				</head></html>`,
+			want: Metadata{Title: "Kettle & Cups", Price: "49.99", Currency: "EUR", Image: "/img/kettle.jpg"},
+		},
+		{
+			name: "json-ld over opengraph",
+			page: `<meta property="og:title" content="Generic">
+				<script type="application/ld+json">
+				{"@graph": [{"@type": "Product", "name": "Kettle", "image": ["https://cdn.example.com/k.jpg"],
+				"offers": {"price": 1200, "priceCurrency": "RUB"}}]}
+				</script>`,
+			want: Metadata{Title: "Kettle", Price: "1200", Currency: "RUB", Image: "https://cdn.example.com/k.jpg"},
+		},
+		{
+			name: "title element",
+			page: "<title>\n  Plain   page </title>",
+			want: Metadata{Title: "Plain page"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := servePage(t, "text/html; charset=utf-8", tt.page)
+			want := tt.want
+			if strings.HasPrefix(want.Image, "/") {
+				want.Image = srv.URL + want.Image
+			}
+
+			meta, err := testFetcher(Options{}, srv).Fetch(context.Background(), srv.URL+"/item")
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+			if *meta != want {
+				t.Errorf("Fetch = %+v, want %+v", *meta, want)
+			}
+		})
+	}
+}
+
+func TestFetchMaxBytes(t *testing.T) {
+	page := "<title>Early</title>" + strings.Repeat(" ", 100) + `<meta property="og:title" content="Late">`
+	srv := servePage(t, "text/html", page)
+
+	meta, err := testFetcher(Options{MaxBytes: 64}, srv).Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if meta.Title != "Early" {
+		t.Errorf("Title = %q, want the part before the limit", meta.Title)
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	_, err := testFetcher(Options{Timeout: 100 * time.Millisecond}, srv).Fetch(context.Background(), srv.URL)
+	if err == nil {
+		t.Fatal("Fetch succeeded, want a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Fetch took %v", elapsed)
+	}
+}
+
+func TestFetchRedirectToPrivate(t *testing.T) {
+	var reached atomic.Bool
+	private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached.Store(true)
+	}))
+	defer private.Close()
+	public := httptest.NewServer(http.RedirectHandler(private.URL+"/admin", http.StatusFound))
+	defer public.Close()
+
+	_, err := testFetcher(Options{}, public).Fetch(context.Background(), public.URL)
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Fetch error = %v, want %v", err, ErrPrivateAddress)
+	}
+	if reached.Load() {
+		t.Error("the private server was reached")
+	}
+}
+
+func TestFetchRejectsLoopback(t *testing.T) {
+	srv := servePage(t, "text/html", "<title>Internal</title>")
+
+	_, err := NewHTTPFetcher(Options{}).Fetch(context.Background(), srv.URL)
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("Fetch error = %v, want %v", err, ErrPrivateAddress)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	srv := servePage(t, "application/json", `{"title": "not a page"}`)
+	fetcher := testFetcher(Options{}, srv)
+
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{srv.URL, ErrNotHTML},
+		{"ftp://example.com/file", ErrUnsupportedURL},
+		{"file:///etc/passwd", ErrUnsupportedURL},
+		{"http://", ErrUnsupportedURL},
+	}
+
+	for _, tt := range tests {
+		if _, err := fetcher.Fetch(context.Background(), tt.url); !errors.Is(err, tt.want) {
+			t.Errorf("Fetch(%q) error = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		private bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"100.127.255.255", true},
+		{"0.0.0.0", true},
+		{"224.0.0.1", true},
+		{"::1", true},
+		{"fc00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"100.128.0.1", false},
+		{"93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPrivateIP(net.ParseIP(tt.ip)); got != tt.private {
+			t.Errorf("isPrivateIP(%s) = %v, want %v", tt.ip, got, tt.private)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package urlmeta
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	metaTagRegex    = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attrRegex       = regexp.MustCompile(`(?s)([a-zA-Z:_-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	titleTagRegex   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	jsonLDRegex     = regexp.MustCompile(`(?is)<script[^>]+type\s*=\s*["']application/ld\+json["'][^>]*>(.*?)</script>`)
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// Parse extracts metadata from an HTML document. JSON-LD Product data wins
+// over OpenGraph tags, which win over the <title> element.
+func Parse(doc string) *Metadata {
+	meta := &Metadata{}
+
+	for _, block := range jsonLDRegex.FindAllStringSubmatch(doc, -1) {
+		var data any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(block[1])), &data); err != nil {
+			continue
+		}
+		if product := findProduct(data); product != nil {
+			fromJSONLD(meta, product)
+			break
+		}
+	}
+
+	tags := metaTags(doc)
+	fill(&meta.Title, tags["og:title"], tags["twitter:title"])
+	fill(&meta.Price, tags["product:price:amount"], tags["og:price:amount"])
+	fill(&meta.Currency, tags["product:price:currency"], tags["og:price:currency"])
+	fill(&meta.Image, tags["og:image"], tags["og:image:url"], tags["twitter:image"])
+
+	if meta.Title == "" {
+		if m := titleTagRegex.FindStringSubmatch(doc); m != nil {
+			meta.Title = clean(m[1])
+		}
+	}
+
+	return meta
+}
+
+// FormatPrice joins amount and currency for display, e.g. "49.99 EUR".
+func (m *Metadata) FormatPrice() string {
+	if m.Price == "" {
+		return ""
+	}
+	if m.Currency == "" {
+		return m.Price
+	}
+	return m.Price + " " + m.Currency
+}
+
+// metaTags collects <meta property|name=... content=...> pairs. The first
+// occurrence of a key wins.
+func metaTags(doc string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range metaTagRegex.FindAllString(doc, -1) {
+		attrs := make(map[string]string)
+		for _, a := range attrRegex.FindAllStringSubmatch(tag, -1) {
+			value := a[2]
+			if value == "" {
+				value = a[3]
+			}
+			attrs[strings.ToLower(a[1])] = value
+		}
+
+		key := attrs["property"]
+		if key == "" {
+			key = attrs["name"]
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if _, seen := tags[key]; !seen {
+			tags[key] = clean(attrs["content"])
+		}
+	}
+	return tags
+}
+
+// findProduct walks decoded JSON-LD looking for an object of type Product.
+func findProduct(data any) map[string]any {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			if p := findProduct(item); p != nil {
+				return p
+			}
+		}
+	case map[string]any:
+		if hasType(v["@type"], "Product") {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findProduct(graph)
+		}
+	}
+	return nil
+}
+
+func hasType(t any, want string) bool {
+	switch v := t.(type) {
+	case string:
+		return v == want
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fromJSONLD(meta *Metadata, product map[string]any) {
+	meta.Title = clean(stringValue(product["name"]))
+	meta.Image = imageValue(product["image"])
+
+	offers := product["offers"]
+	if list, ok := offers.([]any); ok && len(list) > 0 {
+		offers = list[0]
+	}
+	if offer, ok := offers.(map[string]any); ok {
+		meta.Price = stringValue(offer["price"])
+		if meta.Price == "" {
+			meta.Price = stringValue(offer["lowPrice"])
+		}
+		meta.Currency = stringValue(offer["priceCurrency"])
+	}
+}
+
+func imageValue(v any) string {
+	switch img := v.(type) {
+	case string:
+		return img
+	case []any:
+		if len(img) > 0 {
+			return imageValue(img[0])
+		}
+	case map[string]any:
+		return stringValue(img["url"])
+	}
+	return ""
+}
+
+func stringValue(v any) string {
+	switch s := v.(type) {
+	case string:
+		return strings.TrimSpace(s)
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	}
+	return ""
+}
+
+// fill sets dst to the first non-empty candidate unless it is already set.
+func fill(dst *string, candidates ...string) {
+	if *dst != "" {
+		return
+	}
+	for _, c := range candidates {
+		if c != "" {
+			*dst = c
+			return
+		}
+	}
+}
+
+func clean(s string) string {
+	return strings.TrimSpace(whitespaceRegex.ReplaceAllString(html.UnescapeString(s), " "))
+}
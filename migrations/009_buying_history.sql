@@ -0,0 +1,11 @@
+-- Keep bought items as purchase history instead of deleting them
+ALTER TABLE buying_items ADD COLUMN IF NOT EXISTS price NUMERIC(12, 2);
+ALTER TABLE buying_items ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE buying_items ADD COLUMN IF NOT EXISTS bought_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE buying_items ADD COLUMN IF NOT EXISTS archived BOOLEAN DEFAULT false;
+
+-- Items bought before history tracking existed get their creation time
+UPDATE buying_items SET bought_at = created_at WHERE bought = true AND bought_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_buying_items_archived ON buying_items(buying_list_id, archived);
+CREATE INDEX IF NOT EXISTS idx_buying_items_bought_at ON buying_items(buying_list_id, bought_at) WHERE bought = true;
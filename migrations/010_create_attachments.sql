@@ -0,0 +1,34 @@
+-- Create shopping_trips table: a trip groups the items archived by one /buyclear
+CREATE TABLE IF NOT EXISTS shopping_trips (
+    id BIGSERIAL PRIMARY KEY,
+    buying_list_id BIGINT NOT NULL REFERENCES buying_lists(id) ON DELETE CASCADE,
+    completed_by_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    item_count INTEGER NOT NULL DEFAULT 0,
+    total NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shopping_trips_list_id ON shopping_trips(buying_list_id, completed_at);
+
+ALTER TABLE buying_items ADD COLUMN IF NOT EXISTS trip_id BIGINT REFERENCES shopping_trips(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_buying_items_trip_id ON buying_items(trip_id) WHERE trip_id IS NOT NULL;
+
+-- Create attachments table for files stored in the blob store
+CREATE TABLE IF NOT EXISTS attachments (
+    id BIGSERIAL PRIMARY KEY,
+    family_id BIGINT REFERENCES families(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    entity_type VARCHAR(30) NOT NULL CHECK (entity_type IN ('buying_item', 'shopping_trip')),
+    entity_id BIGINT NOT NULL,
+    storage_key VARCHAR(500) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    content_type VARCHAR(100) NOT NULL DEFAULT 'application/octet-stream',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    telegram_file_id VARCHAR(255) NOT NULL DEFAULT '',
+    uploaded_by_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_entity ON attachments(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_chat_id ON attachments(chat_id);
@@ -0,0 +1,2 @@
+-- Let wish list owners opt in to a spoiler-free "something is reserved" hint
+ALTER TABLE wish_lists ADD COLUMN IF NOT EXISTS reserved_hint BOOLEAN NOT NULL DEFAULT false;
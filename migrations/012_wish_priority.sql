@@ -0,0 +1,4 @@
+-- Rank wish items so owners can mark what they want most
+ALTER TABLE wish_items ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_wish_items_priority ON wish_items(wish_list_id, priority);
@@ -0,0 +1,2 @@
+-- Product image found when a wish is added by URL
+ALTER TABLE wish_items ADD COLUMN IF NOT EXISTS image_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,16 @@
+-- Group gifting: several members pledge money toward one wish item. The
+-- member holding the reservation organizes the purchase.
+ALTER TABLE wish_items ADD COLUMN IF NOT EXISTS purchased BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE wish_items ADD COLUMN IF NOT EXISTS purchased_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS wish_pledges (
+    id BIGSERIAL PRIMARY KEY,
+    wish_item_id BIGINT NOT NULL REFERENCES wish_items(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (wish_item_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wish_pledges_item_id ON wish_pledges(wish_item_id);
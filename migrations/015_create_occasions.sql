@@ -0,0 +1,23 @@
+-- Create occasions table: yearly dates such as birthdays and holidays
+CREATE TABLE IF NOT EXISTS occasions (
+    id BIGSERIAL PRIMARY KEY,
+    family_id BIGINT NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'holiday' CHECK (kind IN ('birthday', 'holiday')),
+    title VARCHAR(255) NOT NULL,
+    celebrant_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    month SMALLINT NOT NULL CHECK (month BETWEEN 1 AND 12),
+    day SMALLINT NOT NULL CHECK (day BETWEEN 1 AND 31),
+    year SMALLINT,
+    remind_days_before INTEGER NOT NULL DEFAULT 7 CHECK (remind_days_before >= 0),
+    last_reminded_for DATE,
+    created_by_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_occasions_family_id ON occasions(family_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_occasions_birthday ON occasions(family_id, celebrant_id) WHERE kind = 'birthday';
+
+-- Wish lists can be linked to the occasion they are meant for
+ALTER TABLE wish_lists ADD COLUMN IF NOT EXISTS occasion_id BIGINT REFERENCES occasions(id) ON DELETE SET NULL;
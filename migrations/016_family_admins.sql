@@ -0,0 +1,13 @@
+-- Every family needs an admin: promote the longest-standing member of
+-- families that have none yet
+UPDATE family_members fm
+SET role = 'admin'
+WHERE fm.id IN (
+    SELECT DISTINCT ON (m.family_id) m.id
+    FROM family_members m
+    WHERE NOT EXISTS (
+        SELECT 1 FROM family_members a
+        WHERE a.family_id = m.family_id AND a.role = 'admin'
+    )
+    ORDER BY m.family_id, m.joined_at ASC, m.id ASC
+);
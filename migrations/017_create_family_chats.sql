@@ -0,0 +1,25 @@
+-- A family can span several chats (the group plus members' private chats).
+-- families.chat_id stays the family's home chat; family_chats maps every
+-- chat, including the home chat, to its family.
+CREATE TABLE IF NOT EXISTS family_chats (
+    chat_id BIGINT PRIMARY KEY,
+    family_id BIGINT NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    linked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_family_chats_family_id ON family_chats(family_id);
+
+INSERT INTO family_chats (chat_id, family_id, linked_at)
+SELECT chat_id, id, created_at FROM families
+ON CONFLICT (chat_id) DO NOTHING;
+
+-- One-time codes created by /link and redeemed by /join in another chat
+CREATE TABLE IF NOT EXISTS family_link_codes (
+    code VARCHAR(16) PRIMARY KEY,
+    family_id BIGINT NOT NULL REFERENCES families(id) ON DELETE CASCADE,
+    created_by_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_family_link_codes_family_id ON family_link_codes(family_id);
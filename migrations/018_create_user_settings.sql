@@ -0,0 +1,8 @@
+-- Per-user settings. selected_family_id is the family the user works on in
+-- their private chat with the bot, chosen with /family; NULL means the
+-- private chat's own family.
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    selected_family_id BIGINT REFERENCES families(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
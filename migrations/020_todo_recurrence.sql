@@ -0,0 +1,8 @@
+-- Recurring todos (chores). Completing an occurrence creates the next one,
+-- assigned to the next user in the rotation; previous_id links the two.
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS recurrence VARCHAR(20) NOT NULL DEFAULT 'none'
+    CHECK (recurrence IN ('none', 'daily', 'weekly', 'monthly'));
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS rotation BIGINT[];
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS previous_id BIGINT REFERENCES todos(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_todos_previous_id ON todos(previous_id) WHERE previous_id IS NOT NULL;
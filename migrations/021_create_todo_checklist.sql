@@ -0,0 +1,15 @@
+-- Checklist items (subtasks) of a todo, numbered by position within it
+CREATE TABLE IF NOT EXISTS todo_checklist (
+    id BIGSERIAL PRIMARY KEY,
+    todo_id BIGINT NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    done BOOLEAN DEFAULT FALSE,
+    done_by_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    done_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(todo_id, position)
+);
+
+-- Complete the todo once its whole checklist is ticked off
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
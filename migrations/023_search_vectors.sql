@@ -0,0 +1,36 @@
+-- Full-text search over the family's data. The 'simple' configuration does
+-- not stem, so it works the same for every language; the search itself
+-- matches word prefixes.
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+) STORED;
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(content, '')), 'C')
+) STORED;
+
+ALTER TABLE calendar_events ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '') || ' ' || coalesce(location, '')), 'B')
+) STORED;
+
+ALTER TABLE buying_items ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A')
+) STORED;
+
+ALTER TABLE wish_items ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(notes, '')), 'B')
+) STORED;
+
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(text, '')), 'A')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_todos_search ON todos USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_comments_search ON comments USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_calendar_events_search ON calendar_events USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_buying_items_search ON buying_items USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_wish_items_search ON wish_items USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_reminders_search ON reminders USING GIN (search);
@@ -0,0 +1,5 @@
+-- The language the bot talks in, chosen with /lang: per user for private
+-- chats and per family for group chats. NULL means the user's Telegram app
+-- language.
+ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS language VARCHAR(8);
+ALTER TABLE families ADD COLUMN IF NOT EXISTS language VARCHAR(8);
@@ -0,0 +1,3 @@
+-- Whether the bot reads the family's plain messages for things to buy, do
+-- or be reminded of and offers to save them, turned on with /capture.
+ALTER TABLE families ADD COLUMN IF NOT EXISTS capture BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- Photos, voice notes and documents attached to todos and shopping items
+-- with /add and /buy. Unless DOWNLOAD_MEDIA is set only their Telegram
+-- file_id is kept, so storage_key is empty.
+ALTER TABLE attachments DROP CONSTRAINT IF EXISTS attachments_entity_type_check;
+ALTER TABLE attachments ADD CONSTRAINT attachments_entity_type_check
+    CHECK (entity_type IN ('buying_item', 'shopping_trip', 'todo'));
@@ -0,0 +1,3 @@
+-- Members demoted with /demote stay members even if they administer the
+-- family's Telegram group, which otherwise makes them family admins
+ALTER TABLE family_members ADD COLUMN IF NOT EXISTS demoted BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,14 @@
+-- Multi-step conversations such as /event without arguments. They are kept
+-- here rather than in the bot's memory so they survive restarts and every
+-- instance of the bot sees them.
+CREATE TABLE IF NOT EXISTS dialog_states (
+    chat_id BIGINT NOT NULL,
+    telegram_user_id BIGINT NOT NULL,
+    name VARCHAR(32) NOT NULL,
+    step VARCHAR(32) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (chat_id, telegram_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dialog_states_expires_at ON dialog_states(expires_at);